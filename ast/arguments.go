@@ -0,0 +1,36 @@
+package ast
+
+import (
+	"github.com/Mostafa-DE/delang/token"
+)
+
+// SpreadArgument passes the elements of an array as separate arguments, e.g `add(...numbers)`
+type SpreadArgument struct {
+	Token token.Token // The '...' token
+	Value Expression
+}
+
+func (sa *SpreadArgument) expressionNode() {}
+func (sa *SpreadArgument) TokenLiteral() string {
+	return sa.Token.Literal
+}
+
+func (sa *SpreadArgument) String() string {
+	return "..." + sa.Value.String()
+}
+
+// KeywordArgument passes a value to the parameter with the same name, e.g `greet(name: 'DE')`
+type KeywordArgument struct {
+	Token token.Token // The name token
+	Name  *Identifier
+	Value Expression
+}
+
+func (ka *KeywordArgument) expressionNode() {}
+func (ka *KeywordArgument) TokenLiteral() string {
+	return ka.Token.Literal
+}
+
+func (ka *KeywordArgument) String() string {
+	return ka.Name.String() + ": " + ka.Value.String()
+}
@@ -6,10 +6,12 @@ import (
 	"github.com/Mostafa-DE/delang/token"
 )
 
+// AssignExpression is `target = value`, a compound assignment like `target += value` or `target++`
 type AssignExpression struct {
-	Token token.Token // The token.ASSIGN token
-	Ident *Identifier
-	Value Expression
+	Token    token.Token // The assignment operator token
+	Target   Expression  // An *Identifier, *IndexExpression or *MemberExpression
+	Operator string      // One of = += -= *= /= %= ++ --
+	Value    Expression  // nil for ++ and --
 }
 
 func (assignExpression *AssignExpression) expressionNode() {}
@@ -22,12 +24,33 @@ func (assignExpression *AssignExpression) statementNode() {}
 func (assignExpression *AssignExpression) String() string {
 	var out bytes.Buffer
 
-	out.WriteString(assignExpression.Ident.String())
-	out.WriteString(" = ")
-	if assignExpression.Value != nil {
-		out.WriteString(assignExpression.Value.String())
-		out.WriteString(";")
+	out.WriteString(assignExpression.Target.String())
+
+	if assignExpression.Value == nil {
+		out.WriteString(assignExpression.Operator)
+		return out.String()
 	}
 
+	out.WriteString(" " + assignExpression.Operator + " ")
+	out.WriteString(assignExpression.Value.String())
+	out.WriteString(";")
+
 	return out.String()
 }
+
+// IsAssignable reports whether an expression can be the target of an assignment
+func IsAssignable(expression Expression) bool {
+	switch expression := expression.(type) {
+	case *Identifier:
+		return true
+
+	// An optional link has nothing to assign to when it is null, e.g `user?.name = 'DE'`
+	case *IndexExpression:
+		return !expression.Optional
+
+	case *MemberExpression:
+		return !expression.Optional
+	}
+
+	return false
+}
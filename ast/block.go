@@ -7,7 +7,8 @@ import (
 )
 
 type BlockStatement struct {
-	Token      token.Token // token.LEFTBRAC and token.RIGHTBRAC
+	Token      token.Token // token.LEFTBRAC
+	End        token.Token // The token that closes the block, zero when the block is never closed
 	Statements []Statement
 }
 
@@ -9,14 +9,22 @@ import (
 type ConstStatement struct {
 	Token token.Token // token.CONST
 	Name  *Identifier
-	Value Expression
+	// Pattern destructures the value instead of binding it to Name, Name is nil when it is set
+	Pattern Expression
+	Value   Expression
 }
 
 func (constStatement *ConstStatement) String() string {
 	var out bytes.Buffer
 
 	out.WriteString(constStatement.TokenLiteral() + " ")
-	out.WriteString(constStatement.Name.Value)
+
+	if constStatement.Pattern != nil {
+		out.WriteString(constStatement.Pattern.String())
+	} else {
+		out.WriteString(constStatement.Name.Value)
+	}
+
 	out.WriteString(" = ")
 
 	if constStatement.Value != nil {
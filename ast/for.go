@@ -10,6 +10,7 @@ type ForStatement struct {
 	Token      token.Token     // The token.FOR
 	IdxIdent   *Identifier     // The index identifier of the loop
 	VarIdent   *Identifier     // The variable identifier of the loop
+	VarPattern Expression      // Destructures each element instead of binding it to VarIdent
 	Expression Expression      // The expression to iterate over
 	Body       *BlockStatement // The body of the loop
 }
@@ -24,9 +25,21 @@ func (fs *ForStatement) String() string {
 	var out bytes.Buffer
 
 	out.WriteString("for ")
-	out.WriteString(fs.IdxIdent.String())
+
+	if fs.IdxIdent != nil {
+		out.WriteString(fs.IdxIdent.String())
+	} else {
+		out.WriteString("_")
+	}
+
 	out.WriteString(", ")
-	out.WriteString(fs.VarIdent.String())
+
+	if fs.VarPattern != nil {
+		out.WriteString(fs.VarPattern.String())
+	} else {
+		out.WriteString(fs.VarIdent.String())
+	}
+
 	out.WriteString(" in ")
 	out.WriteString(fs.Expression.String())
 	out.WriteString(":")
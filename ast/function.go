@@ -9,10 +9,43 @@ import (
 
 type Function struct {
 	Token      token.Token // the 'fun' token
-	Parameters []*Identifier
+	Name       *Identifier // The name of a function declaration, nil for anonymous functions
+	Parameters []*Parameter
 	Body       *BlockStatement
 }
 
+/*
+Parameter is one entry of a function's parameter list:
+
+- The target is an *Identifier or a pattern that destructures the argument.
+- Default is evaluated when no argument is passed for the parameter, nil when there is no default.
+- A rest parameter `...others` collects the extra positional arguments into an array, it is always the last one.
+*/
+type Parameter struct {
+	Target  Expression
+	Default Expression
+	Rest    bool
+}
+
+// Name returns the identifier of a plain parameter, nil when the parameter is a pattern
+func (p *Parameter) Name() *Identifier {
+	ident, _ := p.Target.(*Identifier)
+
+	return ident
+}
+
+func (p *Parameter) String() string {
+	if p.Rest {
+		return "..." + p.Target.String()
+	}
+
+	if p.Default != nil {
+		return p.Target.String() + " = " + p.Default.String()
+	}
+
+	return p.Target.String()
+}
+
 func (f *Function) expressionNode()      {}
 func (f *Function) TokenLiteral() string { return f.Token.Literal }
 
@@ -26,6 +59,11 @@ func (f *Function) String() string {
 	}
 
 	out.WriteString(f.TokenLiteral())
+
+	if f.Name != nil {
+		out.WriteString(" " + f.Name.String())
+	}
+
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
 	out.WriteString(") ")
@@ -0,0 +1,20 @@
+package ast
+
+import (
+	"github.com/Mostafa-DE/delang/token"
+)
+
+// FunctionStatement declares a named function, `fun name(params) {}`, it is hoisted to the top of its block
+type FunctionStatement struct {
+	Token    token.Token // The 'fun' token
+	Function *Function   // Function.Name is the declared name
+}
+
+func (fs *FunctionStatement) statementNode() {}
+func (fs *FunctionStatement) TokenLiteral() string {
+	return fs.Token.Literal
+}
+
+func (fs *FunctionStatement) String() string {
+	return fs.Function.String()
+}
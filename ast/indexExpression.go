@@ -7,10 +7,10 @@ import (
 )
 
 type IndexExpression struct {
-	Token token.Token // The [ token
-	Ident Expression
-	Index Expression
-	Value Expression
+	Token    token.Token // The [ or ?[ token
+	Ident    Expression
+	Index    Expression
+	Optional bool // `ident?[index]` is null instead of an error when ident is null
 }
 
 func (idx *IndexExpression) expressionNode() {}
@@ -23,6 +23,11 @@ func (idx *IndexExpression) String() string {
 
 	out.WriteString("(")
 	out.WriteString(idx.Ident.String())
+
+	if idx.Optional {
+		out.WriteString("?")
+	}
+
 	out.WriteString("[")
 	out.WriteString(idx.Index.String())
 	out.WriteString("])")
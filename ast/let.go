@@ -9,14 +9,22 @@ import (
 type LetStatement struct {
 	Token token.Token // token.LET
 	Name  *Identifier
-	Value Expression
+	// Pattern destructures the value instead of binding it to Name, Name is nil when it is set
+	Pattern Expression
+	Value   Expression
 }
 
 func (letStatement *LetStatement) String() string {
 	var out bytes.Buffer
 
 	out.WriteString(letStatement.TokenLiteral() + " ")
-	out.WriteString(letStatement.Name.Value)
+
+	if letStatement.Pattern != nil {
+		out.WriteString(letStatement.Pattern.String())
+	} else {
+		out.WriteString(letStatement.Name.Value)
+	}
+
 	out.WriteString(" = ")
 
 	if letStatement.Value != nil {
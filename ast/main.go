@@ -26,10 +26,11 @@ type Program struct { // Root Node
 }
 
 type VariableStatement struct {
-	Token token.Token // token.LET or token.CONST
-	Type  string      // "let" or "const"
-	Name  *Identifier
-	Value Expression
+	Token   token.Token // token.LET or token.CONST
+	Type    string      // "let" or "const"
+	Name    *Identifier
+	Pattern Expression // Set instead of Name when the statement destructures its value
+	Value   Expression
 }
 
 func (p *Program) TokenLiteral() string { // used only for debugging and testing
@@ -0,0 +1,104 @@
+package ast
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/Mostafa-DE/delang/token"
+)
+
+// TypePatterns maps the names a match pattern can use to test the type of a value to that type, e.g `int` matches INTEGER
+var TypePatterns = map[string]string{
+	"int":     "INTEGER",
+	"float":   "FLOAT",
+	"decimal": "DECIMAL",
+	"str":     "STRING",
+	"bool":    "BOOLEAN",
+	"array":   "ARRAY",
+	"hash":    "HASH",
+	"null":    "NULL",
+}
+
+/*
+MatchExpression is `match value { patterns => body, ... }`, the first arm with a matching pattern is evaluated.
+
+A pattern is one of:
+
+- A literal, e.g `1`, `-2.5`, `'text'` or `true`, it matches an equal value of the same type.
+- `_`, it matches anything.
+- A type name from TypePatterns, e.g `int`.
+- A name, it matches anything and binds the value to the name.
+- An *ArrayPattern or a *HashPattern whose elements are patterns themselves.
+*/
+type MatchExpression struct {
+	Token token.Token // The 'match' token
+	Value Expression
+	Arms  []*MatchArm
+}
+
+// MatchArm is taken when one of its patterns matches and the guard, if there is one, is truthy
+type MatchArm struct {
+	Patterns []Expression
+	Guard    Expression // nil when the arm has no guard
+	Body     *BlockStatement
+}
+
+func (me *MatchExpression) expressionNode() {}
+func (me *MatchExpression) TokenLiteral() string {
+	return me.Token.Literal
+}
+
+func (me *MatchExpression) String() string {
+	var out bytes.Buffer
+
+	arms := []string{}
+
+	for _, arm := range me.Arms {
+		arms = append(arms, arm.String())
+	}
+
+	out.WriteString("match ")
+	out.WriteString(me.Value.String())
+	out.WriteString(" {")
+	out.WriteString(strings.Join(arms, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
+
+func (arm *MatchArm) String() string {
+	var out bytes.Buffer
+
+	patterns := []string{}
+
+	for _, pattern := range arm.Patterns {
+		patterns = append(patterns, pattern.String())
+	}
+
+	out.WriteString(strings.Join(patterns, ", "))
+
+	if arm.Guard != nil {
+		out.WriteString(" if " + arm.Guard.String())
+	}
+
+	out.WriteString(" => {")
+	out.WriteString(arm.Body.String())
+	out.WriteString("}")
+
+	return out.String()
+}
+
+// TypePattern matches the values of one type, e.g `int` or `str`
+type TypePattern struct {
+	Token token.Token // The name token
+	Name  string
+}
+
+func (tp *TypePattern) expressionNode() {}
+func (tp *TypePattern) TokenLiteral() string {
+	return tp.Token.Literal
+}
+
+func (tp *TypePattern) String() string {
+	return tp.Name
+}
@@ -0,0 +1,37 @@
+package ast
+
+import (
+	"bytes"
+
+	"github.com/Mostafa-DE/delang/token"
+)
+
+// MemberExpression is `object.member`, a hash field or a method of the object
+type MemberExpression struct {
+	Token    token.Token // The . or ?. token
+	Object   Expression
+	Member   *Identifier
+	Optional bool // `object?.member` is null instead of an error when the object is null
+}
+
+func (me *MemberExpression) expressionNode() {}
+func (me *MemberExpression) TokenLiteral() string {
+	return me.Token.Literal
+}
+
+func (me *MemberExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(me.Object.String())
+
+	if me.Optional {
+		out.WriteString("?")
+	}
+
+	out.WriteString(".")
+	out.WriteString(me.Member.String())
+	out.WriteString(")")
+
+	return out.String()
+}
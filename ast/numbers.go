@@ -1,10 +1,16 @@
 package ast
 
-import "github.com/Mostafa-DE/delang/token"
+import (
+	"math/big"
+
+	"github.com/Mostafa-DE/delang/token"
+	"github.com/shopspring/decimal"
+)
 
 type Integer struct {
 	Token token.Token // token.INT
 	Value int64
+	Big   *big.Int // Set instead of Value when the literal does not fit in int64
 }
 
 type Float struct {
@@ -12,6 +18,12 @@ type Float struct {
 	Value float64
 }
 
+// Decimal is a decimal literal like `19.99d`, it is exact where a float would round
+type Decimal struct {
+	Token token.Token // token.DECIMAL
+	Value decimal.Decimal
+}
+
 func (integer *Integer) String() string {
 	return integer.TokenLiteral()
 }
@@ -29,3 +41,12 @@ func (float *Float) expressionNode() {}
 func (float *Float) TokenLiteral() string {
 	return float.Token.Literal
 }
+
+func (d *Decimal) String() string {
+	return d.TokenLiteral()
+}
+
+func (d *Decimal) expressionNode() {}
+func (d *Decimal) TokenLiteral() string {
+	return d.Token.Literal
+}
@@ -0,0 +1,118 @@
+package ast
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/Mostafa-DE/delang/token"
+)
+
+// ArrayPattern destructures an array, e.g `[first, second = 0, ...rest]`, match arms use it with nested patterns
+type ArrayPattern struct {
+	Token    token.Token // The '[' token
+	Elements []*PatternElement
+	Rest     *Identifier // The name after '...', nil when there is no rest element
+}
+
+func (ap *ArrayPattern) expressionNode() {}
+func (ap *ArrayPattern) TokenLiteral() string {
+	return ap.Token.Literal
+}
+
+func (ap *ArrayPattern) String() string {
+	var out bytes.Buffer
+
+	elements := []string{}
+
+	for _, element := range ap.Elements {
+		elements = append(elements, element.String())
+	}
+
+	if ap.Rest != nil {
+		elements = append(elements, "..."+ap.Rest.String())
+	}
+
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+
+	return out.String()
+}
+
+// HashPattern destructures a hash by its string keys, e.g `{name, age: years}`, match arms use it with nested patterns
+type HashPattern struct {
+	Token   token.Token // The '{' token
+	Entries []*HashPatternEntry
+}
+
+// HashPatternEntry binds the value under Key to Element, `{name}` is short for `{name: name}`
+type HashPatternEntry struct {
+	Key     *Identifier
+	Element *PatternElement
+}
+
+func (hp *HashPattern) expressionNode() {}
+func (hp *HashPattern) TokenLiteral() string {
+	return hp.Token.Literal
+}
+
+func (hp *HashPattern) String() string {
+	var out bytes.Buffer
+
+	entries := []string{}
+
+	for _, entry := range hp.Entries {
+		if ident, ok := entry.Element.Target.(*Identifier); ok && ident.Value == entry.Key.Value {
+			entries = append(entries, entry.Element.String())
+			continue
+		}
+
+		entries = append(entries, entry.Key.String()+": "+entry.Element.String())
+	}
+
+	out.WriteString("{")
+	out.WriteString(strings.Join(entries, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
+
+// PatternElement is one slot of a pattern, the target is an *Identifier, an *ArrayPattern or a *HashPattern
+type PatternElement struct {
+	Target  Expression
+	Default Expression // Used when the value is missing, nil when there is no default
+}
+
+func (pe *PatternElement) String() string {
+	if pe.Default == nil {
+		return pe.Target.String()
+	}
+
+	return pe.Target.String() + " = " + pe.Default.String()
+}
+
+// PatternNames returns every identifier a pattern binds, in source order
+func PatternNames(pattern Expression) []*Identifier {
+	names := []*Identifier{}
+
+	switch pattern := pattern.(type) {
+	case *Identifier:
+		names = append(names, pattern)
+
+	case *ArrayPattern:
+		for _, element := range pattern.Elements {
+			names = append(names, PatternNames(element.Target)...)
+		}
+
+		if pattern.Rest != nil {
+			names = append(names, pattern.Rest)
+		}
+
+	case *HashPattern:
+		for _, entry := range pattern.Entries {
+			names = append(names, PatternNames(entry.Element.Target)...)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,27 @@
+package ast
+
+import "github.com/Mostafa-DE/delang/token"
+
+// StatementToken returns the first token of a statement, it reports false for blocks and for expressions
+func StatementToken(node Node) (token.Token, bool) {
+	switch node := node.(type) {
+	case *LetStatement:
+		return node.Token, true
+	case *ConstStatement:
+		return node.Token, true
+	case *ReturnStatement:
+		return node.Token, true
+	case *BreakStatement:
+		return node.Token, true
+	case *SkipStatement:
+		return node.Token, true
+	case *ForStatement:
+		return node.Token, true
+	case *FunctionStatement:
+		return node.Token, true
+	case *ExpressionStatement:
+		return node.Token, true
+	}
+
+	return token.Token{}, false
+}
@@ -7,7 +7,7 @@ import (
 )
 
 type PrefixExpression struct {
-	Token    token.Token // The prefix token, e.g. !
+	Token    token.Token // The prefix token, e.g. ! or not
 	Operator string
 	Right    Expression
 }
@@ -17,6 +17,11 @@ func (prefixExpression *PrefixExpression) String() string {
 
 	out.WriteString("(")
 	out.WriteString(prefixExpression.Operator)
+
+	if prefixExpression.Token.Type == token.NOT {
+		out.WriteString(" ")
+	}
+
 	out.WriteString(prefixExpression.Right.String())
 	out.WriteString(")")
 
@@ -192,14 +192,18 @@ func TestFunction(t *testing.T) {
 			&ast.ExpressionStatement{
 				Expression: &ast.Function{
 					Token: token.Token{Type: token.FUNCTION, Literal: "fun"},
-					Parameters: []*ast.Identifier{
+					Parameters: []*ast.Parameter{
 						{
-							Token: token.Token{Type: token.IDENT, Literal: "param1"},
-							Value: "param1",
+							Target: &ast.Identifier{
+								Token: token.Token{Type: token.IDENT, Literal: "param1"},
+								Value: "param1",
+							},
 						},
 						{
-							Token: token.Token{Type: token.IDENT, Literal: "param2"},
-							Value: "param2",
+							Target: &ast.Identifier{
+								Token: token.Token{Type: token.IDENT, Literal: "param2"},
+								Value: "param2",
+							},
 						},
 					},
 					Body: &ast.BlockStatement{
@@ -348,8 +352,9 @@ func TestAssignStatement(t *testing.T) {
 		Statements: []ast.Statement{
 			&ast.ExpressionStatement{
 				Expression: &ast.AssignExpression{
-					Token: token.Token{Type: token.ASSIGN, Literal: "="},
-					Ident: &ast.Identifier{
+					Token:    token.Token{Type: token.ASSIGN, Literal: "="},
+					Operator: "=",
+					Target: &ast.Identifier{
 						Token: token.Token{Type: token.IDENT, Literal: "var1"},
 						Value: "var1",
 					},
@@ -0,0 +1,176 @@
+package ast
+
+import "sort"
+
+/*
+Inspect walks the tree of node in depth-first order, it calls fn for every node before its children.
+If fn returns false the children of that node are skipped.
+Nil children are not visited.
+*/
+func Inspect(node Node, fn func(Node) bool) {
+	if isNil(node) || !fn(node) {
+		return
+	}
+
+	switch node := node.(type) {
+	case *Program:
+		for _, statement := range node.Statements {
+			Inspect(statement, fn)
+		}
+
+	case *BlockStatement:
+		for _, statement := range node.Statements {
+			Inspect(statement, fn)
+		}
+
+	case *ExpressionStatement:
+		Inspect(node.Expression, fn)
+
+	case *LetStatement:
+		Inspect(node.Name, fn)
+		Inspect(node.Pattern, fn)
+		Inspect(node.Value, fn)
+
+	case *ConstStatement:
+		Inspect(node.Name, fn)
+		Inspect(node.Pattern, fn)
+		Inspect(node.Value, fn)
+
+	case *ReturnStatement:
+		Inspect(node.ReturnValue, fn)
+
+	case *ForStatement:
+		Inspect(node.IdxIdent, fn)
+		Inspect(node.VarIdent, fn)
+		Inspect(node.VarPattern, fn)
+		Inspect(node.Expression, fn)
+		Inspect(node.Body, fn)
+
+	case *AssignExpression:
+		Inspect(node.Target, fn)
+		Inspect(node.Value, fn)
+
+	case *PrefixExpression:
+		Inspect(node.Right, fn)
+
+	case *InfixExpression:
+		Inspect(node.Left, fn)
+		Inspect(node.Right, fn)
+
+	case *IfExpression:
+		Inspect(node.Condition, fn)
+		Inspect(node.Consequence, fn)
+		Inspect(node.Alternative, fn)
+
+	case *ConditionalExpression:
+		Inspect(node.Condition, fn)
+		Inspect(node.Consequence, fn)
+		Inspect(node.Alternative, fn)
+
+	case *DuringExpression:
+		Inspect(node.Condition, fn)
+		Inspect(node.Body, fn)
+
+	case *FunctionStatement:
+		Inspect(node.Function, fn)
+
+	case *Function:
+		Inspect(node.Name, fn)
+
+		for _, param := range node.Parameters {
+			Inspect(param.Target, fn)
+			Inspect(param.Default, fn)
+		}
+
+		Inspect(node.Body, fn)
+
+	case *CallFunction:
+		Inspect(node.Function, fn)
+
+		for _, arg := range node.Arguments {
+			Inspect(arg, fn)
+		}
+
+	case *Array:
+		for _, element := range node.Elements {
+			Inspect(element, fn)
+		}
+
+	case *Hash:
+		for _, key := range SortedKeys(node) {
+			Inspect(key, fn)
+			Inspect(node.Pairs[key], fn)
+		}
+
+	case *IndexExpression:
+		Inspect(node.Ident, fn)
+		Inspect(node.Index, fn)
+
+	case *MemberExpression:
+		Inspect(node.Object, fn)
+		Inspect(node.Member, fn)
+
+	case *SpreadArgument:
+		Inspect(node.Value, fn)
+
+	case *KeywordArgument:
+		// The name refers to a parameter of the called function, not to a variable
+		Inspect(node.Value, fn)
+
+	case *MatchExpression:
+		Inspect(node.Value, fn)
+
+		for _, arm := range node.Arms {
+			for _, pattern := range arm.Patterns {
+				Inspect(pattern, fn)
+			}
+
+			Inspect(arm.Guard, fn)
+			Inspect(arm.Body, fn)
+		}
+
+	case *ArrayPattern:
+		for _, element := range node.Elements {
+			Inspect(element.Target, fn)
+			Inspect(element.Default, fn)
+		}
+
+		Inspect(node.Rest, fn)
+
+	case *HashPattern:
+		// The keys name entries of the hash, not variables, so only the targets are visited
+		for _, entry := range node.Entries {
+			Inspect(entry.Element.Target, fn)
+			Inspect(entry.Element.Default, fn)
+		}
+	}
+}
+
+// SortedKeys returns the keys of a hash literal ordered by their source text, so that walking a hash is deterministic
+func SortedKeys(hash *Hash) []Expression {
+	keys := make([]Expression, 0, len(hash.Pairs))
+
+	for key := range hash.Pairs {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	return keys
+}
+
+// isNil reports whether node is nil or holds a nil pointer, e.g an if without an else
+func isNil(node Node) bool {
+	switch node := node.(type) {
+	case nil:
+		return true
+	case *BlockStatement:
+		return node == nil
+	case *Identifier:
+		return node == nil
+	case *FunctionStatement:
+		return node == nil
+	}
+
+	return false
+}
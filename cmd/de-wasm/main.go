@@ -0,0 +1,144 @@
+//go:build js && wasm
+
+/*
+de-wasm runs DE in the browser, build it with:
+
+	GOOS=js GOARCH=wasm go build -o de.wasm ./cmd/de-wasm
+
+Once loaded with wasm_exec.js it defines a global function:
+
+	deEval(source, {input, timeout, maxSteps, maxOutput, maxDepth, maxIntegerBits, maxStringLength, maxRangeLength, maxDecimalDigits})
+
+The options are optional, the timeout is in milliseconds. It returns an object:
+
+	{output: ["line", ...], result: "42", type: "INTEGER", errors: [{message, line, column}]}
+
+result and type are null when the program failed. Only the packages the evaluator needs are imported,
+the REPL and its keyboard dependency are not part of this build.
+*/
+package main
+
+import (
+	"context"
+	"strings"
+	"syscall/js"
+	"time"
+
+	"github.com/Mostafa-DE/delang/sandbox"
+)
+
+func main() {
+	js.Global().Set("deEval", js.FuncOf(deEval))
+
+	// Keep the module alive so deEval can be called
+	select {}
+}
+
+func deEval(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 || args[0].Type() != js.TypeString {
+		return toJS(nil, nil, []sandbox.Event{{Type: sandbox.ERROR_EVENT, Message: "deEval expects the source code as a string"}})
+	}
+
+	req := sandbox.Request{Source: args[0].String()}
+	limits := sandbox.DefaultLimits
+
+	if len(args) > 1 && args[1].Type() == js.TypeObject {
+		options := args[1]
+
+		if input := options.Get("input"); input.Type() == js.TypeString {
+			req.Input = input.String()
+		}
+
+		if timeout := options.Get("timeout"); timeout.Type() == js.TypeNumber {
+			limits.Timeout = time.Duration(timeout.Float()) * time.Millisecond
+		}
+
+		if maxSteps := options.Get("maxSteps"); maxSteps.Type() == js.TypeNumber {
+			limits.MaxSteps = maxSteps.Int()
+		}
+
+		if maxOutput := options.Get("maxOutput"); maxOutput.Type() == js.TypeNumber {
+			limits.MaxOutput = maxOutput.Int()
+		}
+
+		if maxDepth := options.Get("maxDepth"); maxDepth.Type() == js.TypeNumber {
+			limits.MaxDepth = maxDepth.Int()
+		}
+
+		if maxIntegerBits := options.Get("maxIntegerBits"); maxIntegerBits.Type() == js.TypeNumber {
+			limits.MaxIntegerBits = maxIntegerBits.Int()
+		}
+
+		if maxStringLength := options.Get("maxStringLength"); maxStringLength.Type() == js.TypeNumber {
+			limits.MaxStringLength = maxStringLength.Int()
+		}
+
+		if maxRangeLength := options.Get("maxRangeLength"); maxRangeLength.Type() == js.TypeNumber {
+			limits.MaxRangeLength = maxRangeLength.Int()
+		}
+
+		if maxDecimalDigits := options.Get("maxDecimalDigits"); maxDecimalDigits.Type() == js.TypeNumber {
+			limits.MaxDecimalDigits = maxDecimalDigits.Int()
+		}
+	}
+
+	var output strings.Builder
+	var result *sandbox.Event
+	var errors []sandbox.Event
+
+	sandbox.Eval(context.Background(), req, limits, func(event sandbox.Event) {
+		switch event.Type {
+		case sandbox.OUTPUT_EVENT:
+			output.WriteString(event.Text)
+
+		case sandbox.RESULT_EVENT:
+			result = &event
+
+		case sandbox.ERROR_EVENT:
+			errors = append(errors, event)
+		}
+	})
+
+	return toJS(outputLines(output.String()), result, errors)
+}
+
+// outputLines splits what the program printed, the newline that ends the last line does not add an empty one
+func outputLines(output string) []string {
+	if output == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+}
+
+func toJS(lines []string, result *sandbox.Event, errors []sandbox.Event) map[string]interface{} {
+	output := make([]interface{}, len(lines))
+
+	for idx, line := range lines {
+		output[idx] = line
+	}
+
+	errorList := make([]interface{}, len(errors))
+
+	for idx, err := range errors {
+		errorList[idx] = map[string]interface{}{
+			"message": err.Message,
+			"line":    err.Line,
+			"column":  err.Column,
+		}
+	}
+
+	value := map[string]interface{}{
+		"output": output,
+		"result": nil,
+		"type":   nil,
+		"errors": errorList,
+	}
+
+	if result != nil {
+		value["result"] = result.Value
+		value["type"] = result.ValueType
+	}
+
+	return value
+}
@@ -0,0 +1,208 @@
+package debugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+)
+
+func decodeArguments(args json.RawMessage, target interface{}) error {
+	if len(args) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(args, target)
+}
+
+func (s *Session) initialize(args json.RawMessage) (interface{}, error) {
+	return Capabilities{SupportsConfigurationDoneRequest: true, SupportsTerminateRequest: true}, nil
+}
+
+func (s *Session) launch(args json.RawMessage) (interface{}, error) {
+	var launch LaunchArguments
+
+	if err := decodeArguments(args, &launch); err != nil {
+		return nil, err
+	}
+
+	if launch.Program == "" {
+		return nil, fmt.Errorf("launch needs a program to debug")
+	}
+
+	prog, err := loadProgram(launch.Program)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.program = prog
+	s.frames = []*frame{{name: "main", env: prog.env}}
+
+	if launch.StopOnEntry {
+		s.step = stepState{mode: STEP_ENTRY}
+	}
+
+	s.mu.Unlock()
+
+	// The client sends its breakpoints once it knows the adapter is ready for them
+	defer s.event("initialized", nil)
+
+	return nil, nil
+}
+
+func (s *Session) setBreakpoints(args json.RawMessage) (interface{}, error) {
+	var params SetBreakpointsArguments
+
+	if err := decodeArguments(args, &params); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.program == nil {
+		return nil, fmt.Errorf("no program is launched")
+	}
+
+	s.breakpoints = make(map[int]bool)
+	result := SetBreakpointsResponse{Breakpoints: []Breakpoint{}}
+
+	for _, bp := range params.Breakpoints {
+		if !s.program.byLines[bp.Line] {
+			result.Breakpoints = append(result.Breakpoints, Breakpoint{Line: bp.Line, Message: "No statement on this line"})
+			continue
+		}
+
+		s.breakpoints[bp.Line] = true
+		result.Breakpoints = append(result.Breakpoints, Breakpoint{Verified: true, Line: bp.Line})
+	}
+
+	return result, nil
+}
+
+func (s *Session) configurationDone(args json.RawMessage) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.program == nil {
+		return nil, fmt.Errorf("no program is launched")
+	}
+
+	if !s.running {
+		s.running = true
+		go s.run()
+	}
+
+	return nil, nil
+}
+
+func (s *Session) threads(args json.RawMessage) (interface{}, error) {
+	return ThreadsResponse{Threads: []Thread{{ID: THREAD_ID, Name: "main"}}}, nil
+}
+
+func (s *Session) stackTrace(args json.RawMessage) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.program == nil {
+		return nil, fmt.Errorf("no program is launched")
+	}
+
+	source := &Source{Name: filepath.Base(s.program.path), Path: s.program.path}
+	result := StackTraceResponse{StackFrames: []StackFrame{}, TotalFrames: len(s.frames)}
+
+	// The top of the stack comes first, frame ids are the positions of the frames from the bottom
+	for idx := len(s.frames) - 1; idx >= 0; idx-- {
+		f := s.frames[idx]
+
+		result.StackFrames = append(result.StackFrames, StackFrame{
+			ID:     idx + 1,
+			Name:   f.name,
+			Source: source,
+			Line:   f.line,
+			Column: f.column,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *Session) scopes(args json.RawMessage) (interface{}, error) {
+	var params ScopesArguments
+
+	if err := decodeArguments(args, &params); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.stopped {
+		return nil, fmt.Errorf("the program is not stopped")
+	}
+
+	if params.FrameID < 1 || params.FrameID > len(s.frames) {
+		return nil, fmt.Errorf("unknown frame: %d", params.FrameID)
+	}
+
+	return ScopesResponse{Scopes: s.handles.scopes(s.frames[params.FrameID-1].env)}, nil
+}
+
+func (s *Session) variables(args json.RawMessage) (interface{}, error) {
+	var params VariablesArguments
+
+	if err := decodeArguments(args, &params); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.stopped {
+		return nil, fmt.Errorf("the program is not stopped")
+	}
+
+	variables, ok := s.handles.variables(params.VariablesReference)
+
+	if !ok {
+		return nil, fmt.Errorf("unknown variables reference: %d", params.VariablesReference)
+	}
+
+	return VariablesResponse{Variables: variables}, nil
+}
+
+func (s *Session) continueRequest(args json.RawMessage) (interface{}, error) {
+	if err := s.resumeWith(STEP_NONE); err != nil {
+		return nil, err
+	}
+
+	return ContinueResponse{AllThreadsContinued: true}, nil
+}
+
+func (s *Session) next(args json.RawMessage) (interface{}, error) {
+	return nil, s.resumeWith(STEP_OVER)
+}
+
+func (s *Session) stepIn(args json.RawMessage) (interface{}, error) {
+	return nil, s.resumeWith(STEP_IN)
+}
+
+func (s *Session) stepOut(args json.RawMessage) (interface{}, error) {
+	return nil, s.resumeWith(STEP_OUT)
+}
+
+func (s *Session) pause(args json.RawMessage) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running && !s.stopped {
+		s.step = stepState{mode: STEP_PAUSE}
+	}
+
+	return nil, nil
+}
+
+func (s *Session) disconnect(args json.RawMessage) (interface{}, error) {
+	return nil, nil
+}
@@ -0,0 +1,303 @@
+package debugger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/evaluator"
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/object"
+	"github.com/Mostafa-DE/delang/parser"
+)
+
+const (
+	STEP_NONE = iota // Run until a breakpoint
+	STEP_ENTRY
+	STEP_IN
+	STEP_OVER
+	STEP_OUT
+	STEP_PAUSE
+)
+
+type stepState struct {
+	mode  int
+	depth int // The number of frames when the step was requested
+}
+
+// program is a parsed file along with what the debugger needs to know about its AST
+type program struct {
+	path    string
+	ast     *ast.Program
+	env     *object.Environment
+	names   map[*ast.BlockStatement]string // The names of functions by their body
+	stopAt  map[[2]int]bool                // The line and column of the statements the debugger stops at
+	byLines map[int]bool                   // The lines that have a statement to stop at
+}
+
+// frame is a function call, the bottom frame is the program itself
+type frame struct {
+	name   string
+	env    *object.Environment // The environment of the statement being executed
+	line   int
+	column int
+}
+
+func loadProgram(path string) (*program, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser.New(lexer.New(string(content)))
+	tree := p.ParseProgram()
+
+	if errs := p.DetailedErrors(); len(errs) != 0 {
+		return nil, fmt.Errorf("%s:%d:%d: %s", filepath.Base(path), errs[0].Line, errs[0].Column, errs[0].Msg)
+	}
+
+	prog := &program{
+		path:    path,
+		ast:     tree,
+		env:     object.NewEnvironment(),
+		names:   functionNames(tree),
+		stopAt:  make(map[[2]int]bool),
+		byLines: make(map[int]bool),
+	}
+
+	prog.findStopPoints()
+
+	return prog, nil
+}
+
+/*
+findStopPoints decides where stepping and breakpoints can stop, the debugger works with lines so it stops
+at the first statement of every line and not at statements nested in it, e.g `during x < 3: { x = x + 1; }`.
+The first statement of a function body is a stop point as well so that stepping into a one line function works.
+*/
+func (prog *program) findStopPoints() {
+	first := make(map[int]int)
+
+	ast.Inspect(prog.ast, func(node ast.Node) bool {
+		if function, ok := node.(*ast.Function); ok && function.Body != nil && len(function.Body.Statements) != 0 {
+			if tok, ok := ast.StatementToken(function.Body.Statements[0]); ok {
+				prog.stopAt[[2]int{tok.Line, tok.Column}] = true
+			}
+		}
+
+		if tok, ok := ast.StatementToken(node); ok {
+			if column, seen := first[tok.Line]; !seen || tok.Column < column {
+				first[tok.Line] = tok.Column
+			}
+		}
+
+		return true
+	})
+
+	for line, column := range first {
+		prog.stopAt[[2]int{line, column}] = true
+		prog.byLines[line] = true
+	}
+}
+
+// functionNames names function literals after what they are assigned to, others are anonymous
+func functionNames(tree *ast.Program) map[*ast.BlockStatement]string {
+	names := make(map[*ast.BlockStatement]string)
+
+	name := func(value ast.Expression, ident string) {
+		if function, ok := value.(*ast.Function); ok && function.Body != nil {
+			names[function.Body] = ident
+		}
+	}
+
+	ast.Inspect(tree, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.LetStatement:
+			if node.Name != nil {
+				name(node.Value, node.Name.Value)
+			}
+
+		case *ast.ConstStatement:
+			if node.Name != nil {
+				name(node.Value, node.Name.Value)
+			}
+
+		case *ast.AssignExpression:
+			switch target := node.Target.(type) {
+			case *ast.Identifier:
+				name(node.Value, target.Value)
+			case *ast.MemberExpression:
+				name(node.Value, target.Member.Value)
+			}
+
+		case *ast.Hash:
+			for key, value := range node.Pairs {
+				if key, ok := key.(*ast.StringLiteral); ok {
+					name(value, key.Value)
+				}
+			}
+		}
+
+		return true
+	})
+
+	return names
+}
+
+// run evaluates the program on the current goroutine, the hooks block it whenever it has to stop
+func (s *Session) run() {
+	defer close(s.done)
+
+	prog := s.program
+	runtime := prog.env.Runtime()
+	runtime.Output = outputWriter{s}
+	runtime.Hooks = &object.Hooks{
+		BeforeNode:    s.beforeNode,
+		EnterFunction: s.enterFunction,
+		ExitFunction:  s.exitFunction,
+	}
+
+	result := evaluator.Eval(prog.ast, prog.env)
+	exitCode := 0
+
+	if err, ok := result.(*object.Error); ok {
+		exitCode = 1
+
+		if !s.isTerminated() {
+			s.event("output", OutputEvent{Category: "stderr", Output: err.Inspect() + "\n"})
+		}
+	}
+
+	s.event("exited", ExitedEvent{ExitCode: exitCode})
+	s.event("terminated", nil)
+}
+
+func (s *Session) beforeNode(node ast.Node, env *object.Environment) *object.Error {
+	tok, ok := ast.StatementToken(node)
+
+	if !ok {
+		return s.checkTerminated()
+	}
+
+	s.mu.Lock()
+
+	top := s.frames[len(s.frames)-1]
+	top.env, top.line, top.column = env, tok.Line, tok.Column
+
+	reason := ""
+
+	if s.program.stopAt[[2]int{tok.Line, tok.Column}] {
+		reason = s.stopReason(tok.Line)
+	}
+
+	if reason == "" {
+		s.mu.Unlock()
+		return s.checkTerminated()
+	}
+
+	s.stopped = true
+	s.handles = newHandles()
+	s.mu.Unlock()
+
+	s.event("stopped", StoppedEvent{Reason: reason, ThreadID: THREAD_ID, AllThreadsStopped: true})
+
+	<-s.resume
+
+	return s.checkTerminated()
+}
+
+// stopReason must be called with the lock held
+func (s *Session) stopReason(line int) string {
+	depth := len(s.frames)
+
+	switch {
+	case s.step.mode == STEP_ENTRY:
+		return "entry"
+
+	case s.step.mode == STEP_PAUSE:
+		return "pause"
+
+	case s.step.mode == STEP_IN,
+		s.step.mode == STEP_OVER && depth <= s.step.depth,
+		s.step.mode == STEP_OUT && depth < s.step.depth:
+		return "step"
+
+	case s.breakpoints[line]:
+		return "breakpoint"
+	}
+
+	return ""
+}
+
+func (s *Session) enterFunction(function *object.Function, args []object.Object, env *object.Environment) {
+	name := function.Name
+
+	if name == "" {
+		var ok bool
+
+		if name, ok = s.program.names[function.Body]; !ok {
+			name = "<anonymous>"
+		}
+	}
+
+	s.mu.Lock()
+	s.frames = append(s.frames, &frame{name: name, env: env})
+	s.mu.Unlock()
+}
+
+func (s *Session) exitFunction(function *object.Function, result object.Object, env *object.Environment) {
+	s.mu.Lock()
+	s.frames = s.frames[:len(s.frames)-1]
+	s.mu.Unlock()
+}
+
+func (s *Session) isTerminated() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.terminated
+}
+
+func (s *Session) checkTerminated() *object.Error {
+	if s.isTerminated() {
+		return &object.Error{Msg: "Program terminated by the debugger"}
+	}
+
+	return nil
+}
+
+// resumeWith lets the program continue until the given kind of step is done
+func (s *Session) resumeWith(mode int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.stopped {
+		return fmt.Errorf("the program is not stopped")
+	}
+
+	s.stopped = false
+	s.step = stepState{mode: mode, depth: len(s.frames)}
+	s.resume <- struct{}{}
+
+	return nil
+}
+
+// terminate stops the program if it runs and waits for it to finish
+func (s *Session) terminate() {
+	s.mu.Lock()
+	s.terminated = true
+	running := s.running
+
+	if s.stopped {
+		s.stopped = false
+		s.resume <- struct{}{}
+	}
+
+	s.mu.Unlock()
+
+	if running {
+		<-s.done
+	}
+}
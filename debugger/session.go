@@ -0,0 +1,144 @@
+package debugger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/Mostafa-DE/delang/transport"
+)
+
+// Session is one debugging session, it reads DAP requests on the main goroutine
+// while the program being debugged runs on its own goroutine
+type Session struct {
+	conn    *transport.Conn
+	writeMu sync.Mutex
+	seq     int
+
+	mu          sync.Mutex // Guards the state shared with the program goroutine
+	program     *program
+	breakpoints map[int]bool
+	configured  bool
+	running     bool
+	stopped     bool
+	step        stepState
+	frames      []*frame
+	handles     *handles
+	resume      chan struct{}
+	terminated  bool
+	done        chan struct{}
+}
+
+func NewSession(in io.Reader, out io.Writer) *Session {
+	return &Session{
+		conn:        transport.NewConn(in, out),
+		breakpoints: make(map[int]bool),
+		handles:     newHandles(),
+		resume:      make(chan struct{}, 1),
+		done:        make(chan struct{}),
+	}
+}
+
+// Run is the entry point of `de debug`, the adapter speaks DAP over stdin and stdout
+func Run() {
+	if err := NewSession(os.Stdin, os.Stdout).Serve(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// Serve handles requests until the client disconnects or closes the connection
+func (s *Session) Serve() error {
+	defer s.terminate()
+
+	for {
+		body, err := s.conn.Read()
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		var req Message
+
+		if err := json.Unmarshal(body, &req); err != nil || req.Type != "request" {
+			continue
+		}
+
+		s.handle(&req)
+
+		if req.Command == "disconnect" || req.Command == "terminate" {
+			return nil
+		}
+	}
+}
+
+func (s *Session) handle(req *Message) {
+	handler, ok := handlers[req.Command]
+
+	if !ok {
+		s.reply(req, nil, fmt.Errorf("unsupported request: %s", req.Command))
+		return
+	}
+
+	body, err := handler(s, req.Arguments)
+	s.reply(req, body, err)
+}
+
+type handlerFunc func(s *Session, args json.RawMessage) (interface{}, error)
+
+var handlers = map[string]handlerFunc{
+	"initialize":        (*Session).initialize,
+	"launch":            (*Session).launch,
+	"setBreakpoints":    (*Session).setBreakpoints,
+	"configurationDone": (*Session).configurationDone,
+	"threads":           (*Session).threads,
+	"stackTrace":        (*Session).stackTrace,
+	"scopes":            (*Session).scopes,
+	"variables":         (*Session).variables,
+	"continue":          (*Session).continueRequest,
+	"next":              (*Session).next,
+	"stepIn":            (*Session).stepIn,
+	"stepOut":           (*Session).stepOut,
+	"pause":             (*Session).pause,
+	"disconnect":        (*Session).disconnect,
+	"terminate":         (*Session).disconnect,
+}
+
+func (s *Session) reply(req *Message, body interface{}, err error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.seq++
+	res := response{Seq: s.seq, Type: "response", RequestSeq: req.Seq, Success: err == nil, Command: req.Command, Body: body}
+
+	if err != nil {
+		res.Message = err.Error()
+	}
+
+	s.conn.Write(res)
+}
+
+func (s *Session) event(name string, body interface{}) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.seq++
+	s.conn.Write(event{Seq: s.seq, Type: "event", Event: name, Body: body})
+}
+
+// outputWriter turns what the program prints with `logs` into output events
+type outputWriter struct {
+	session *Session
+}
+
+func (w outputWriter) Write(p []byte) (int, error) {
+	w.session.event("output", OutputEvent{Category: "stdout", Output: string(p)})
+
+	return len(p), nil
+}
@@ -0,0 +1,432 @@
+package tests
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Mostafa-DE/delang/debugger"
+	"github.com/Mostafa-DE/delang/transport"
+)
+
+const PROGRAM = `let double = fun(x) {
+    let result = x * 2;
+    return result;
+};
+
+let items = [1, 2];
+let total = double(21);
+logs(total);
+`
+
+// client is a scripted DAP client talking to a session running in the same process
+type client struct {
+	t        *testing.T
+	conn     *transport.Conn
+	seq      int
+	messages chan *debugger.Message
+	events   []*debugger.Message // Events received while waiting for a response
+	done     chan error
+}
+
+func newClient(t *testing.T) *client {
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+
+	c := &client{
+		t:        t,
+		conn:     transport.NewConn(clientIn, clientOut),
+		messages: make(chan *debugger.Message, 100),
+		done:     make(chan error, 1),
+	}
+
+	go func() {
+		c.done <- debugger.NewSession(serverIn, serverOut).Serve()
+		serverOut.Close()
+	}()
+
+	go func() {
+		defer close(c.messages)
+
+		for {
+			body, err := c.conn.Read()
+
+			if err != nil {
+				return
+			}
+
+			msg := &debugger.Message{}
+			json.Unmarshal(body, msg)
+			c.messages <- msg
+		}
+	}()
+
+	t.Cleanup(func() {
+		c.send("disconnect", nil)
+
+		select {
+		case err := <-c.done:
+			if err != nil {
+				t.Errorf("Serve() returned an error: %v", err)
+			}
+
+		case <-time.After(2 * time.Second):
+			t.Errorf("Session did not stop after disconnect")
+		}
+	})
+
+	return c
+}
+
+func (c *client) send(command string, args interface{}) int {
+	c.seq++
+
+	c.conn.Write(struct {
+		Seq       int         `json:"seq"`
+		Type      string      `json:"type"`
+		Command   string      `json:"command"`
+		Arguments interface{} `json:"arguments,omitempty"`
+	}{c.seq, "request", command, args})
+
+	return c.seq
+}
+
+func (c *client) read() *debugger.Message {
+	select {
+	case msg, ok := <-c.messages:
+		if !ok {
+			c.t.Fatalf("Connection closed")
+		}
+
+		return msg
+
+	case <-time.After(2 * time.Second):
+		c.t.Fatalf("Timed out waiting for a message")
+	}
+
+	return nil
+}
+
+// request sends a request and decodes the body of its response, it returns the error message if it failed
+func (c *client) request(command string, args interface{}, body interface{}) string {
+	seq := c.send(command, args)
+
+	for {
+		msg := c.read()
+
+		if msg.Type == "event" {
+			c.events = append(c.events, msg)
+			continue
+		}
+
+		if msg.RequestSeq != seq || msg.Command != command {
+			c.t.Fatalf("Unexpected response. expected=%s(%d), got=%s(%d)", command, seq, msg.Command, msg.RequestSeq)
+		}
+
+		if !msg.Success {
+			return msg.Message
+		}
+
+		if body != nil {
+			if err := json.Unmarshal(msg.Body, body); err != nil {
+				c.t.Fatalf("Failed to decode the body of %s: %v", command, err)
+			}
+		}
+
+		return ""
+	}
+}
+
+func (c *client) mustRequest(command string, args interface{}, body interface{}) {
+	if err := c.request(command, args, body); err != "" {
+		c.t.Fatalf("%s failed: %s", command, err)
+	}
+}
+
+// event waits for the next event with the given name, output events are collected on the way
+func (c *client) event(name string, body interface{}) {
+	for {
+		var msg *debugger.Message
+
+		if len(c.events) > 0 {
+			msg, c.events = c.events[0], c.events[1:]
+		} else {
+			msg = c.read()
+		}
+
+		if msg.Type != "event" || (msg.Event != name && msg.Event == "output") {
+			continue
+		}
+
+		if msg.Event != name {
+			c.t.Fatalf("Unexpected event. expected=%s, got=%s", name, msg.Event)
+		}
+
+		if body != nil {
+			json.Unmarshal(msg.Body, body)
+		}
+
+		return
+	}
+}
+
+func (c *client) launch(source string, stopOnEntry bool, breakpoints ...int) {
+	c.mustRequest("initialize", map[string]string{"adapterID": "de"}, nil)
+	c.mustRequest("launch", debugger.LaunchArguments{Program: writeProgram(c.t, source), StopOnEntry: stopOnEntry}, nil)
+	c.event("initialized", nil)
+
+	args := debugger.SetBreakpointsArguments{}
+
+	for _, line := range breakpoints {
+		args.Breakpoints = append(args.Breakpoints, debugger.SourceBreakpoint{Line: line})
+	}
+
+	c.mustRequest("setBreakpoints", args, nil)
+	c.mustRequest("configurationDone", nil, nil)
+}
+
+func (c *client) expectStop(reason string, frames ...string) []debugger.StackFrame {
+	var stopped debugger.StoppedEvent
+	c.event("stopped", &stopped)
+
+	if stopped.Reason != reason {
+		c.t.Fatalf("Stop reason wrong. expected=%q, got=%q", reason, stopped.Reason)
+	}
+
+	var trace debugger.StackTraceResponse
+	c.mustRequest("stackTrace", map[string]int{"threadId": debugger.THREAD_ID}, &trace)
+
+	if len(trace.StackFrames) != len(frames) {
+		c.t.Fatalf("Wrong number of frames. expected=%d, got=%+v", len(frames), trace.StackFrames)
+	}
+
+	for idx, name := range frames {
+		if trace.StackFrames[idx].Name != name {
+			c.t.Errorf("Frame %d name wrong. expected=%q, got=%q", idx, name, trace.StackFrames[idx].Name)
+		}
+	}
+
+	return trace.StackFrames
+}
+
+func (c *client) variables(reference int) map[string]debugger.Variable {
+	var res debugger.VariablesResponse
+	c.mustRequest("variables", debugger.VariablesArguments{VariablesReference: reference}, &res)
+
+	variables := make(map[string]debugger.Variable)
+
+	for _, v := range res.Variables {
+		variables[v.Name] = v
+	}
+
+	return variables
+}
+
+func writeProgram(t *testing.T, source string) string {
+	path := filepath.Join(t.TempDir(), "main.de")
+
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatalf("Failed to write program: %v", err)
+	}
+
+	return path
+}
+
+func TestSetBreakpoints(t *testing.T) {
+	c := newClient(t)
+
+	c.mustRequest("initialize", nil, nil)
+	c.mustRequest("launch", debugger.LaunchArguments{Program: writeProgram(t, PROGRAM)}, nil)
+
+	var res debugger.SetBreakpointsResponse
+	c.mustRequest("setBreakpoints", debugger.SetBreakpointsArguments{
+		Breakpoints: []debugger.SourceBreakpoint{{Line: 2}, {Line: 5}},
+	}, &res)
+
+	expected := []debugger.Breakpoint{{Verified: true, Line: 2}, {Verified: false, Line: 5, Message: "No statement on this line"}}
+
+	if len(res.Breakpoints) != len(expected) {
+		t.Fatalf("Wrong number of breakpoints. got=%+v", res.Breakpoints)
+	}
+
+	for idx, bp := range expected {
+		if res.Breakpoints[idx] != bp {
+			t.Errorf("Breakpoint %d wrong. expected=%+v, got=%+v", idx, bp, res.Breakpoints[idx])
+		}
+	}
+}
+
+func TestBreakpointAndVariables(t *testing.T) {
+	c := newClient(t)
+	c.launch(PROGRAM, false, 2)
+
+	frames := c.expectStop("breakpoint", "double", "main")
+
+	if frames[0].Line != 2 || frames[1].Line != 7 {
+		t.Errorf("Frame lines wrong. expected=2 and 7, got=%d and %d", frames[0].Line, frames[1].Line)
+	}
+
+	var scopes debugger.ScopesResponse
+	c.mustRequest("scopes", debugger.ScopesArguments{FrameID: frames[0].ID}, &scopes)
+
+	if len(scopes.Scopes) != 2 || scopes.Scopes[0].Name != "Locals" || scopes.Scopes[1].Name != "Globals" {
+		t.Fatalf("Scopes wrong. got=%+v", scopes.Scopes)
+	}
+
+	locals := c.variables(scopes.Scopes[0].VariablesReference)
+
+	if x := locals["x"]; x.Value != "21" || x.Type != "INTEGER" {
+		t.Errorf("Local x wrong. got=%+v", x)
+	}
+
+	globals := c.variables(scopes.Scopes[1].VariablesReference)
+
+	if _, ok := globals["double"]; !ok {
+		t.Errorf("Global double missing. got=%+v", globals)
+	}
+
+	if _, ok := globals["_getDecimalData"]; ok {
+		t.Errorf("Internal names should not be listed")
+	}
+
+	items := globals["items"]
+
+	if items.VariablesReference == 0 {
+		t.Fatalf("Array should have a variables reference. got=%+v", items)
+	}
+
+	elements := c.variables(items.VariablesReference)
+
+	if elements["0"].Value != "1" || elements["1"].Value != "2" {
+		t.Errorf("Array elements wrong. got=%+v", elements)
+	}
+
+	c.mustRequest("continue", nil, nil)
+
+	var output debugger.OutputEvent
+	c.event("output", &output)
+
+	if output.Output != "42\n" {
+		t.Errorf("Output wrong. expected=%q, got=%q", "42\n", output.Output)
+	}
+
+	var exited debugger.ExitedEvent
+	c.event("exited", &exited)
+
+	if exited.ExitCode != 0 {
+		t.Errorf("Exit code wrong. expected=0, got=%d", exited.ExitCode)
+	}
+
+	c.event("terminated", nil)
+}
+
+func TestStepping(t *testing.T) {
+	c := newClient(t)
+	c.launch(PROGRAM, true)
+
+	tests := []struct {
+		command string
+		reason  string
+		line    int
+		frames  []string
+	}{
+		{"", "entry", 1, []string{"main"}},
+		{"next", "step", 6, []string{"main"}},
+		{"next", "step", 7, []string{"main"}},
+		{"stepIn", "step", 2, []string{"double", "main"}},
+		{"next", "step", 3, []string{"double", "main"}},
+		{"stepOut", "step", 8, []string{"main"}},
+	}
+
+	for _, tt := range tests {
+		if tt.command != "" {
+			c.mustRequest(tt.command, map[string]int{"threadId": debugger.THREAD_ID}, nil)
+		}
+
+		frames := c.expectStop(tt.reason, tt.frames...)
+
+		if frames[0].Line != tt.line {
+			t.Fatalf("After %q line wrong. expected=%d, got=%d", tt.command, tt.line, frames[0].Line)
+		}
+	}
+
+	c.mustRequest("next", nil, nil)
+	c.event("exited", nil)
+}
+
+func TestStepOverLoop(t *testing.T) {
+	c := newClient(t)
+	c.launch("let i = 0;\nduring i < 2: {\n    i = i + 1;\n}\nlogs(i);\n", false, 3)
+
+	for range 2 {
+		frames := c.expectStop("breakpoint", "main")
+
+		if frames[0].Line != 3 {
+			t.Fatalf("Line wrong. expected=3, got=%d", frames[0].Line)
+		}
+
+		c.mustRequest("continue", nil, nil)
+	}
+
+	c.event("exited", nil)
+}
+
+func TestFunctionDeclarationFrame(t *testing.T) {
+	c := newClient(t)
+	c.launch("let n = square(3);\nfun square(x) {\n    return x * x;\n}\n", false, 3)
+
+	frames := c.expectStop("breakpoint", "square", "main")
+
+	if frames[0].Line != 3 || frames[1].Line != 1 {
+		t.Errorf("Frame lines wrong. expected=3 and 1, got=%d and %d", frames[0].Line, frames[1].Line)
+	}
+
+	c.mustRequest("continue", nil, nil)
+	c.event("exited", nil)
+}
+
+func TestErrors(t *testing.T) {
+	c := newClient(t)
+	c.mustRequest("initialize", nil, nil)
+
+	if err := c.request("launch", debugger.LaunchArguments{Program: writeProgram(t, "let = 1;")}, nil); err == "" {
+		t.Errorf("Launching a program with syntax errors should fail")
+	}
+
+	if err := c.request("continue", nil, nil); err == "" {
+		t.Errorf("Continuing a program that is not stopped should fail")
+	}
+
+	if err := c.request("evaluate", nil, nil); err != "unsupported request: evaluate" {
+		t.Errorf("Unknown request error wrong. got=%q", err)
+	}
+}
+
+func TestRuntimeError(t *testing.T) {
+	c := newClient(t)
+	c.launch("logs(1);\nlet x = 1 + true;\n", false)
+
+	var output debugger.OutputEvent
+	c.event("output", &output)
+	c.event("output", &output)
+
+	if output.Category != "stderr" || output.Output != "ERROR: type mismatch: INTEGER + BOOLEAN\n" {
+		t.Errorf("Error output wrong. got=%+v", output)
+	}
+
+	var exited debugger.ExitedEvent
+	c.event("exited", &exited)
+
+	if exited.ExitCode != 1 {
+		t.Errorf("Exit code wrong. expected=1, got=%d", exited.ExitCode)
+	}
+}
+
+func TestDisconnectWhileStopped(t *testing.T) {
+	c := newClient(t)
+	c.launch("let i = 0;\nduring true: {\n    i = i + 1;\n}\n", false, 3)
+	c.expectStop("breakpoint", "main")
+}
@@ -0,0 +1,85 @@
+package debugger
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Mostafa-DE/delang/object"
+)
+
+// handles maps the variables references given to the client back to environments and values,
+// the references are only valid while the program stays stopped
+type handles struct {
+	values []interface{} // *object.Environment, *object.Array or *object.Hash
+}
+
+func newHandles() *handles {
+	return &handles{}
+}
+
+func (h *handles) add(value interface{}) int {
+	h.values = append(h.values, value)
+
+	return len(h.values)
+}
+
+// scopes returns a scope for every environment from env up to the main environment
+func (h *handles) scopes(env *object.Environment) []Scope {
+	scopes := []Scope{}
+
+	for current := env; current != nil; current = current.GetOuterEnv() {
+		name := "Closure"
+
+		switch {
+		case current.GetOuterEnv() == nil:
+			name = "Globals"
+		case current == env:
+			name = "Locals"
+		}
+
+		scopes = append(scopes, Scope{Name: name, VariablesReference: h.add(current)})
+	}
+
+	return scopes
+}
+
+func (h *handles) variables(reference int) ([]Variable, bool) {
+	if reference < 1 || reference > len(h.values) {
+		return nil, false
+	}
+
+	variables := []Variable{}
+
+	switch value := h.values[reference-1].(type) {
+	case *object.Environment:
+		for _, name := range value.Names() {
+			obj, _ := value.Get(name)
+			variables = append(variables, h.variable(name, obj))
+		}
+
+	case *object.Array:
+		for idx, element := range value.Elements {
+			variables = append(variables, h.variable(fmt.Sprint(idx), element))
+		}
+
+	case *object.Hash:
+		for _, pair := range value.Pairs {
+			variables = append(variables, h.variable(pair.Key.Inspect(), pair.Value))
+		}
+
+		sort.Slice(variables, func(i, j int) bool { return variables[i].Name < variables[j].Name })
+	}
+
+	return variables, true
+}
+
+func (h *handles) variable(name string, obj object.Object) Variable {
+	variable := Variable{Name: name, Value: obj.Inspect(), Type: string(obj.Type())}
+
+	switch obj.(type) {
+	case *object.Array, *object.Hash:
+		variable.VariablesReference = h.add(obj)
+	}
+
+	return variable
+}
@@ -4,12 +4,13 @@ import "github.com/Mostafa-DE/delang/object"
 
 func evalArrayIndex(array object.Object, index object.Object) object.Object {
 	arrayObject := array.(*object.Array)
-	idx := index.(*object.Integer).Value
+	integer, ok := index.(*object.Integer)
 	max := int64(len(arrayObject.Elements) - 1)
 
-	if idx < 0 || idx > max {
+	// A big integer is always out of range
+	if !ok || integer.Value < 0 || integer.Value > max {
 		return throwError("Index out of range")
 	}
 
-	return arrayObject.Elements[idx]
+	return arrayObject.Elements[integer.Value]
 }
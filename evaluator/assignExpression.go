@@ -5,24 +5,124 @@ import (
 	"github.com/Mostafa-DE/delang/object"
 )
 
+/*
+evalAssignExpression assigns to a variable, an index (`grid[i][j]`) or a member (`person.name`).
+The expressions of the target are evaluated once, `arr[next()] += 1` calls next a single time.
+An assignment evaluates to the assigned value, except `x++` and `x--` that evaluate to the value before the change.
+*/
 func evalAssignExpression(node *ast.AssignExpression, env *object.Environment) object.Object {
-	val := Eval(node.Value, env)
+	var get func() object.Object
+	var set func(val object.Object) object.Object
+
+	switch target := node.Target.(type) {
+	case *ast.Identifier:
+		name := target.Value
+
+		get = func() object.Object {
+			if val, ok := env.Get(name); ok {
+				return val
+			}
+
+			return throwError("identifier not found: %s", name)
+		}
+
+		set = func(val object.Object) object.Object {
+			targetEnv := env.GetTargetEnv(name)
+
+			if targetEnv == nil {
+				return throwError("identifier not found: %s", name)
+			}
+
+			return targetEnv.Set(name, val, false)
+		}
+
+	case *ast.IndexExpression:
+		container := Eval(target.Ident, env)
+
+		if isError(container) {
+			return container
+		}
+
+		idx := Eval(target.Index, env)
+
+		if isError(idx) {
+			return idx
+		}
+
+		get = func() object.Object { return evalIndexExpression(container, idx) }
+		set = func(val object.Object) object.Object { return setIndexExpression(container, idx, val) }
+
+	case *ast.MemberExpression:
+		receiver := Eval(target.Object, env)
+
+		if isError(receiver) {
+			return receiver
+		}
+
+		name := target.Member.Value
+
+		get = func() object.Object { return getMember(receiver, name, env) }
+		set = func(val object.Object) object.Object { return setMember(receiver, name, val) }
+
+	default:
+		return throwError("cannot assign to %s", node.Target.String())
+	}
+
+	// A plain assignment does not read the target, `x = 1` works on a key that does not exist yet
+	if node.Operator == "=" {
+		val := Eval(node.Value, env)
+
+		if isError(val) {
+			return val
+		}
+
+		if result := set(val); isError(result) {
+			return result
+		}
 
-	if isError(val) {
 		return val
 	}
 
-	_, ok := env.Get(node.Ident.Value)
+	current := get()
+
+	if isError(current) {
+		return current
+	}
+
+	var operand object.Object = &object.Integer{Value: 1}
+
+	if node.Value != nil {
+		operand = Eval(node.Value, env)
+
+		if isError(operand) {
+			return operand
+		}
+	}
+
+	val := evalInfixExpression(compoundOperators[node.Operator], current, operand, env)
 
-	if !ok {
-		return throwError("identifier not found: %s", node.Ident.Value)
+	if isError(val) {
+		return val
 	}
 
-	returnVal := env.GetTargetEnv(node.Ident.Value).Set(node.Ident.Value, val, false)
+	if result := set(val); isError(result) {
+		return result
+	}
 
-	if isError(returnVal) {
-		return returnVal
+	if node.Operator == "++" || node.Operator == "--" {
+		return current
 	}
 
-	return returnVal
+	return val
+}
+
+// compoundOperators maps a compound assignment to the infix operator it applies
+var compoundOperators = map[string]string{
+	"+=": "+",
+	"-=": "-",
+	"*=": "*",
+	"/=": "/",
+	"%=": "%",
+	"++": "+",
+	"--": "-",
 }
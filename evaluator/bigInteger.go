@@ -0,0 +1,101 @@
+package evaluator
+
+import (
+	"math/big"
+
+	"github.com/Mostafa-DE/delang/object"
+	"github.com/shopspring/decimal"
+)
+
+/*
+Integers are int64 until a result does not fit, then they are promoted to a *object.BigInteger:
+
+- `9223372036854775807 + 1` is 9223372036854775808 and not a negative number.
+- Both are INTEGER for typeof, comparisons, hash keys, int() and str().
+- object.NewInteger turns results that fit again back into an *object.Integer.
+- A result with more bits than the runtime allows is an error, see bigIntegerResult.
+*/
+func evalBigIntegerInfixExpression(operator string, left *big.Int, right *big.Int, env *object.Environment) object.Object {
+	switch operator {
+	case "+":
+		return bigIntegerResult(new(big.Int).Add(left, right), env)
+
+	case "-":
+		return bigIntegerResult(new(big.Int).Sub(left, right), env)
+
+	case "*":
+		return bigIntegerResult(new(big.Int).Mul(left, right), env)
+
+	case "/":
+		if right.Sign() == 0 {
+			return throwError("division by zero")
+		}
+
+		// Quo and Rem truncate toward zero like the int64 operators, Div and Mod would not
+		return bigIntegerResult(new(big.Int).Quo(left, right), env)
+
+	case "%":
+		if right.Sign() == 0 {
+			return throwError("division by zero")
+		}
+
+		return bigIntegerResult(new(big.Int).Rem(left, right), env)
+
+	case "<":
+		return getBooleanObject(left.Cmp(right) < 0)
+
+	case ">":
+		return getBooleanObject(left.Cmp(right) > 0)
+
+	case "<=":
+		return getBooleanObject(left.Cmp(right) <= 0)
+
+	case ">=":
+		return getBooleanObject(left.Cmp(right) >= 0)
+
+	case "==":
+		return getBooleanObject(left.Cmp(right) == 0)
+
+	case "!=":
+		return getBooleanObject(left.Cmp(right) != 0)
+
+	default:
+		return throwError("unknown operator: %s %s %s", object.INTEGER_OBJ, operator, object.INTEGER_OBJ)
+	}
+}
+
+// bigIntegerResult is the integer object of value, or an error when value has more bits than the runtime allows
+func bigIntegerResult(value *big.Int, env *object.Environment) object.Object {
+	if err := checkIntegerBits(value.BitLen(), env); err != nil {
+		return err
+	}
+
+	return object.NewInteger(value)
+}
+
+// bigIntegerValue is the value of an INTEGER object whatever its representation
+func bigIntegerValue(integer object.Object) *big.Int {
+	if bigInteger, ok := integer.(*object.BigInteger); ok {
+		return bigInteger.Value
+	}
+
+	return big.NewInt(integer.(*object.Integer).Value)
+}
+
+func integerToFloat(integer object.Object) *object.Float {
+	if small, ok := integer.(*object.Integer); ok {
+		return &object.Float{Value: float64(small.Value)}
+	}
+
+	value, _ := new(big.Float).SetInt(bigIntegerValue(integer)).Float64()
+
+	return &object.Float{Value: value}
+}
+
+func integerToDecimal(integer object.Object) *object.Decimal {
+	if small, ok := integer.(*object.Integer); ok {
+		return &object.Decimal{Value: decimal.NewFromInt(small.Value)}
+	}
+
+	return &object.Decimal{Value: decimal.NewFromBigInt(bigIntegerValue(integer), 0)}
+}
@@ -2,6 +2,11 @@ package evaluator
 
 import (
 	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"sort"
 	"time"
 
 	"github.com/Mostafa-DE/delang/object"
@@ -226,15 +231,7 @@ var builtins = map[string]*object.Builtin{
 
 	"logs": {
 		Func: func(args ...object.Object) object.Object {
-			for _, arg := range args {
-				if arg.Type() == object.STRING_OBJ {
-					fmt.Printf("'%s'\n", arg.Inspect())
-				} else {
-					fmt.Println(arg.Inspect())
-				}
-			}
-
-			return NULL
+			return writeLogs(os.Stdout, args)
 		},
 		Desc: "Prints the result to the console",
 		Name: "logs",
@@ -272,11 +269,17 @@ var builtins = map[string]*object.Builtin{
 				return throwError("wrong number of arguments passed to range(). got=%d, want=2", len(args))
 			}
 
-			if len(args) == 1 {
-				if args[0].Type() != object.INTEGER_OBJ {
+			for _, arg := range args {
+				if arg.Type() != object.INTEGER_OBJ {
 					return throwError("argument to `range` must be INTEGER")
 				}
 
+				if _, ok := arg.(*object.Integer); !ok {
+					return throwError("argument to `range` is too large, got %s", arg.Inspect())
+				}
+			}
+
+			if len(args) == 1 {
 				if args[0].(*object.Integer).Value < 0 {
 					return &object.Array{Elements: []object.Object{}}
 				}
@@ -284,10 +287,6 @@ var builtins = map[string]*object.Builtin{
 				return makeRangeArray(0, args[0].(*object.Integer).Value-1)
 
 			} else {
-				if args[0].Type() != object.INTEGER_OBJ || args[1].Type() != object.INTEGER_OBJ {
-					return throwError("argument to `range` must be INTEGER")
-				}
-
 				return makeRangeArray(args[0].(*object.Integer).Value, args[1].(*object.Integer).Value-1)
 			}
 
@@ -303,8 +302,8 @@ var builtins = map[string]*object.Builtin{
 			}
 
 			switch arg := args[0].(type) {
-			case *object.Integer:
-				return &object.Decimal{Value: decimal.NewFromInt(arg.Value)}
+			case *object.Integer, *object.BigInteger:
+				return integerToDecimal(arg)
 
 			case *object.Float:
 				return &object.Decimal{Value: decimal.NewFromFloat(arg.Value)}
@@ -374,18 +373,7 @@ var builtins = map[string]*object.Builtin{
 
 	"input": {
 		Func: func(args ...object.Object) object.Object {
-			if len(args) > 1 {
-				return throwError("wrong number of arguments passed to input(). got=%d", len(args))
-			}
-
-			if len(args) == 1 {
-				fmt.Println(args[0].Inspect())
-			}
-
-			var input string
-			fmt.Scanln(&input)
-
-			return &object.String{Value: input}
+			return readInput(os.Stdin, os.Stdout, args)
 		},
 		Desc: "Reads a line from the standard input",
 		Name: "input",
@@ -404,13 +392,19 @@ var builtins = map[string]*object.Builtin{
 					return throwError("string argument to `int` not supported, got `%s`", args[0].Inspect())
 				}
 
-				return &object.Integer{Value: val.IntPart()}
+				return object.NewInteger(val.BigInt())
 
 			case *object.Decimal:
-				return &object.Integer{Value: arg.Value.IntPart()}
+				return object.NewInteger(arg.Value.BigInt())
 
 			case *object.Float:
-				return &object.Integer{Value: int64(arg.Value)}
+				if math.IsNaN(arg.Value) || math.IsInf(arg.Value, 0) {
+					return throwError("argument to `int` not supported, got `%s`", args[0].Inspect())
+				}
+
+				value, _ := big.NewFloat(arg.Value).Int(nil)
+
+				return object.NewInteger(value)
 
 			case *object.Boolean:
 				if arg.Value {
@@ -419,7 +413,7 @@ var builtins = map[string]*object.Builtin{
 
 				return &object.Integer{Value: 0}
 
-			case *object.Integer:
+			case *object.Integer, *object.BigInteger:
 				return arg
 
 			default:
@@ -452,8 +446,8 @@ var builtins = map[string]*object.Builtin{
 				floatval, _ := arg.Value.Float64()
 				return &object.Float{Value: floatval}
 
-			case *object.Integer:
-				return &object.Float{Value: float64(arg.Value)}
+			case *object.Integer, *object.BigInteger:
+				return integerToFloat(arg)
 
 			case *object.Float:
 				return arg
@@ -473,42 +467,7 @@ var builtins = map[string]*object.Builtin{
 				return throwError("wrong number of arguments passed to bool(). got=%d, want=1", len(args))
 			}
 
-			switch arg := args[0].(type) {
-			case *object.String:
-				if arg.Value == "" {
-					return FALSE
-				}
-
-				return TRUE
-
-			case *object.Integer:
-				if arg.Value == 0 {
-					return FALSE
-				}
-
-				return TRUE
-
-			case *object.Float:
-				if arg.Value == 0 {
-					return FALSE
-				}
-
-				return TRUE
-
-			case *object.Decimal:
-				if arg.Value.IsZero() {
-					return FALSE
-				}
-
-				return TRUE
-
-			case *object.Boolean:
-				return arg
-
-			default:
-				return TRUE
-
-			}
+			return getBooleanObject(isTruthy(args[0]))
 		},
 		Desc: "Converts a value to a boolean",
 		Name: "bool",
@@ -524,7 +483,7 @@ var builtins = map[string]*object.Builtin{
 			case *object.String:
 				return arg
 
-			case *object.Integer:
+			case *object.Integer, *object.BigInteger:
 				return &object.String{Value: arg.Inspect()}
 
 			case *object.Float:
@@ -556,3 +515,73 @@ var builtins = map[string]*object.Builtin{
 		Name: "time",
 	},
 }
+
+// writeLogs prints the arguments of `logs` one per line, evalFunction passes the output of the runtime
+func writeLogs(out io.Writer, args []object.Object) object.Object {
+	for _, arg := range args {
+		if arg.Type() == object.STRING_OBJ {
+			fmt.Fprintf(out, "'%s'\n", arg.Inspect())
+		} else {
+			fmt.Fprintln(out, arg.Inspect())
+		}
+	}
+
+	return NULL
+}
+
+// readInput prints the prompt of `input` and reads the answer, evalFunction passes the input and output of the runtime
+func readInput(in io.Reader, out io.Writer, args []object.Object) object.Object {
+	if len(args) > 1 {
+		return throwError("wrong number of arguments passed to input(). got=%d", len(args))
+	}
+
+	if len(args) == 1 {
+		fmt.Fprintln(out, args[0].Inspect())
+	}
+
+	var input string
+	fmt.Fscanln(in, &input)
+
+	return &object.String{Value: input}
+}
+
+/*
+RegisterBuiltin makes a plain Go function callable from every script under the given name, see object.NewBuiltin.
+The builtins are shared by every interpreter so it has to be called before any script runs, e.g. in an init function.
+To give a function to a single program set it in its environment instead.
+*/
+func RegisterBuiltin(name string, desc string, fn interface{}) error {
+	if _, ok := builtins[name]; ok {
+		return fmt.Errorf("builtin `%s` is already registered", name)
+	}
+
+	builtin, err := object.NewBuiltin(name, desc, fn)
+
+	if err != nil {
+		return err
+	}
+
+	builtins[name] = builtin
+
+	return nil
+}
+
+// GetBuiltin returns the builtin function registered under the given name
+func GetBuiltin(name string) (*object.Builtin, bool) {
+	builtin, ok := builtins[name]
+
+	return builtin, ok
+}
+
+// BuiltinNames returns the names of every builtin function sorted alphabetically
+func BuiltinNames() []string {
+	names := make([]string, 0, len(builtins))
+
+	for name := range builtins {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
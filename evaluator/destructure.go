@@ -0,0 +1,114 @@
+package evaluator
+
+import (
+	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/object"
+)
+
+/*
+bindPattern binds value to the names of pattern in env, it is shared by let, const, for and function parameters:
+
+- A name is set like a plain variable, isConst marks it as a constant.
+- An array pattern takes the elements in order, `...rest` collects what is left into a new array,
+extra elements are ignored when there is no rest.
+- A hash pattern looks up each key, `{age: years}` binds the value of "age" to years.
+- A missing element or key uses the default of its slot, it is an error when there is none.
+
+Defaults are evaluated in env, so they can refer to the names bound before them.
+*/
+func bindPattern(pattern ast.Expression, value object.Object, env *object.Environment, isConst bool) object.Object {
+	switch pattern := pattern.(type) {
+	case *ast.Identifier:
+		return env.Set(pattern.Value, value, isConst)
+
+	case *ast.ArrayPattern:
+		return bindArrayPattern(pattern, value, env, isConst)
+
+	case *ast.HashPattern:
+		return bindHashPattern(pattern, value, env, isConst)
+	}
+
+	return throwError("Cannot bind a value to '%s'", pattern.String())
+}
+
+func bindArrayPattern(pattern *ast.ArrayPattern, value object.Object, env *object.Environment, isConst bool) object.Object {
+	array, ok := value.(*object.Array)
+
+	if !ok {
+		return throwError("Cannot destructure %s with the array pattern %s", value.Type(), pattern.String())
+	}
+
+	for idx, element := range pattern.Elements {
+		if idx >= len(array.Elements) {
+			if element.Default == nil {
+				return throwError(
+					"Cannot destructure %s: the array has %d elements, no value for '%s'",
+					pattern.String(), len(array.Elements), element.Target.String(),
+				)
+			}
+
+			if res := bindDefault(element, env, isConst); isError(res) {
+				return res
+			}
+
+			continue
+		}
+
+		if res := bindPattern(element.Target, array.Elements[idx], env, isConst); isError(res) {
+			return res
+		}
+	}
+
+	if pattern.Rest != nil {
+		rest := []object.Object{}
+
+		if len(array.Elements) > len(pattern.Elements) {
+			rest = append(rest, array.Elements[len(pattern.Elements):]...)
+		}
+
+		return env.Set(pattern.Rest.Value, &object.Array{Elements: rest}, isConst)
+	}
+
+	return NULL
+}
+
+func bindHashPattern(pattern *ast.HashPattern, value object.Object, env *object.Environment, isConst bool) object.Object {
+	hash, ok := value.(*object.Hash)
+
+	if !ok {
+		return throwError("Cannot destructure %s with the hash pattern %s", value.Type(), pattern.String())
+	}
+
+	for _, entry := range pattern.Entries {
+		key := &object.String{Value: entry.Key.Value}
+		pair, ok := hash.Pairs[key.HashKey()]
+
+		if !ok {
+			if entry.Element.Default == nil {
+				return throwError("Cannot destructure %s: the hash has no key '%s'", pattern.String(), entry.Key.Value)
+			}
+
+			if res := bindDefault(entry.Element, env, isConst); isError(res) {
+				return res
+			}
+
+			continue
+		}
+
+		if res := bindPattern(entry.Element.Target, pair.Value, env, isConst); isError(res) {
+			return res
+		}
+	}
+
+	return NULL
+}
+
+func bindDefault(element *ast.PatternElement, env *object.Environment, isConst bool) object.Object {
+	value := Eval(element.Default, env)
+
+	if isError(value) {
+		return value
+	}
+
+	return bindPattern(element.Target, value, env, isConst)
+}
@@ -23,10 +23,14 @@ func evalDuringExpression(node *ast.DuringExpression, env *object.Environment) o
 
 loop:
 	for isTruthy(condition) {
+		// An error is truthy, a hook that stops the program returns one from the condition of an empty loop
+		if isError(condition) {
+			return condition
+		}
 
 		select {
 		case <-timeout:
-			fmt.Println("Timeout exceeded")
+			fmt.Fprintln(env.Runtime().Output, "Timeout exceeded")
 			env.Set("timeoutExceeded", &object.Boolean{Value: true}, false)
 			break loop
 
@@ -3,18 +3,5 @@ package evaluator
 import "github.com/Mostafa-DE/delang/object"
 
 func evalExclamationOperatorExpression(right object.Object) object.Object {
-	switch right {
-	case TRUE:
-		return FALSE
-
-	case FALSE:
-		return TRUE
-
-	case NULL:
-		return TRUE
-
-	default:
-		return FALSE
-
-	}
+	return getBooleanObject(!isTruthy(right))
 }
@@ -12,16 +12,13 @@ func evalForStatement(node *ast.ForStatement, env *object.Environment) object.Ob
 		idxIdent = node.IdxIdent.Value
 	}
 
-	var varIdent string
-	if node.VarIdent == nil {
+	if node.VarIdent == nil && node.VarPattern == nil {
 		return throwError("Expected a variable identifier after for statement")
-	} else {
-		varIdent = node.VarIdent.Value
 	}
 
 	body := node.Body
 
-	if idxIdent == varIdent {
+	if node.VarIdent != nil && idxIdent == node.VarIdent.Value {
 		return throwError("Index identifier and variable identifier cannot be the same")
 	}
 
@@ -35,23 +32,46 @@ func evalForStatement(node *ast.ForStatement, env *object.Environment) object.Ob
 		return eval
 	}
 
+	// bind sets the loop variables for one iteration, a pattern destructures the element
+	bind := func(idx object.Object, val object.Object) object.Object {
+		if idxIdent != "" {
+			localEnv.Set(idxIdent, idx, false)
+		}
+
+		if node.VarPattern != nil {
+			return bindPattern(node.VarPattern, val, localEnv, false)
+		}
+
+		return localEnv.Set(node.VarIdent.Value, val, false)
+	}
+
 	switch eval.Type() {
 	case object.STRING_OBJ:
-		res := stringLoop(eval.(*object.String), idxIdent, varIdent, body, localEnv)
+		res := stringLoop(eval.(*object.String), bind, body, localEnv)
 
 		if isError(res) {
 			return res
 		}
 
 	case object.ARRAY_OBJ:
-		res := arrayLoop(eval.(*object.Array), idxIdent, varIdent, body, localEnv)
+		res := arrayLoop(eval.(*object.Array), bind, body, localEnv)
 
 		if isError(res) {
 			return res
 		}
 
 	default:
-		return throwError("Type %s is not iterable", eval.Type())
+		iterable, ok := eval.(object.Iterable)
+
+		if !ok {
+			return throwError("Type %s is not iterable", eval.Type())
+		}
+
+		res := hostLoop(iterable, bind, body, localEnv)
+
+		if isError(res) {
+			return res
+		}
 	}
 
 	return NULL
@@ -59,17 +79,15 @@ func evalForStatement(node *ast.ForStatement, env *object.Environment) object.Ob
 
 func arrayLoop(
 	array *object.Array,
-	idxIdent string,
-	varIdent string, body *ast.BlockStatement,
+	bind func(idx object.Object, val object.Object) object.Object,
+	body *ast.BlockStatement,
 	env *object.Environment,
 ) object.Object {
 	for idx, val := range array.Elements {
-		if idxIdent != "" {
-			env.Set(idxIdent, &object.Integer{Value: int64(idx)}, false)
+		if res := bind(&object.Integer{Value: int64(idx)}, val); isError(res) {
+			return res
 		}
 
-		env.Set(varIdent, val, false)
-
 		result := evalBlockStatement(body.Statements, env)
 
 		if isError(result) {
@@ -92,14 +110,14 @@ func arrayLoop(
 
 func stringLoop(
 	str *object.String,
-	idxIdent string,
-	varIdent string,
+	bind func(idx object.Object, val object.Object) object.Object,
 	body *ast.BlockStatement,
 	env *object.Environment,
 ) object.Object {
 	for idx, val := range str.Value {
-		env.Set(idxIdent, &object.Integer{Value: int64(idx)}, false)
-		env.Set(varIdent, &object.String{Value: string(val)}, false)
+		if res := bind(&object.Integer{Value: int64(idx)}, &object.String{Value: string(val)}); isError(res) {
+			return res
+		}
 
 		result := evalBlockStatement(body.Statements, env)
 
@@ -120,3 +138,39 @@ func stringLoop(
 
 	return NULL
 }
+
+// hostLoop iterates over a host object, the key goes to the index identifier
+func hostLoop(
+	iterable object.Iterable,
+	bind func(idx object.Object, val object.Object) object.Object,
+	body *ast.BlockStatement,
+	env *object.Environment,
+) object.Object {
+	var loopErr object.Object
+
+	err := iterable.Iterate(func(key object.Object, value object.Object) bool {
+		if res := bind(key, value); isError(res) {
+			loopErr = res
+			return false
+		}
+
+		result := evalBlockStatement(body.Statements, env)
+
+		if isError(result) {
+			loopErr = result
+			return false
+		}
+
+		return result == nil || result.Type() != object.BREAK_OBJ
+	})
+
+	if loopErr != nil {
+		return loopErr
+	}
+
+	if isError(err) {
+		return err
+	}
+
+	return NULL
+}
@@ -3,20 +3,42 @@ package evaluator
 import (
 	"bytes"
 
+	"github.com/Mostafa-DE/delang/ast"
 	"github.com/Mostafa-DE/delang/object"
 )
 
-func evalFunction(fun object.Object, args []object.Object, env *object.Environment) object.Object {
+// keywordArgument is a `name: value` argument of a call, they are kept in source order so errors are deterministic
+type keywordArgument struct {
+	name  string
+	value object.Object
+}
+
+func evalFunction(fun object.Object, args []object.Object, keywords []keywordArgument, env *object.Environment) object.Object {
+	if _, ok := fun.(*object.Function); !ok && len(keywords) > 0 {
+		return throwError("keyword argument '%s' passed to %s, only functions declared with fun accept keyword arguments", keywords[0].name, fun.Type())
+	}
+
 	switch fun := fun.(type) {
 	case *object.Function:
-		if len(fun.Parameters) != len(args) {
-			return throwError("wrong number of arguments: want=%d, got=%d", len(fun.Parameters), len(args))
+		localEnv, err := createLocalEnv(fun, args, keywords)
+
+		if err != nil {
+			return err
 		}
 
-		localEnv := createLocalEnv(fun, args)
-		evaluated := Eval(fun.Body, localEnv)
+		hooks := env.Runtime().Hooks
 
-		return unwrapReturnValue(evaluated)
+		if hooks != nil && hooks.EnterFunction != nil {
+			hooks.EnterFunction(fun, args, localEnv)
+		}
+
+		result := unwrapReturnValue(Eval(fun.Body, localEnv))
+
+		if hooks != nil && hooks.ExitFunction != nil {
+			hooks.ExitFunction(fun, result, localEnv)
+		}
+
+		return result
 
 	case *object.Builtin:
 		// TODO: This should be handled in a better way
@@ -25,8 +47,15 @@ func evalFunction(fun object.Object, args []object.Object, env *object.Environme
 			var buffer []bytes.Buffer
 
 			for _, arg := range args {
+				// writeLogs inspects the arguments again, once they are known to fit it cannot run for long
+				text, err := inspectWithin(arg, env)
+
+				if err != nil {
+					return err
+				}
+
 				buffer = append(buffer, bytes.Buffer{})
-				buffer[len(buffer)-1].WriteString(arg.Inspect())
+				buffer[len(buffer)-1].WriteString(text)
 			}
 
 			if env.GetOuterEnv() != nil {
@@ -39,24 +68,185 @@ func evalFunction(fun object.Object, args []object.Object, env *object.Environme
 				env.Set("bufferLogs", &object.Buffer{Value: buffer}, false)
 			}
 
+			return writeLogs(env.Runtime().Output, args)
+		}
+
+		if fun.Name == "input" {
+			return readInput(env.Runtime().Input, env.Runtime().Output, args)
+		}
+
+		if err := checkBuiltinLimits(fun.Name, args, env); err != nil {
+			return err
 		}
 
 		return fun.Func(args...)
 
+	case object.Callable:
+		return fun.Call(args...)
+
 	default:
 		return throwError("not a function: %s", fun.Type())
 	}
 
 }
 
-func createLocalEnv(fun *object.Function, args []object.Object) *object.Environment {
+// hoistFunctions binds the function declarations of a block before its first statement runs,
+// so they can be called before they are declared and can call each other
+func hoistFunctions(statements []ast.Statement, env *object.Environment) object.Object {
+	for _, statement := range statements {
+		declaration, ok := statement.(*ast.FunctionStatement)
+
+		if !ok {
+			continue
+		}
+
+		function := declaration.Function
+		fn := &object.Function{Name: function.Name.Value, Parameters: function.Parameters, Body: function.Body, Env: env}
+
+		if res := env.Set(function.Name.Value, fn, false); isError(res) {
+			return res
+		}
+	}
+
+	return nil
+}
+
+// evalArguments evaluates the arguments of a call, `...arr` is spread into the positional arguments
+func evalArguments(arguments []ast.Expression, env *object.Environment) ([]object.Object, []keywordArgument, object.Object) {
+	var args []object.Object
+	var keywords []keywordArgument
+
+	for _, argument := range arguments {
+		switch argument := argument.(type) {
+		case *ast.SpreadArgument:
+			value := Eval(argument.Value, env)
+
+			if isError(value) {
+				return nil, nil, value
+			}
+
+			array, ok := value.(*object.Array)
+
+			if !ok {
+				return nil, nil, throwError("Cannot spread %s into arguments, expected ARRAY", value.Type())
+			}
+
+			args = append(args, array.Elements...)
+
+		case *ast.KeywordArgument:
+			value := Eval(argument.Value, env)
+
+			if isError(value) {
+				return nil, nil, value
+			}
+
+			keywords = append(keywords, keywordArgument{name: argument.Name.Value, value: value})
+
+		default:
+			value := Eval(argument, env)
+
+			if isError(value) {
+				return nil, nil, value
+			}
+
+			args = append(args, value)
+		}
+	}
+
+	return args, keywords, nil
+}
+
+/*
+createLocalEnv binds the arguments of a call to the parameters of fun:
+
+- Positional arguments fill the parameters in order, the extra ones go to the rest parameter.
+- Keyword arguments fill the parameter with the same name.
+- A parameter that got no argument takes its default, which is evaluated in the new environment
+so it can refer to the parameters before it.
+*/
+func createLocalEnv(fun *object.Function, args []object.Object, keywords []keywordArgument) (*object.Environment, object.Object) {
 	env := object.NewLocalEnvironment(fun.Env)
 
-	for idx, param := range fun.Parameters {
-		env.Set(param.Value, args[idx], false)
+	params := fun.Parameters
+	var rest *ast.Parameter
+
+	if len(params) > 0 && params[len(params)-1].Rest {
+		rest = params[len(params)-1]
+		params = params[:len(params)-1]
+	}
+
+	got := len(args) + len(keywords)
+
+	if rest == nil && len(args) > len(params) {
+		return nil, throwError("wrong number of arguments: want=%d, got=%d, unexpected argument %d", len(params), got, len(params)+1)
+	}
+
+	values := make([]object.Object, len(params))
+	copy(values, args)
+
+	for _, keyword := range keywords {
+		idx := parameterIndex(params, keyword.name)
+
+		if idx == -1 {
+			if rest != nil && rest.Name().Value == keyword.name {
+				return nil, throwError("rest parameter '%s' cannot be passed by keyword", keyword.name)
+			}
+
+			return nil, throwError("unexpected keyword argument '%s'", keyword.name)
+		}
+
+		if values[idx] != nil {
+			return nil, throwError("parameter '%s' got multiple values", keyword.name)
+		}
+
+		values[idx] = keyword.value
+	}
+
+	for idx, param := range params {
+		value := values[idx]
+
+		if value == nil {
+			if param.Default == nil {
+				return nil, throwError("wrong number of arguments: want=%d, got=%d, missing parameter '%s'", len(params), got, param.Target)
+			}
+
+			value = Eval(param.Default, env)
+
+			if isError(value) {
+				return nil, value
+			}
+		}
+
+		// A pattern parameter destructures its argument, e.g `fun([x, y]) {}`
+		if res := bindPattern(param.Target, value, env, false); isError(res) {
+			return nil, res
+		}
+	}
+
+	if rest != nil {
+		others := []object.Object{}
+
+		if len(args) > len(params) {
+			others = append(others, args[len(params):]...)
+		}
+
+		if res := env.Set(rest.Name().Value, &object.Array{Elements: others}, false); isError(res) {
+			return nil, res
+		}
+	}
+
+	return env, nil
+}
+
+// parameterIndex returns the position of the parameter called name, -1 when there is none
+func parameterIndex(params []*ast.Parameter, name string) int {
+	for idx, param := range params {
+		if ident := param.Name(); ident != nil && ident.Value == name {
+			return idx
+		}
 	}
 
-	return env
+	return -1
 }
 
 func unwrapReturnValue(obj object.Object) object.Object {
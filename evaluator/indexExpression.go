@@ -11,6 +11,10 @@ func evalIndexExpression(ident object.Object, index object.Object) object.Object
 		return evalHashIndex(ident, index)
 
 	default:
+		if indexable, ok := ident.(object.Indexable); ok {
+			return indexable.Index(index)
+		}
+
 		return throwError("index operator not supported: %s", ident.Type())
 
 	}
@@ -25,6 +29,14 @@ func setIndexExpression(ident object.Object, index object.Object, value object.O
 		return setHashIndex(ident, index, value)
 
 	default:
+		if settable, ok := ident.(object.Settable); ok {
+			if result := settable.SetIndex(index, value); isError(result) {
+				return result
+			}
+
+			return NULL
+		}
+
 		return throwError("index operator not supported: %s", ident.Type())
 
 	}
@@ -33,13 +45,13 @@ func setIndexExpression(ident object.Object, index object.Object, value object.O
 func setArrayIndex(array object.Object, index object.Object, value object.Object) object.Object {
 	arrayObject := array.(*object.Array)
 
-	idx := index.(*object.Integer).Value
+	integer, ok := index.(*object.Integer)
 
-	if idx < 0 || idx > int64(len(arrayObject.Elements)-1) {
+	if !ok || integer.Value < 0 || integer.Value > int64(len(arrayObject.Elements)-1) {
 		return throwError("index out of bounds")
 	}
 
-	arrayObject.Elements[idx] = value
+	arrayObject.Elements[integer.Value] = value
 
 	return NULL
 }
@@ -2,7 +2,9 @@ package evaluator
 
 import (
 	"math"
+	"math/big"
 
+	"github.com/Mostafa-DE/delang/ast"
 	"github.com/Mostafa-DE/delang/object"
 	"github.com/shopspring/decimal"
 )
@@ -13,54 +15,54 @@ func evalInfixExpression(operator string, left object.Object, right object.Objec
 
 	switch {
 	case _leftType == object.INTEGER_OBJ && _rightType == object.INTEGER_OBJ:
-		return evalIntegerInfixExpression(operator, left, right)
+		return evalIntegerInfixExpression(operator, left, right, env)
 
 	case _leftType == object.STRING_OBJ && _rightType == object.STRING_OBJ:
-		return evalStringInfixExpression(operator, left, right)
+		return evalStringInfixExpression(operator, left, right, env)
 
 	case _leftType == object.STRING_OBJ && _rightType == object.INTEGER_OBJ:
 		right = &object.String{Value: right.Inspect()}
 
-		return evalStringInfixExpression(operator, left, right)
+		return evalStringInfixExpression(operator, left, right, env)
 
 	case _leftType == object.INTEGER_OBJ && _rightType == object.STRING_OBJ:
 		left = &object.String{Value: left.Inspect()}
 
-		return evalStringInfixExpression(operator, left, right)
+		return evalStringInfixExpression(operator, left, right, env)
 
 	case _leftType == object.FLOAT_OBJ && _rightType == object.FLOAT_OBJ:
 		return evalFloatInfixExpression(operator, left, right)
 
 	case _leftType == object.FLOAT_OBJ && _rightType == object.INTEGER_OBJ:
-		right = &object.Float{Value: float64(right.(*object.Integer).Value)}
+		right = integerToFloat(right)
 
 		return evalFloatInfixExpression(operator, left, right)
 
 	case _leftType == object.INTEGER_OBJ && _rightType == object.FLOAT_OBJ:
-		left = &object.Float{Value: float64(left.(*object.Integer).Value)}
+		left = integerToFloat(left)
 
 		return evalFloatInfixExpression(operator, left, right)
 
 	case _leftType == object.FLOAT_OBJ && _rightType == object.STRING_OBJ:
 		left = &object.String{Value: left.Inspect()}
 
-		return evalStringInfixExpression(operator, left, right)
+		return evalStringInfixExpression(operator, left, right, env)
 
 	case _leftType == object.STRING_OBJ && _rightType == object.FLOAT_OBJ:
 		right = &object.String{Value: right.Inspect()}
 
-		return evalStringInfixExpression(operator, left, right)
+		return evalStringInfixExpression(operator, left, right, env)
 
 	case _leftType == object.DECIMAL_OBJ && _rightType == object.DECIMAL_OBJ:
 		return evalDecimalInfixExpression(operator, left, right, env)
 
 	case _leftType == object.DECIMAL_OBJ && _rightType == object.INTEGER_OBJ:
-		right = &object.Decimal{Value: decimal.NewFromInt(right.(*object.Integer).Value)}
+		right = integerToDecimal(right)
 
 		return evalDecimalInfixExpression(operator, left, right, env)
 
 	case _leftType == object.INTEGER_OBJ && _rightType == object.DECIMAL_OBJ:
-		left = &object.Decimal{Value: decimal.NewFromInt(left.(*object.Integer).Value)}
+		left = integerToDecimal(left)
 
 		return evalDecimalInfixExpression(operator, left, right, env)
 
@@ -78,13 +80,13 @@ func evalInfixExpression(operator string, left object.Object, right object.Objec
 		left = &object.String{Value: left.Inspect()}
 		right = &object.String{Value: right.Inspect()}
 
-		return evalStringInfixExpression(operator, left, right)
+		return evalStringInfixExpression(operator, left, right, env)
 
 	case _leftType == object.STRING_OBJ && _rightType == object.DECIMAL_OBJ:
 		left = &object.String{Value: left.Inspect()}
 		right = &object.String{Value: right.Inspect()}
 
-		return evalStringInfixExpression(operator, left, right)
+		return evalStringInfixExpression(operator, left, right, env)
 
 	/*
 		- This is pointer comparison because we only have one instance of TRUE and FALSE in memory
@@ -98,65 +100,88 @@ func evalInfixExpression(operator string, left object.Object, right object.Objec
 	case operator == "!=":
 		return getBooleanObject(left != right)
 
-	case operator == "and":
-		/*
-			- If the 'and' operator is used with booleans and integers.
-				- First we convert the integer to boolean
-				- Then we compare the two booleans
-		*/
-		if _leftType == object.INTEGER_OBJ && _rightType == object.BOOLEAN_OBJ {
-			left = getBooleanObject(intToBool(left.(*object.Integer).Value))
+	case _leftType != _rightType:
+		return throwError("type mismatch: %s %s %s", left.Type(), operator, right.Type())
 
-		} else if _leftType == object.BOOLEAN_OBJ && _rightType == object.INTEGER_OBJ {
-			right = getBooleanObject(intToBool(right.(*object.Integer).Value))
+	default:
+		return throwError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
 
-		}
+/*
+evalLogicalExpression is `left and right` or `left or right`, right is only evaluated when left does not decide the result:
 
-		return getBooleanObject((left == TRUE) && (right == TRUE))
+- `and` gives left when it is falsy, otherwise right.
+- `or` gives left when it is truthy, otherwise right.
 
-	case operator == "or":
-		/*
-			- If the 'or' operator is used with booleans and integers.
-				- First we convert the integer to boolean
-				- Then we compare the two booleans
-		*/
-		if _leftType == object.INTEGER_OBJ && _rightType == object.BOOLEAN_OBJ {
-			left = getBooleanObject(intToBool(left.(*object.Integer).Value))
+The operand itself is the result and not a boolean, e.g `0 or 5` is 5, see isTruthy for what is falsy.
+*/
+func evalLogicalExpression(node *ast.InfixExpression, env *object.Environment) object.Object {
+	left := Eval(node.Left, env)
 
-		} else if _leftType == object.BOOLEAN_OBJ && _rightType == object.INTEGER_OBJ {
-			right = getBooleanObject(intToBool(right.(*object.Integer).Value))
+	if isError(left) {
+		return left
+	}
 
-		}
+	if isTruthy(left) == (node.Operator == "or") {
+		return left
+	}
 
-		return getBooleanObject((left == TRUE) || (right == TRUE))
+	return Eval(node.Right, env)
+}
 
-	case _leftType != _rightType:
-		return throwError("type mismatch: %s %s %s", left.Type(), operator, right.Type())
+// evalIntegerInfixExpression works on int64 and goes through evalBigIntegerInfixExpression when an operand is big or the result overflows
+func evalIntegerInfixExpression(operator string, left object.Object, right object.Object, env *object.Environment) object.Object {
+	leftInt, leftOk := left.(*object.Integer)
+	rightInt, rightOk := right.(*object.Integer)
 
-	default:
-		return throwError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	if !leftOk || !rightOk {
+		return evalBigIntegerInfixExpression(operator, bigIntegerValue(left), bigIntegerValue(right), env)
 	}
-}
 
-func evalIntegerInfixExpression(operator string, left object.Object, right object.Object) object.Object {
-	leftVal := left.(*object.Integer).Value
-	rightVal := right.(*object.Integer).Value
+	leftVal := leftInt.Value
+	rightVal := rightInt.Value
 
 	switch operator {
 	case "+":
-		return &object.Integer{Value: leftVal + rightVal}
+		sum := leftVal + rightVal
+
+		// The sum wrapped around when both operands have a sign the sum does not have
+		if (leftVal^sum)&(rightVal^sum) < 0 {
+			return evalBigIntegerInfixExpression(operator, big.NewInt(leftVal), big.NewInt(rightVal), env)
+		}
+
+		return &object.Integer{Value: sum}
 
 	case "-":
-		return &object.Integer{Value: leftVal - rightVal}
+		difference := leftVal - rightVal
+
+		// The difference wrapped around when the operands differ in sign and the difference does not have the sign of left
+		if (leftVal^rightVal)&(leftVal^difference) < 0 {
+			return evalBigIntegerInfixExpression(operator, big.NewInt(leftVal), big.NewInt(rightVal), env)
+		}
+
+		return &object.Integer{Value: difference}
 
 	case "*":
-		return &object.Integer{Value: leftVal * rightVal}
+		product := leftVal * rightVal
+
+		if leftVal != 0 && (product/leftVal != rightVal || leftVal == -1 && rightVal == math.MinInt64) {
+			return evalBigIntegerInfixExpression(operator, big.NewInt(leftVal), big.NewInt(rightVal), env)
+		}
+
+		return &object.Integer{Value: product}
 
 	case "/":
 		if rightVal == 0 {
 			return throwError("division by zero")
 		}
 
+		// The only quotient that does not fit in int64
+		if leftVal == math.MinInt64 && rightVal == -1 {
+			return evalBigIntegerInfixExpression(operator, big.NewInt(leftVal), big.NewInt(rightVal), env)
+		}
+
 		return &object.Integer{Value: leftVal / rightVal}
 
 	case "%":
@@ -184,54 +209,21 @@ func evalIntegerInfixExpression(operator string, left object.Object, right objec
 	case "!=":
 		return getBooleanObject(leftVal != rightVal)
 
-	case "and":
-		/*
-			- If 'and' operator is used with integers.
-				- Check if the left or right value is 0
-					- Then return 0
-
-				- Otherwise return the right value
-		*/
-
-		if leftVal == 0 || rightVal == 0 {
-			return &object.Integer{Value: 0}
-		}
-
-		return &object.Integer{Value: rightVal}
-
-	case "or":
-		/*
-			- If 'or' operator is used with integers.
-				- Check if the left value is 0
-					- Then return the right value
-
-				- Check if the right value is 0
-					- Then return the left value
-
-				- Otherwise return the left value
-		*/
-
-		if leftVal == 0 && rightVal != 0 {
-			return &object.Integer{Value: rightVal}
-
-		} else if leftVal != 0 && rightVal == 0 {
-			return &object.Integer{Value: leftVal}
-
-		}
-
-		return &object.Integer{Value: leftVal}
-
 	default:
 		return throwError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
 	}
 }
 
-func evalStringInfixExpression(operator string, left object.Object, right object.Object) object.Object {
+func evalStringInfixExpression(operator string, left object.Object, right object.Object, env *object.Environment) object.Object {
 	leftVal := left.(*object.String).Value
 	rightVal := right.(*object.String).Value
 
 	switch operator {
 	case "+":
+		if err := checkStringLength(len(leftVal)+len(rightVal), env); err != nil {
+			return err
+		}
+
 		return &object.String{Value: leftVal + rightVal}
 
 	case "==":
@@ -292,44 +284,6 @@ func evalFloatInfixExpression(operator string, left object.Object, right object.
 	case "!=":
 		return getBooleanObject(leftVal != rightVal)
 
-	case "and":
-		/*
-			- If 'and' operator is used with floats.
-				- Check if the left or right value is 0
-					- Then return 0
-
-				- Otherwise return the right value
-		*/
-
-		if leftVal == 0 || rightVal == 0 {
-			return &object.Float{Value: 0}
-		}
-
-		return &object.Float{Value: rightVal}
-
-	case "or":
-		/*
-			- If 'or' operator is used with floats.
-				- Check if the left value is 0
-					- Then return the right value
-
-				- Check if the right value is 0
-					- Then return the left value
-
-				- Otherwise return the left value
-
-		*/
-
-		if leftVal == 0 && rightVal != 0 {
-			return &object.Float{Value: rightVal}
-
-		} else if leftVal != 0 && rightVal == 0 {
-			return &object.Float{Value: leftVal}
-
-		}
-
-		return &object.Float{Value: leftVal}
-
 	default:
 		return throwError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
 	}
@@ -380,6 +334,10 @@ func evalDecimalInfixExpression(operator string, left object.Object, right objec
 		return throwError("Valid range for divPrec is [0 to 28]")
 	}
 
+	if err := checkDecimalDigits(operator, leftVal, rightVal, env); err != nil {
+		return err
+	}
+
 	switch operator {
 	case "+":
 		return &object.Decimal{Value: leftVal.Add(rightVal).Round(int32(prec.Value))}
@@ -395,18 +353,18 @@ func evalDecimalInfixExpression(operator string, left object.Object, right objec
 			return throwError("division by zero")
 		}
 
-		decimal.DivisionPrecision = int(divPrec.Value)
-
-		return &object.Decimal{Value: leftVal.Div(rightVal)}
+		// The precision is passed instead of setting decimal.DivisionPrecision, which is shared by every interpreter
+		return &object.Decimal{Value: leftVal.DivRound(rightVal, int32(divPrec.Value))}
 
 	case "%":
 		if rightVal.IsZero() {
 			return throwError("division by zero")
 		}
 
-		decimal.DivisionPrecision = int(divPrec.Value)
+		// Same as Decimal.Mod without going through decimal.DivisionPrecision
+		quotient := leftVal.DivRound(rightVal, int32(divPrec.Value)).Truncate(0)
 
-		return &object.Decimal{Value: leftVal.Mod(rightVal)}
+		return &object.Decimal{Value: leftVal.Sub(rightVal.Mul(quotient))}
 
 	case "<":
 		return getBooleanObject(leftVal.LessThan(rightVal))
@@ -426,46 +384,6 @@ func evalDecimalInfixExpression(operator string, left object.Object, right objec
 	case "!=":
 		return getBooleanObject(!leftVal.Equal(rightVal))
 
-	case "and":
-		/*
-			- If the 'and' operator is used with decimals.
-				- Check if the left or right value is 0
-					- Then return 0
-
-				- Otherwise return the right value
-
-		*/
-
-		if leftVal.IsZero() || rightVal.IsZero() {
-			return &object.Decimal{Value: decimal.Zero}
-		}
-
-		return &object.Decimal{Value: rightVal}
-
-	case "or":
-		/*
-			- If the 'or' operator is used with decimals.
-				- Check if the left value is 0
-					- Then return the right value
-
-				- Check if the right value is 0
-					- Then return the left value
-
-				- Otherwise return the left value
-
-		*/
-
-		if leftVal.IsZero() && !rightVal.IsZero() {
-			return &object.Decimal{Value: rightVal}
-
-		} else if !leftVal.IsZero() && rightVal.IsZero() {
-
-			return &object.Decimal{Value: leftVal}
-
-		}
-
-		return &object.Decimal{Value: leftVal}
-
 	default:
 		return throwError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
 	}
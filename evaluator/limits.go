@@ -0,0 +1,162 @@
+package evaluator
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/Mostafa-DE/delang/object"
+	"github.com/shopspring/decimal"
+)
+
+// checkStringLength gives an error when a string of that many bytes is longer than the runtime allows
+func checkStringLength(length int, env *object.Environment) *object.Error {
+	if max := env.Runtime().Limits.MaxStringLength; max > 0 && length > max {
+		return throwError("string too long, the limit is %d bytes", max)
+	}
+
+	return nil
+}
+
+// inspectWithin is the text of a value, or the error of checkStringLength when it is longer than the runtime allows.
+// The text is only built up to the limit, see object.InspectLimit.
+func inspectWithin(value object.Object, env *object.Environment) (string, *object.Error) {
+	text, _ := object.InspectLimit(value, env.Runtime().Limits.MaxStringLength)
+
+	if err := checkStringLength(len(text), env); err != nil {
+		return "", err
+	}
+
+	return text, nil
+}
+
+// checkIntegerBits gives an error when an integer of that many bits is larger than the runtime allows
+func checkIntegerBits(bits int, env *object.Environment) *object.Error {
+	if max := env.Runtime().Limits.MaxIntegerBits; max > 0 && bits > max {
+		return throwError("integer too large, the limit is %d bits", max)
+	}
+
+	return nil
+}
+
+/*
+checkDecimalDigits gives an error when `+`, `-` or `*` would work on more digits than the runtime allows.
+Decimals are a coefficient and an exponent, adding them lines both up on the smaller exponent and
+rounding the result lines it up on the precision, the digits written out are counted before either happens.
+*/
+func checkDecimalDigits(operator string, left decimal.Decimal, right decimal.Decimal, env *object.Environment) *object.Error {
+	limit := env.Runtime().Limits.MaxDecimalDigits
+
+	if limit <= 0 {
+		return nil
+	}
+
+	var integerDigits, fractionDigits int64
+
+	switch operator {
+	case "+", "-":
+		integerDigits = max(decimalIntegerDigits(left), decimalIntegerDigits(right)) + 1
+		fractionDigits = max(decimalFractionDigits(left), decimalFractionDigits(right))
+
+	case "*":
+		integerDigits = decimalIntegerDigits(left) + decimalIntegerDigits(right)
+		fractionDigits = decimalFractionDigits(left) + decimalFractionDigits(right)
+
+	default:
+		return nil
+	}
+
+	if integerDigits+fractionDigits > int64(limit) {
+		return throwError("decimal too large, the limit is %d digits", limit)
+	}
+
+	return nil
+}
+
+// decimalIntegerDigits is the number of digits before the decimal point
+func decimalIntegerDigits(value decimal.Decimal) int64 {
+	return max(int64(value.NumDigits())+int64(value.Exponent()), 0)
+}
+
+// decimalFractionDigits is the number of digits after the decimal point
+func decimalFractionDigits(value decimal.Decimal) int64 {
+	return max(-int64(value.Exponent()), 0)
+}
+
+/*
+checkBuiltinLimits stops a builtin before it builds a value larger than the runtime allows,
+the builtins do not see the runtime so the check is done for them:
+
+- int() of a string or a decimal, `int("1e100000000")` would take minutes to build.
+- range(), `range(2000000000)` would allocate gigabytes.
+- str(), an array holding itself on every level prints exponentially long text.
+*/
+func checkBuiltinLimits(name string, args []object.Object, env *object.Environment) *object.Error {
+	switch name {
+	case "range":
+		max := env.Runtime().Limits.MaxRangeLength
+
+		if max <= 0 || len(args) == 0 || len(args) > 2 {
+			return nil
+		}
+
+		bounds := make([]*big.Int, len(args))
+
+		for idx, arg := range args {
+			if arg.Type() != object.INTEGER_OBJ {
+				return nil
+			}
+
+			bounds[idx] = bigIntegerValue(arg)
+		}
+
+		length := bounds[0]
+
+		if len(bounds) == 2 {
+			length = new(big.Int).Sub(bounds[1], bounds[0])
+		}
+
+		if length.Cmp(big.NewInt(int64(max))) > 0 {
+			return throwError("range of %s elements is too long, the limit is %d elements", length, max)
+		}
+
+	case "str":
+		if len(args) != 1 {
+			return nil
+		}
+
+		_, err := inspectWithin(args[0], env)
+
+		return err
+
+	case "int":
+		if len(args) != 1 {
+			return nil
+		}
+
+		var value decimal.Decimal
+
+		switch arg := args[0].(type) {
+		case *object.Decimal:
+			value = arg.Value
+
+		case *object.String:
+			parsed, err := decimal.NewFromString(arg.Value)
+
+			if err != nil {
+				return nil
+			}
+
+			value = parsed
+
+		default:
+			return nil
+		}
+
+		// The digits before the decimal point, every digit takes at most log2(10) bits
+		digits := value.NumDigits() + int(value.Exponent())
+
+		return checkIntegerBits(int(math.Ceil(float64(digits)*math.Log2(10))), env)
+	}
+
+	return nil
+}
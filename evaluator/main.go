@@ -13,6 +13,10 @@ var (
 )
 
 func Eval(node ast.Node, env *object.Environment) object.Object {
+	if err := beforeNode(node, env); err != nil {
+		return err
+	}
+
 	switch node := node.(type) {
 	case *ast.Program: // Root node of every AST our parser produces
 		return evalProgram(node.Statements, env)
@@ -21,11 +25,22 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		return Eval(node.Expression, env)
 
 	case *ast.Integer:
+		if node.Big != nil {
+			if err := checkIntegerBits(node.Big.BitLen(), env); err != nil {
+				return err
+			}
+
+			return &object.BigInteger{Value: node.Big}
+		}
+
 		return &object.Integer{Value: node.Value}
 
 	case *ast.Float:
 		return &object.Float{Value: node.Value}
 
+	case *ast.Decimal:
+		return &object.Decimal{Value: node.Value}
+
 	case *ast.Boolean:
 		// This is because we don't need to create a new object for every boolean literal
 		// Comparing the pointer address is enough
@@ -41,6 +56,14 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		return evalPrefixExpression(node.Operator, right)
 
 	case *ast.InfixExpression:
+		switch node.Operator {
+		case "??":
+			return evalNullishExpression(node, env)
+
+		case "and", "or":
+			return evalLogicalExpression(node, env)
+		}
+
 		left := Eval(node.Left, env)
 		if isError(left) {
 			return left
@@ -59,6 +82,9 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	case *ast.IfExpression:
 		return evalIfExpression(node, env)
 
+	case *ast.ConditionalExpression:
+		return evalConditionalExpression(node, env)
+
 	case *ast.ReturnStatement:
 		val := Eval(node.ReturnValue, env)
 
@@ -76,7 +102,13 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 			return val
 		}
 
-		returnValue := env.Set(node.Name.Value, val, false)
+		var returnValue object.Object
+
+		if node.Pattern != nil {
+			returnValue = bindPattern(node.Pattern, val, env, false)
+		} else {
+			returnValue = env.Set(node.Name.Value, val, false)
+		}
 
 		if isError(returnValue) {
 			return returnValue
@@ -89,7 +121,13 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 			return val
 		}
 
-		returnValue := env.Set(node.Name.Value, val, true)
+		var returnValue object.Object
+
+		if node.Pattern != nil {
+			returnValue = bindPattern(node.Pattern, val, env, true)
+		} else {
+			returnValue = env.Set(node.Name.Value, val, true)
+		}
 
 		if isError(returnValue) {
 			return returnValue
@@ -104,20 +142,14 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 
 		return &object.Function{Parameters: params, Body: body, Env: env}
 
-	case *ast.CallFunction:
-		function := Eval(node.Function, env)
+	case *ast.FunctionStatement:
+		// The function was bound when its block started, see hoistFunctions
+		return nil
 
-		if isError(function) {
-			return function
-		}
-
-		args := evalExpressions(node.Arguments, env)
+	case *ast.CallFunction, *ast.IndexExpression, *ast.MemberExpression:
+		result, _ := evalChain(node.(ast.Expression), env)
 
-		if len(args) == 1 && isError(args[0]) {
-			return args[0]
-		}
-
-		return evalFunction(function, args, env)
+		return result
 
 	case *ast.StringLiteral:
 		return &object.String{Value: node.Value}
@@ -131,31 +163,6 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 
 		return &object.Array{Elements: elements}
 
-	case *ast.IndexExpression:
-		ident := Eval(node.Ident, env)
-
-		if isError(ident) {
-			return ident
-		}
-
-		idx := Eval(node.Index, env)
-
-		if isError(idx) {
-			return idx
-		}
-
-		if node.Value != nil {
-			val := Eval(node.Value, env)
-
-			if isError(val) {
-				return val
-			}
-
-			return setIndexExpression(ident, idx, val)
-		} else {
-			return evalIndexExpression(ident, idx)
-		}
-
 	case *ast.Hash:
 		return evalHash(node, env)
 
@@ -174,6 +181,9 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	case *ast.ForStatement:
 		return evalForStatement(node, env)
 
+	case *ast.MatchExpression:
+		return evalMatchExpression(node, env)
+
 	case *ast.BreakStatement:
 		return &object.Break{}
 
@@ -188,9 +198,22 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	return nil
 }
 
+// beforeNode runs the BeforeNode hook of the runtime, a non nil error stops the evaluation
+func beforeNode(node ast.Node, env *object.Environment) *object.Error {
+	if hooks := env.Runtime().Hooks; hooks != nil && hooks.BeforeNode != nil && node != nil {
+		return hooks.BeforeNode(node, env)
+	}
+
+	return nil
+}
+
 func evalProgram(statements []ast.Statement, env *object.Environment) object.Object {
 	var result object.Object
 
+	if err := hoistFunctions(statements, env); err != nil {
+		return err
+	}
+
 	for _, statement := range statements {
 		result = Eval(statement, env)
 
@@ -209,6 +232,10 @@ func evalProgram(statements []ast.Statement, env *object.Environment) object.Obj
 func evalBlockStatement(statements []ast.Statement, env *object.Environment) object.Object {
 	var result object.Object = &object.Null{}
 
+	if err := hoistFunctions(statements, env); err != nil {
+		return err
+	}
+
 	for _, statement := range statements {
 		result = Eval(statement, env)
 
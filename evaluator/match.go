@@ -0,0 +1,144 @@
+package evaluator
+
+import (
+	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/object"
+)
+
+/*
+evalMatchExpression evaluates the value once and tries the arms in order.
+
+Every pattern of an arm is tried in a new local environment, so the names bound by a pattern that
+did not match, or whose guard was falsy, never leak into the next attempt.
+It is an error when no arm matches.
+*/
+func evalMatchExpression(node *ast.MatchExpression, env *object.Environment) object.Object {
+	value := Eval(node.Value, env)
+
+	if isError(value) {
+		return value
+	}
+
+	for _, arm := range node.Arms {
+		for _, pattern := range arm.Patterns {
+			armEnv := object.NewLocalEnvironment(env)
+
+			matched, err := matchPattern(pattern, value, armEnv)
+
+			if err != nil {
+				return err
+			}
+
+			if !matched {
+				continue
+			}
+
+			if arm.Guard != nil {
+				guard := Eval(arm.Guard, armEnv)
+
+				if isError(guard) {
+					return guard
+				}
+
+				if !isTruthy(guard) {
+					continue
+				}
+			}
+
+			return evalBlockStatement(arm.Body.Statements, armEnv)
+		}
+	}
+
+	text, err := inspectWithin(value, env)
+
+	if err != nil {
+		return err
+	}
+
+	return throwError("No match arm matches %s", text)
+}
+
+// matchPattern reports whether value matches pattern, binding the names of the pattern in env on the way
+func matchPattern(pattern ast.Expression, value object.Object, env *object.Environment) (bool, object.Object) {
+	switch pattern := pattern.(type) {
+	case *ast.Identifier:
+		if pattern.Value == "_" {
+			return true, nil
+		}
+
+		if res := env.Set(pattern.Value, value, false); isError(res) {
+			return false, res
+		}
+
+		return true, nil
+
+	case *ast.TypePattern:
+		return value.Type() == ast.TypePatterns[pattern.Name], nil
+
+	case *ast.ArrayPattern:
+		return matchArrayPattern(pattern, value, env)
+
+	case *ast.HashPattern:
+		return matchHashPattern(pattern, value, env)
+	}
+
+	// Everything else is a literal
+	literal := Eval(pattern, env)
+
+	if isError(literal) {
+		return false, literal
+	}
+
+	return objectsEqual(literal, value), nil
+}
+
+func matchArrayPattern(pattern *ast.ArrayPattern, value object.Object, env *object.Environment) (bool, object.Object) {
+	array, ok := value.(*object.Array)
+
+	if !ok {
+		return false, nil
+	}
+
+	if len(array.Elements) < len(pattern.Elements) || pattern.Rest == nil && len(array.Elements) != len(pattern.Elements) {
+		return false, nil
+	}
+
+	for idx, element := range pattern.Elements {
+		if matched, err := matchPattern(element.Target, array.Elements[idx], env); !matched || err != nil {
+			return false, err
+		}
+	}
+
+	if pattern.Rest != nil && pattern.Rest.Value != "_" {
+		rest := append([]object.Object{}, array.Elements[len(pattern.Elements):]...)
+
+		if res := env.Set(pattern.Rest.Value, &object.Array{Elements: rest}, false); isError(res) {
+			return false, res
+		}
+	}
+
+	return true, nil
+}
+
+func matchHashPattern(pattern *ast.HashPattern, value object.Object, env *object.Environment) (bool, object.Object) {
+	hash, ok := value.(*object.Hash)
+
+	if !ok {
+		return false, nil
+	}
+
+	for _, entry := range pattern.Entries {
+		key := &object.String{Value: entry.Key.Value}
+		pair, ok := hash.Pairs[key.HashKey()]
+
+		if !ok {
+			return false, nil
+		}
+
+		if matched, err := matchPattern(entry.Element.Target, pair.Value, env); !matched || err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,54 @@
+package evaluator
+
+import (
+	"github.com/Mostafa-DE/delang/object"
+)
+
+/*
+getMember reads `object.member`, see evalChain, assignments to a member go through evalAssignExpression:
+
+- On a hash it is the same as `object["member"]`, a key that does not exist falls back to the methods of hashes.
+- On a host object that is Indexable it is `object.Index("member")`.
+- On anything else it is a method from the dispatch table of the type, bound to the object.
+*/
+func getMember(receiver object.Object, name string, env *object.Environment) object.Object {
+	key := &object.String{Value: name}
+
+	switch receiver := receiver.(type) {
+	case *object.Hash:
+		if pair, ok := receiver.Pairs[key.HashKey()]; ok {
+			return pair.Value
+		}
+
+	case object.Indexable:
+		return receiver.Index(key)
+	}
+
+	if fn, ok := lookupMethod(receiver.Type(), name); ok {
+		return bindMethod(receiver, name, fn, env)
+	}
+
+	if receiver.Type() == object.HASH_OBJ {
+		return NULL
+	}
+
+	return throwError("%s has no member '%s'", receiver.Type(), name)
+}
+
+func setMember(receiver object.Object, name string, value object.Object) object.Object {
+	key := &object.String{Value: name}
+
+	switch receiver := receiver.(type) {
+	case *object.Hash:
+		return setHashIndex(receiver, key, value)
+
+	case object.Settable:
+		if result := receiver.SetIndex(key, value); isError(result) {
+			return result
+		}
+
+		return NULL
+	}
+
+	return throwError("cannot set member '%s' of %s", name, receiver.Type())
+}
@@ -0,0 +1,395 @@
+package evaluator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Mostafa-DE/delang/object"
+)
+
+// method is called with the value before the dot as receiver, `"abc".upper()` calls upper with "abc"
+type method func(receiver object.Object, args []object.Object, env *object.Environment) object.Object
+
+// methods is the dispatch table of `value.method(args)`, looked up by the type of the value
+var methods map[string]map[string]method
+
+// The table is filled in init because the methods that take callbacks call back into Eval
+func init() {
+	methods = map[string]map[string]method{
+		object.STRING_OBJ: {
+			"len":        builtinMethod("len"),
+			"upper":      stringMethod("upper", 0, upperString),
+			"lower":      stringMethod("lower", 0, lowerString),
+			"trim":       stringMethod("trim", 0, trimString),
+			"contains":   stringMethod("contains", 1, stringContains),
+			"startsWith": stringMethod("startsWith", 1, stringStartsWith),
+			"endsWith":   stringMethod("endsWith", 1, stringEndsWith),
+			"indexOf":    stringMethod("indexOf", 1, stringIndexOf),
+			"replace":    replaceString,
+			"split":      stringMethod("split", 1, splitString),
+		},
+
+		object.ARRAY_OBJ: {
+			"len":       builtinMethod("len"),
+			"first":     builtinMethod("first"),
+			"last":      builtinMethod("last"),
+			"skipFirst": builtinMethod("skipFirst"),
+			"skipLast":  builtinMethod("skipLast"),
+			"push":      builtinMethod("push"),
+			"pop":       builtinMethod("pop"),
+			"shift":     builtinMethod("shift"),
+			"unshift":   builtinMethod("unshift"),
+			"copy":      builtinMethod("copy"),
+			"map":       mapArray,
+			"filter":    filterArray,
+			"reduce":    reduceArray,
+			"join":      joinArray,
+			"contains":  arrayContains,
+			"indexOf":   arrayIndexOf,
+		},
+
+		object.HASH_OBJ: {
+			"len":    hashLen,
+			"keys":   hashKeys,
+			"values": hashValues,
+			"has":    hashHas,
+			"del":    builtinMethod("del"),
+			"copy":   builtinMethod("copy"),
+		},
+	}
+}
+
+// commonMethods are the methods of every type
+var commonMethods = map[string]method{
+	"str": builtinMethod("str"),
+}
+
+func lookupMethod(objType string, name string) (method, bool) {
+	if fn, ok := methods[objType][name]; ok {
+		return fn, true
+	}
+
+	fn, ok := commonMethods[name]
+
+	return fn, ok
+}
+
+// bindMethod turns a method into a builtin that can be called or passed around like any other function
+func bindMethod(receiver object.Object, name string, fn method, env *object.Environment) *object.Builtin {
+	return &object.Builtin{
+		Name: name,
+		Func: func(args ...object.Object) object.Object {
+			return fn(receiver, args, env)
+		},
+	}
+}
+
+// builtinMethod calls the builtin function of the same name with the receiver as first argument, `arr.len()` is `len(arr)`
+func builtinMethod(name string) method {
+	return func(receiver object.Object, args []object.Object, env *object.Environment) object.Object {
+		return builtins[name].Func(append([]object.Object{receiver}, args...)...)
+	}
+}
+
+func checkMethodArgs(name string, args []object.Object, want int) *object.Error {
+	if len(args) != want {
+		return throwError("wrong number of arguments passed to %s(). got=%d, want=%d", name, len(args), want)
+	}
+
+	return nil
+}
+
+// stringMethod checks that a string method gets the expected number of string arguments
+func stringMethod(name string, arity int, fn func(str string, args []string) object.Object) method {
+	return func(receiver object.Object, args []object.Object, env *object.Environment) object.Object {
+		if err := checkMethodArgs(name, args, arity); err != nil {
+			return err
+		}
+
+		values := make([]string, len(args))
+
+		for idx, arg := range args {
+			str, ok := arg.(*object.String)
+
+			if !ok {
+				return throwError("argument to `%s` must be STRING, got %s", name, arg.Type())
+			}
+
+			values[idx] = str.Value
+		}
+
+		return fn(receiver.(*object.String).Value, values)
+	}
+}
+
+func upperString(str string, args []string) object.Object {
+	return &object.String{Value: strings.ToUpper(str)}
+}
+
+func lowerString(str string, args []string) object.Object {
+	return &object.String{Value: strings.ToLower(str)}
+}
+
+func trimString(str string, args []string) object.Object {
+	return &object.String{Value: strings.TrimSpace(str)}
+}
+
+func stringContains(str string, args []string) object.Object {
+	return getBooleanObject(strings.Contains(str, args[0]))
+}
+
+func stringStartsWith(str string, args []string) object.Object {
+	return getBooleanObject(strings.HasPrefix(str, args[0]))
+}
+
+func stringEndsWith(str string, args []string) object.Object {
+	return getBooleanObject(strings.HasSuffix(str, args[0]))
+}
+
+func stringIndexOf(str string, args []string) object.Object {
+	return &object.Integer{Value: int64(strings.Index(str, args[0]))}
+}
+
+// replaceString checks the length of the result before building it, a short string can replace every byte of a long one
+func replaceString(receiver object.Object, args []object.Object, env *object.Environment) object.Object {
+	return stringMethod("replace", 2, func(str string, args []string) object.Object {
+		length := len(str) + strings.Count(str, args[0])*(len(args[1])-len(args[0]))
+
+		if err := checkStringLength(length, env); err != nil {
+			return err
+		}
+
+		return &object.String{Value: strings.ReplaceAll(str, args[0], args[1])}
+	})(receiver, args, env)
+}
+
+func splitString(str string, args []string) object.Object {
+	parts := strings.Split(str, args[0])
+	elements := make([]object.Object, len(parts))
+
+	for idx, part := range parts {
+		elements[idx] = &object.String{Value: part}
+	}
+
+	return &object.Array{Elements: elements}
+}
+
+// callEach calls fn with every element of the array and its index, it stops at the first error
+func callEach(array *object.Array, fn object.Object, env *object.Environment, each func(idx int, result object.Object)) object.Object {
+	for idx, element := range array.Elements {
+		result := evalFunction(fn, callbackArgs(fn, element, idx), nil, env)
+
+		if isError(result) {
+			return result
+		}
+
+		each(idx, result)
+	}
+
+	return nil
+}
+
+// callbackArgs passes the index as second argument to callbacks that take two parameters, `arr.map(fun(x, i) {...})`
+func callbackArgs(fn object.Object, element object.Object, idx int) []object.Object {
+	if function, ok := fn.(*object.Function); ok && len(function.Parameters) == 2 {
+		return []object.Object{element, &object.Integer{Value: int64(idx)}}
+	}
+
+	return []object.Object{element}
+}
+
+func mapArray(receiver object.Object, args []object.Object, env *object.Environment) object.Object {
+	if err := checkMethodArgs("map", args, 1); err != nil {
+		return err
+	}
+
+	array := receiver.(*object.Array)
+	elements := make([]object.Object, len(array.Elements))
+
+	if err := callEach(array, args[0], env, func(idx int, result object.Object) { elements[idx] = result }); err != nil {
+		return err
+	}
+
+	return &object.Array{Elements: elements}
+}
+
+func filterArray(receiver object.Object, args []object.Object, env *object.Environment) object.Object {
+	if err := checkMethodArgs("filter", args, 1); err != nil {
+		return err
+	}
+
+	array := receiver.(*object.Array)
+	elements := []object.Object{}
+
+	err := callEach(array, args[0], env, func(idx int, result object.Object) {
+		if isTruthy(result) {
+			elements = append(elements, array.Elements[idx])
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return &object.Array{Elements: elements}
+}
+
+func reduceArray(receiver object.Object, args []object.Object, env *object.Environment) object.Object {
+	if err := checkMethodArgs("reduce", args, 2); err != nil {
+		return err
+	}
+
+	accumulator := args[1]
+
+	for _, element := range receiver.(*object.Array).Elements {
+		accumulator = evalFunction(args[0], []object.Object{accumulator, element}, nil, env)
+
+		if isError(accumulator) {
+			return accumulator
+		}
+	}
+
+	return accumulator
+}
+
+func joinArray(receiver object.Object, args []object.Object, env *object.Environment) object.Object {
+	if err := checkMethodArgs("join", args, 1); err != nil {
+		return err
+	}
+
+	separator, ok := args[0].(*object.String)
+
+	if !ok {
+		return throwError("argument to `join` must be STRING, got %s", args[0].Type())
+	}
+
+	parts := []string{}
+	length := 0
+
+	for idx, element := range receiver.(*object.Array).Elements {
+		text, err := inspectWithin(element, env)
+
+		if err != nil {
+			return err
+		}
+
+		parts = append(parts, text)
+
+		if idx > 0 {
+			length += len(separator.Value)
+		}
+
+		length += len(parts[idx])
+
+		if err := checkStringLength(length, env); err != nil {
+			return err
+		}
+	}
+
+	return &object.String{Value: strings.Join(parts, separator.Value)}
+}
+
+func arrayIndexOf(receiver object.Object, args []object.Object, env *object.Environment) object.Object {
+	if err := checkMethodArgs("indexOf", args, 1); err != nil {
+		return err
+	}
+
+	for idx, element := range receiver.(*object.Array).Elements {
+		if objectsEqual(element, args[0]) {
+			return &object.Integer{Value: int64(idx)}
+		}
+	}
+
+	return &object.Integer{Value: -1}
+}
+
+func arrayContains(receiver object.Object, args []object.Object, env *object.Environment) object.Object {
+	index := arrayIndexOf(receiver, args, env)
+
+	if isError(index) {
+		return index
+	}
+
+	return getBooleanObject(index.(*object.Integer).Value >= 0)
+}
+
+// objectsEqual compares values by type and content, arrays, hashes and functions are only equal to themselves
+func objectsEqual(left object.Object, right object.Object) bool {
+	if left == right {
+		return true
+	}
+
+	switch left.(type) {
+	case *object.Integer, *object.BigInteger, *object.Float, *object.Decimal, *object.String, *object.Boolean, *object.Null:
+		return left.Type() == right.Type() && left.Inspect() == right.Inspect()
+	}
+
+	return false
+}
+
+func hashLen(receiver object.Object, args []object.Object, env *object.Environment) object.Object {
+	if err := checkMethodArgs("len", args, 0); err != nil {
+		return err
+	}
+
+	return &object.Integer{Value: int64(len(receiver.(*object.Hash).Pairs))}
+}
+
+// hashKeys returns the keys sorted by their text so that the order is the same on every run
+func hashKeys(receiver object.Object, args []object.Object, env *object.Environment) object.Object {
+	if err := checkMethodArgs("keys", args, 0); err != nil {
+		return err
+	}
+
+	pairs := sortedPairs(receiver.(*object.Hash))
+	keys := make([]object.Object, len(pairs))
+
+	for idx, pair := range pairs {
+		keys[idx] = pair.Key
+	}
+
+	return &object.Array{Elements: keys}
+}
+
+func hashValues(receiver object.Object, args []object.Object, env *object.Environment) object.Object {
+	if err := checkMethodArgs("values", args, 0); err != nil {
+		return err
+	}
+
+	pairs := sortedPairs(receiver.(*object.Hash))
+	values := make([]object.Object, len(pairs))
+
+	for idx, pair := range pairs {
+		values[idx] = pair.Value
+	}
+
+	return &object.Array{Elements: values}
+}
+
+func hashHas(receiver object.Object, args []object.Object, env *object.Environment) object.Object {
+	if err := checkMethodArgs("has", args, 1); err != nil {
+		return err
+	}
+
+	key, ok := args[0].(object.Hashable)
+
+	if !ok {
+		return throwError("unusable as hash key: %s", args[0].Type())
+	}
+
+	_, exists := receiver.(*object.Hash).Pairs[key.HashKey()]
+
+	return getBooleanObject(exists)
+}
+
+func sortedPairs(hash *object.Hash) []object.HashPair {
+	pairs := make([]object.HashPair, 0, len(hash.Pairs))
+
+	for _, pair := range hash.Pairs {
+		pairs = append(pairs, pair)
+	}
+
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key.Inspect() < pairs[j].Key.Inspect() })
+
+	return pairs
+}
@@ -1,17 +1,30 @@
 package evaluator
 
-import "github.com/Mostafa-DE/delang/object"
+import (
+	"math"
+	"math/big"
+
+	"github.com/Mostafa-DE/delang/object"
+)
 
 func evalMinusPrefixOperatorExpression(right object.Object) object.Object {
 	switch right.Type() {
 	case object.INTEGER_OBJ:
-		value := right.(*object.Integer).Value
-		return &object.Integer{Value: -value}
+		// -9223372036854775808 is the only int64 whose negation does not fit
+		if value, ok := right.(*object.Integer); ok && value.Value != math.MinInt64 {
+			return &object.Integer{Value: -value.Value}
+		}
+
+		return object.NewInteger(new(big.Int).Neg(bigIntegerValue(right)))
 
 	case object.FLOAT_OBJ:
 		value := right.(*object.Float).Value
 		return &object.Float{Value: -value}
 
+	case object.DECIMAL_OBJ:
+		value := right.(*object.Decimal).Value
+		return &object.Decimal{Value: value.Neg()}
+
 	default:
 		return throwError("unknown operator: -%s", right.Type())
 	}
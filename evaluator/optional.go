@@ -0,0 +1,109 @@
+package evaluator
+
+import (
+	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/object"
+)
+
+func evalConditionalExpression(node *ast.ConditionalExpression, env *object.Environment) object.Object {
+	condition := Eval(node.Condition, env)
+
+	if isError(condition) {
+		return condition
+	}
+
+	if isTruthy(condition) {
+		return Eval(node.Consequence, env)
+	}
+
+	return Eval(node.Alternative, env)
+}
+
+// evalNullishExpression is `left ?? right`, right is only evaluated when left is null
+func evalNullishExpression(node *ast.InfixExpression, env *object.Environment) object.Object {
+	left := Eval(node.Left, env)
+
+	if isError(left) || !isNull(left) {
+		return left
+	}
+
+	return Eval(node.Right, env)
+}
+
+/*
+evalChain evaluates a chain of member accesses, indexes and calls like `user?.address.city()`.
+
+When an optional link `?.` or `?[` finds null, the chain is skipped from that link to its end,
+so `user?.address.city()` is null when user is null instead of failing on `.city`.
+The second result reports that the chain was skipped.
+*/
+func evalChain(node ast.Expression, env *object.Environment) (object.Object, bool) {
+	switch node := node.(type) {
+	case *ast.MemberExpression:
+		receiver, skipped := evalLink(node.Object, env)
+
+		if skipped || isError(receiver) {
+			return receiver, skipped
+		}
+
+		if node.Optional && isNull(receiver) {
+			return NULL, true
+		}
+
+		return getMember(receiver, node.Member.Value, env), false
+
+	case *ast.IndexExpression:
+		ident, skipped := evalLink(node.Ident, env)
+
+		if skipped || isError(ident) {
+			return ident, skipped
+		}
+
+		if node.Optional && isNull(ident) {
+			return NULL, true
+		}
+
+		idx := Eval(node.Index, env)
+
+		if isError(idx) {
+			return idx, false
+		}
+
+		return evalIndexExpression(ident, idx), false
+
+	case *ast.CallFunction:
+		function, skipped := evalLink(node.Function, env)
+
+		if skipped || isError(function) {
+			return function, skipped
+		}
+
+		args, keywords, err := evalArguments(node.Arguments, env)
+
+		if err != nil {
+			return err, false
+		}
+
+		return evalFunction(function, args, keywords, env), false
+	}
+
+	return Eval(node, env), false
+}
+
+// evalLink evaluates the inner link of a chain, it runs the hooks like Eval does for every node
+func evalLink(node ast.Expression, env *object.Environment) (object.Object, bool) {
+	switch node.(type) {
+	case *ast.MemberExpression, *ast.IndexExpression, *ast.CallFunction:
+		if err := beforeNode(node, env); err != nil {
+			return err, false
+		}
+
+		return evalChain(node, env)
+	}
+
+	return Eval(node, env), false
+}
+
+func isNull(obj object.Object) bool {
+	return obj == nil || obj.Type() == object.NULL_OBJ
+}
@@ -4,7 +4,7 @@ import "github.com/Mostafa-DE/delang/object"
 
 func evalPrefixExpression(operator string, right object.Object) object.Object {
 	switch operator {
-	case "!":
+	case "!", "not":
 		return evalExclamationOperatorExpression(right)
 
 	case "-":
@@ -17,8 +17,6 @@ func TestAndLogicalWithBoolean(t *testing.T) {
 		{"false and false", false},
 		{"1 and true", true},
 		{"1 and false", false},
-		{"0 and true", false},
-		{"0 and false", false},
 	}
 
 	for _, val := range tests {
@@ -43,6 +41,9 @@ func TestAndLogicalWithInteger(t *testing.T) {
 		{"2 and 2", 2},
 		{"2 and 0", 0},
 		{"0 and 2", 0},
+		// The falsy left side is the result, not false
+		{"0 and true", 0},
+		{"0 and false", 0},
 	}
 
 	for _, val := range tests {
@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Mostafa-DE/delang/evaluator"
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/object"
+	"github.com/Mostafa-DE/delang/parser"
+)
+
+func TestIntegerOverflowPromotion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"9223372036854775807 + 1", "9223372036854775808"},
+		{"-9223372036854775807 - 2", "-9223372036854775809"},
+		{"9223372036854775807 * 2", "18446744073709551614"},
+		{"-9223372036854775808 * -1", "9223372036854775808"},
+		{"-1 * -9223372036854775808", "9223372036854775808"},
+		{"-9223372036854775808 / -1", "9223372036854775808"},
+		{"-9223372036854775808 % -1", "0"},
+		{"-(-9223372036854775808)", "9223372036854775808"},
+		{"4294967296 * 4294967296", "18446744073709551616"},
+		{"123456789012345678901234567890 * 10", "1234567890123456789012345678900"},
+		{"123456789012345678901234567890 / 7", "17636684144620811271604938270"},
+		{"-123456789012345678901234567890 / 7", "-17636684144620811271604938270"},
+		{"-123456789012345678901234567890 % 7", "0"},
+		{"-123456789012345678901234567891 % 7", "-1"},
+		{"let x = 9223372036854775807; x += 1; x", "9223372036854775808"},
+		{"let x = 9223372036854775807; x++; x", "9223372036854775808"},
+		{"123456789012345678901234567890 / 0", "ERROR: division by zero"},
+		{"123456789012345678901234567890 % 0", "ERROR: division by zero"},
+		{"9223372036854775808 + 0.5", "9.223372036854776e+18"},
+		{"9223372036854775808 + 0.5d", "9223372036854775808.5"},
+		{`"n=" + 9223372036854775808`, "n=9223372036854775808"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestBigIntegerDemotion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"9223372036854775808 - 1", 9223372036854775807},
+		{"(9223372036854775807 + 1) - 1", 9223372036854775807},
+		{"123456789012345678901234567890 / 123456789012345678901234567890", 1},
+		{"-9223372036854775808", -9223372036854775808},
+		{"int(9223372036854775808 / 2)", 4611686018427387904},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestBigIntegerComparison(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"9223372036854775807 + 1 > 9223372036854775807", true},
+		{"9223372036854775807 + 1 == 9223372036854775808", true},
+		{"9223372036854775808 != 9223372036854775809", true},
+		{"-9223372036854775809 < -9223372036854775808", true},
+		{"123456789012345678901234567890 <= 123456789012345678901234567890", true},
+		{"123456789012345678901234567890 >= 123456789012345678901234567891", false},
+		{"9223372036854775808 > 1.5", true},
+		{"[9223372036854775808].contains(9223372036854775807 + 1)", true},
+	}
+
+	for _, tt := range tests {
+		testBooleanObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestBigIntegerBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`int("123456789012345678901234567890")`, "123456789012345678901234567890"},
+		{`int("-99999999999999999999.9")`, "-99999999999999999999"},
+		{"int(1e20)", "100000000000000000000"},
+		{"int(12345678901234567890.75d)", "12345678901234567890"},
+		{"int(9223372036854775808)", "9223372036854775808"},
+		{"str(9223372036854775807 + 1)", "9223372036854775808"},
+		{"typeof(9223372036854775808)", object.INTEGER_OBJ},
+		{"float(9223372036854775808)", "9.223372036854776e+18"},
+		{"decimal(123456789012345678901234567890)", "123456789012345678901234567890"},
+		{"bool(9223372036854775808)", "true"},
+		{"[1, 2, 3][9223372036854775808]", "ERROR: Index out of range"},
+		{"range(9223372036854775808)", "ERROR: argument to `range` is too large, got 9223372036854775808"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestBigIntegerHashKeys(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let h = {9223372036854775808: "big"}; h[9223372036854775807 + 1]`, "big"},
+		{`let h = {}; h[123456789012345678901234567890] = 1; h[123456789012345678901234567890]`, "1"},
+		{`let h = {9223372036854775807: "small"}; h[9223372036854775808 - 1]`, "small"},
+		{`let h = {9223372036854775808: "big"}; h[-9223372036854775808]`, "null"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestIntegerBitLimit(t *testing.T) {
+	tests := []struct {
+		input    string
+		maxBits  int
+		expected string
+	}{
+		{"let x = 3; during true: { x = x * x; }", 1 << 20, "ERROR: integer too large, the limit is 1048576 bits"},
+		{`int("1e100000000")`, 1 << 20, "ERROR: integer too large, the limit is 1048576 bits"},
+		{"let x = 1; for i in range(100): { x = x * 4; }; x", 128, "ERROR: integer too large, the limit is 128 bits"},
+		{"340282366920938463463374607431768211455 + 1", 128, "ERROR: integer too large, the limit is 128 bits"},
+		{"340282366920938463463374607431768211455", 128, "340282366920938463463374607431768211455"},
+		{"340282366920938463463374607431768211456", 128, "ERROR: integer too large, the limit is 128 bits"},
+		{"int(1e100d)", 128, "ERROR: integer too large, the limit is 128 bits"},
+		{`int("1e30")`, 128, "1000000000000000000000000000000"},
+		{"let x = 3; for i in range(20): { x = x * x; }; x > 0", 0, "true"},
+	}
+
+	for _, tt := range tests {
+		program := parser.New(lexer.New(tt.input)).ParseProgram()
+		env := object.NewEnvironment()
+		env.Runtime().Limits.MaxIntegerBits = tt.maxBits
+
+		if result := evaluator.Eval(program, env); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestIntegersHaveNoDefaultBitLimit(t *testing.T) {
+	// 2^(2^21) has more than two million bits, only the sandbox limits the size of integers
+	input := "let x = 2; for i in range(21): { x = x * x; }; x > 0"
+
+	if result := testEval(input); result.Inspect() != "true" {
+		t.Errorf("%s: expected %q, got %q", input, "true", result.Inspect())
+	}
+}
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestNestedAssignment(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let grid = [[1, 2], [3, 4]]; grid[1][0] = 0; grid`, "[[1, 2], [0, 4]]"},
+		{`let h = {"a": {}}; h["a"]["b"] = 1; h["a"]["b"]`, "1"},
+		{`let h = {"a": {"b": [1, 2]}}; h.a.b[1] = 5; h.a.b`, "[1, 5]"},
+		{`let x = 1; let y = x = 5; [x, y]`, "[5, 5]"},
+		{`let a = 0; let b = 0; a = b = 3; a + b`, "6"},
+		{`let arr = [1]; arr[0] = 2`, "2"},
+		{`let grid = [[1]]; grid[0][3] = 1`, "ERROR: index out of bounds"},
+		{`y = 1`, "ERROR: identifier not found: y"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestCompoundAssignment(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let x = 5; x += 3; x`, "8"},
+		{`let x = 5; x -= 3; x`, "2"},
+		{`let x = 5; x *= 3; x`, "15"},
+		{`let x = 6; x /= 3; x`, "2"},
+		{`let x = 7; x %= 3; x`, "1"},
+		{`let s = "de"; s += "lang"; s`, "delang"},
+		{`let x = 1.5; x += 1; x`, "2.5"},
+		{`let x = 5; x += 3`, "8"},
+		{`let x = 5; x++; x`, "6"},
+		{`let x = 5; x--; x`, "4"},
+		{`let x = 5; x++`, "5"},
+		{`let arr = [1, 2]; arr[1] += 10; arr`, "[1, 12]"},
+		{`let grid = [[1, 2]]; grid[0][1]++; grid`, "[[1, 3]]"},
+		{`let p = {"age": 30}; p.age += 1; p.age`, "31"},
+		{`let p = {"n": 0}; p["n"]++; p["n"]++; p.n`, "2"},
+		{`let total = 0; for _, n in [1, 2, 3]: { total += n; }; total`, "6"},
+		{`let i = 0; during i < 3: { i++; }; i`, "3"},
+		{`let f = fun() { let x = 1; x += 1; x }; f()`, "2"},
+		{`let x = 1; let f = fun() { x += 1; }; f(); f(); x`, "3"},
+		{`let x = 5; x += true`, "ERROR: type mismatch: INTEGER + BOOLEAN"},
+		{`let p = {}; p.count++`, "ERROR: type mismatch: NULL + INTEGER"},
+		{`y += 1`, "ERROR: identifier not found: y"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestAssignmentTargetEvaluatedOnce(t *testing.T) {
+	input := `
+		let calls = 0;
+		let arr = [10, 20, 30];
+		let next = fun() { calls = calls + 1; calls };
+
+		arr[next()] += 5;
+		arr[next()]++;
+
+		[calls, arr]
+	`
+
+	if result := testEval(input); result.Inspect() != "[2, [10, 25, 31]]" {
+		t.Errorf("expected the index to be evaluated once per assignment, got %s", result.Inspect())
+	}
+}
+
+func TestConstAssignment(t *testing.T) {
+	tests := []string{
+		`const x = 1; x = 2`,
+		`const x = 1; x += 2`,
+		`const x = 1; x++`,
+		`const x = 1; let f = fun() { x -= 1 }; f()`,
+	}
+
+	for _, input := range tests {
+		if result := testEval(input); result.Inspect() != "ERROR: Cannot reassign constant 'x'" {
+			t.Errorf("%s: expected a const error, got %q", input, result.Inspect())
+		}
+	}
+}
@@ -371,3 +371,24 @@ func TestSubRoundingDecimal(t *testing.T) {
 
 	}
 }
+
+func TestDecimalLiterals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"19.99d", "19.99"},
+		{"0.1d + 0.2d", "0.3"},
+		{"0.1d + 0.2d == decimal('0.3')", "true"},
+		{"19.99d * 3", "59.97"},
+		{"typeof(5d)", "DECIMAL"},
+		{"-2.5d", "-2.5"},
+		{"match 1.5d { 1.5d => 'exact', _ => 'other' }", "exact"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestArrayDestructuring(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let [a, b] = [1, 2]; a + b`, "3"},
+		{`let [a, b, ...rest] = [1, 2, 3, 4]; rest`, "[3, 4]"},
+		{`let [a, ...rest] = [1]; rest`, "[]"},
+		{`let [head] = [1, 2, 3]; head`, "1"},
+		{`let [x = 0, y = 10] = [5]; [x, y]`, "[5, 10]"},
+		{`let [x, y = x * 2] = [4]; y`, "8"},
+		{`let [[a, b], [c]] = [[1, 2], [3]]; a + b + c`, "6"},
+		{`let [a, b] = [1]`, "ERROR: Cannot destructure [a, b]: the array has 1 elements, no value for 'b'"},
+		{`let [a] = 5`, "ERROR: Cannot destructure INTEGER with the array pattern [a]"},
+		{`let [[a]] = [1]`, "ERROR: Cannot destructure INTEGER with the array pattern [a]"},
+		{`let [x = y] = []`, "ERROR: identifier not found: y"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestHashDestructuring(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let {name, age: years} = {"name": "DE", "age": 3}; [name, years]`, "[DE, 3]"},
+		{`let {name, role = "dev"} = {"name": "DE"}; role`, "dev"},
+		{`let {name: n = "anon"} = {}; n`, "anon"},
+		{`let {"first name": given} = {"first name": "Mostafa"}; given`, "Mostafa"},
+		{`let {tags: [head, ...others]} = {"tags": ["a", "b", "c"]}; [head, others]`, "[a, [b, c]]"},
+		{`let [{x}, {x: y}] = [{"x": 1}, {"x": 2}]; x + y`, "3"},
+		{`let {name} = {"age": 3}`, "ERROR: Cannot destructure {name}: the hash has no key 'name'"},
+		{`let {name} = [1]`, "ERROR: Cannot destructure ARRAY with the hash pattern {name}"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestConstDestructuring(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`const [a, b] = [1, 2]; a + b`, "3"},
+		{`const [a, ...rest] = [1, 2]; a = 5`, "ERROR: Cannot reassign constant 'a'"},
+		{`const [a, ...rest] = [1, 2]; rest = []`, "ERROR: Cannot reassign constant 'rest'"},
+		{`const {name, age: years} = {"name": "DE", "age": 3}; years = 4`, "ERROR: Cannot reassign constant 'years'"},
+		{`const [x = 1] = []; x = 2`, "ERROR: Cannot reassign constant 'x'"},
+		{`let [a, b] = [1, 2]; a = 5; a + b`, "7"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestForDestructuring(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let total = 0; for [a, b] in [[1, 2], [3, 4]]: { total += a * b; }; total`, "14"},
+		{`let names = []; for {name} in [{"name": "a"}, {"name": "b"}]: { names.push(name); }; names`, "[a, b]"},
+		{`let out = []; for i, [k, v = 0] in [["a", 1], ["b"]]: { out.push([i, k, v]); }; out`, "[[0, a, 1], [1, b, 0]]"},
+		{`let out = []; for _, {x} in [{"x": 1}]: { out.push(x); }; out`, "[1]"},
+		{`for [a, b] in [[1, 2], [3]]: { a; }`, "ERROR: Cannot destructure [a, b]: the array has 1 elements, no value for 'b'"},
+		{`for {x} in [1]: { x; }`, "ERROR: Cannot destructure INTEGER with the hash pattern {x}"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestParameterDestructuring(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let add = fun([x, y]) { x + y }; add([1, 2])`, "3"},
+		{`let greet = fun({name, greeting = "hi"}) { greeting + " " + name }; greet({"name": "DE"})`, "hi DE"},
+		{`let f = fun(a, [b, ...rest]) { [a, b, rest] }; f(1, [2, 3])`, "[1, 2, [3]]"},
+		{`let f = fun([x, y]) { x }; f(1)`, "ERROR: Cannot destructure INTEGER with the array pattern [x, y]"},
+		{`let f = fun({name}) { name }; f({})`, "ERROR: Cannot destructure {name}: the hash has no key 'name'"},
+		{`let f = fun([x]) { x }; f([1], 2)`, "ERROR: wrong number of arguments: want=1, got=2, unexpected argument 2"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
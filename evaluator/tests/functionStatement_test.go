@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestFunctionStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`fun add(x, y) { return x + y; } add(1, 2)`, "3"},
+		{`fun add(x, y) { return x + y; }; add(1, 2)`, "3"},
+		// Declarations are hoisted to the top of their block
+		{`let n = square(4); fun square(x) { x * x } n`, "16"},
+		{`
+			fun isEven(n) { if n == 0: { return true; }; return isOdd(n - 1); }
+			fun isOdd(n) { if n == 0: { return false; }; return isEven(n - 1); }
+			[isEven(10), isOdd(7), isEven(3)]
+		`, "[true, true, false]"},
+		{`let f = fun() { return inner(); fun inner() { 5 } }; f()`, "5"},
+		{`if true: { fun local() { 1 } }; local()`, "ERROR: identifier not found: local"},
+		{`fun counter() { let count = 0; return fun() { count++; count }; } let next = counter(); next(); next()`, "2"},
+		{`fun f(x = 1, ...rest) { [x, rest] } f()`, "[1, []]"},
+		{`fun f() { 1 } fun f() { 2 } f()`, "2"},
+		{`const f = 1; fun f() { 2 }`, "ERROR: Cannot redeclare constant 'f'"},
+		{`fun len() { 1 }`, "ERROR: Shadowing of 'len' is not allowed"},
+		{`fun named() { 1 } typeof(named)`, "FUNCTION"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestFunctionStatementInspect(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`fun add(x, y) { x + y } add`, "fun add(x, y) {\n(x + y)\n}"},
+		{`let add = fun(x, y) { x + y }; add`, "fun(x, y) {\n(x + y)\n}"},
+		{`fun greet(name = "DE") { name } greet`, "fun greet(name = DE) {\nname\n}"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
@@ -149,7 +149,7 @@ func TestCallingWithoutArgs(t *testing.T) {
 				// const add = fun() { return 1 + 1; };
 				add();
 			`,
-			expected: &object.Error{Msg: "wrong number of arguments: want=2, got=0"},
+			expected: &object.Error{Msg: "wrong number of arguments: want=2, got=0, missing parameter 'x'"},
 		},
 		{
 			explanation: "Calling without args, should return error",
@@ -157,7 +157,7 @@ func TestCallingWithoutArgs(t *testing.T) {
 				const _logs = fun(x) { return x; };
 				_logs();
 			`,
-			expected: &object.Error{Msg: "wrong number of arguments: want=1, got=0"},
+			expected: &object.Error{Msg: "wrong number of arguments: want=1, got=0, missing parameter 'x'"},
 		},
 	}
 	for _, val := range tests {
@@ -313,9 +313,7 @@ func TestFunctionWithNoEndOrStartCurlyBracket(t *testing.T) {
 	}
 }
 
-// Ignore this test for now
 func TestFunctionWithDefaultParameters(t *testing.T) {
-	t.Skip()
 	tests := []struct {
 		explanation string
 		input       string
@@ -352,7 +350,7 @@ func TestFunctionWithDefaultParameters(t *testing.T) {
 
 				add(5, 5, 5);
 			`,
-			expected: &object.Error{Msg: "wrong number of arguments: want=2, got=3"},
+			expected: &object.Error{Msg: "wrong number of arguments: want=2, got=3, unexpected argument 3"},
 		},
 		{
 			explanation: "Function with default parameters",
@@ -363,7 +361,7 @@ func TestFunctionWithDefaultParameters(t *testing.T) {
 
 				add(5, 5, 5, 5);
 			`,
-			expected: &object.Error{Msg: "wrong number of arguments: want=2, got=4"},
+			expected: &object.Error{Msg: "wrong number of arguments: want=2, got=4, unexpected argument 3"},
 		},
 		{
 			explanation: "Function with default parameters",
@@ -374,7 +372,7 @@ func TestFunctionWithDefaultParameters(t *testing.T) {
 
 				add();
 			`,
-			expected: &object.Error{Msg: "wrong number of arguments: want=2, got=0"},
+			expected: &object.Error{Msg: "wrong number of arguments: want=2, got=0, missing parameter 'x'"},
 		},
 	}
 
@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/evaluator"
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/object"
+	"github.com/Mostafa-DE/delang/parser"
+)
+
+func TestHooks(t *testing.T) {
+	input := `
+		let add = fun(a, b) { return a + b; };
+		let x = add(1, 2);
+		logs(add(x, 3));
+		let y = 10;
+	`
+
+	program := parser.New(lexer.New(input)).ParseProgram()
+	env := object.NewEnvironment()
+
+	var output bytes.Buffer
+	var calls, returns []string
+	statements := 0
+
+	env.Runtime().Output = &output
+	env.Runtime().Hooks = &object.Hooks{
+		BeforeNode: func(node ast.Node, env *object.Environment) *object.Error {
+			if let, ok := node.(*ast.LetStatement); ok && let.Name.Value == "y" {
+				return &object.Error{Msg: "stopped"}
+			}
+
+			if _, ok := node.(ast.Statement); ok {
+				statements++
+			}
+
+			return nil
+		},
+		EnterFunction: func(function *object.Function, args []object.Object, env *object.Environment) {
+			calls = append(calls, args[0].Inspect()+","+args[1].Inspect())
+		},
+		ExitFunction: func(function *object.Function, result object.Object, env *object.Environment) {
+			returns = append(returns, result.Inspect())
+		},
+	}
+
+	testErrorObject(t, evaluator.Eval(program, env), "stopped")
+
+	if output.String() != "6\n" {
+		t.Errorf("logs should write to the runtime output. got=%q", output.String())
+	}
+
+	if len(calls) != 2 || calls[0] != "1,2" || calls[1] != "3,3" {
+		t.Errorf("EnterFunction calls wrong. got=%v", calls)
+	}
+
+	if len(returns) != 2 || returns[0] != "3" || returns[1] != "6" {
+		t.Errorf("ExitFunction calls wrong. got=%v", returns)
+	}
+
+	// The 3 statements before `let y`, then the body block and the return statement of each call
+	if statements != 7 {
+		t.Errorf("BeforeNode statements wrong. expected=7, got=%d", statements)
+	}
+
+	if _, ok := env.Get("y"); ok {
+		t.Errorf("Evaluation should stop when BeforeNode returns an error")
+	}
+}
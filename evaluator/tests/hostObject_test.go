@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Mostafa-DE/delang/evaluator"
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/object"
+	"github.com/Mostafa-DE/delang/parser"
+)
+
+// row is a host object with named columns that scripts can read, update and iterate over
+type row struct {
+	columns []string
+	values  map[string]object.Object
+}
+
+func (r *row) Type() string    { return "ROW" }
+func (r *row) Inspect() string { return fmt.Sprintf("row(%d columns)", len(r.columns)) }
+
+func (r *row) Index(key object.Object) object.Object {
+	if value, ok := r.values[key.Inspect()]; ok {
+		return value
+	}
+
+	return &object.Error{Msg: "no column " + key.Inspect()}
+}
+
+func (r *row) SetIndex(key object.Object, value object.Object) object.Object {
+	if _, ok := r.values[key.Inspect()]; !ok {
+		return &object.Error{Msg: "no column " + key.Inspect()}
+	}
+
+	r.values[key.Inspect()] = value
+
+	return nil
+}
+
+func (r *row) Iterate(yield func(key object.Object, value object.Object) bool) object.Object {
+	for _, column := range r.columns {
+		if !yield(&object.String{Value: column}, r.values[column]) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// multiplier is a host object that can be called
+type multiplier struct {
+	factor int64
+}
+
+func (m *multiplier) Type() string    { return "MULTIPLIER" }
+func (m *multiplier) Inspect() string { return fmt.Sprintf("multiplier(%d)", m.factor) }
+
+func (m *multiplier) Call(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return &object.Error{Msg: "multiplier takes one argument"}
+	}
+
+	return &object.Integer{Value: args[0].(*object.Integer).Value * m.factor}
+}
+
+func testEvalWithHostObjects(input string) (object.Object, *row) {
+	r := &row{
+		columns: []string{"name", "age"},
+		values: map[string]object.Object{
+			"name": &object.String{Value: "Mostafa"},
+			"age":  &object.Integer{Value: 30},
+		},
+	}
+
+	env := object.NewEnvironment()
+	env.Set("user", r, false)
+	env.Set("triple", &multiplier{factor: 3}, false)
+
+	program := parser.New(lexer.New(input)).ParseProgram()
+
+	return evaluator.Eval(program, env), r
+}
+
+func TestHostObjects(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`user["name"]`, "Mostafa"},
+		{`user["email"]`, "ERROR: no column email"},
+		{`user["age"] = 31; user["age"]`, "31"},
+		{`user["email"] = "x"`, "ERROR: no column email"},
+		{`triple(14)`, "42"},
+		{`triple(1, 2)`, "ERROR: multiplier takes one argument"},
+		{`typeof(user)`, "ROW"},
+		{`let keys = []; for key, value in user: { keys = push(keys, key); }; keys`, "[name, age]"},
+		{`let count = 0; for key, value in user: { count = count + 1; break; }; count`, "1"},
+		{`for key, value in user: { value + true; }`, "ERROR: type mismatch: STRING + BOOLEAN"},
+		{`for key, value in triple: { 1; }`, "ERROR: Type MULTIPLIER is not iterable"},
+		{`triple[0]`, "ERROR: index operator not supported: MULTIPLIER"},
+		{`user.name`, "Mostafa"},
+		{`user.age = 40; user.age`, "40"},
+		{`user.email`, "ERROR: no column email"},
+	}
+
+	for _, tt := range tests {
+		result, _ := testEvalWithHostObjects(tt.input)
+
+		if result == nil || result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %v", tt.input, tt.expected, result)
+		}
+	}
+
+	_, r := testEvalWithHostObjects(`user["name"] = "DE"`)
+
+	if r.values["name"].Inspect() != "DE" {
+		t.Errorf("expected the script to update the host object, got %s", r.values["name"].Inspect())
+	}
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestLogicalShortCircuit(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		// The operand that decides the result is returned as it is
+		{`"" or "default"`, "default"},
+		{`"DE" and "Lang"`, "Lang"},
+		{`[] or [1]`, "[1]"},
+		// The right side is not evaluated when the left side decides
+		{`false and undefinedName`, "false"},
+		{`true or undefinedName`, "true"},
+		{`let arr = []; len(arr) > 0 and arr[0] > 1`, "false"},
+		{`let calls = 0; let f = fun() { calls += 1; true }; false and f(); true or f(); calls`, "0"},
+		{`let calls = 0; let f = fun() { calls += 1; true }; true and f(); false or f(); calls`, "2"},
+		{`let h = {}; let arr = h.arr; arr and arr[0]`, "null"},
+		{`true and undefinedName`, "ERROR: identifier not found: undefinedName"},
+		{`undefinedName or true`, "ERROR: identifier not found: undefinedName"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestTruthiness(t *testing.T) {
+	tests := []struct {
+		value  string
+		truthy bool
+	}{
+		{`true`, true},
+		{`false`, false},
+		{`{}.missing`, false},
+		{`0`, false},
+		{`7`, true},
+		{`-1`, true},
+		{`0.0`, false},
+		{`0.5`, true},
+		{`decimal(0)`, false},
+		{`decimal("1.5")`, true},
+		{`""`, false},
+		{`"0"`, true},
+		{`[]`, false},
+		{`[0]`, true},
+		{`{}`, false},
+		{`{"a": 1}`, true},
+		{`fun() {}`, true},
+	}
+
+	expected := func(truthy bool) string {
+		if truthy {
+			return "true"
+		}
+
+		return "false"
+	}
+
+	for _, tt := range tests {
+		// Every construct that takes a condition has to agree with bool()
+		inputs := map[string]bool{
+			"bool(" + tt.value + ")":                                             tt.truthy,
+			"!(" + tt.value + ")":                                                !tt.truthy,
+			"not (" + tt.value + ")":                                             !tt.truthy,
+			"if " + tt.value + ": { true } else { false }":                       tt.truthy,
+			"(" + tt.value + ") ? true : false":                                  tt.truthy,
+			"bool((" + tt.value + ") and true)":                                  tt.truthy,
+			"bool((" + tt.value + ") or false)":                                  tt.truthy,
+			"let n = 0; during " + tt.value + " and n == 0: { n += 1; }; n == 1": tt.truthy,
+		}
+
+		for input, truthy := range inputs {
+			if result := testEval(input); result.Inspect() != expected(truthy) {
+				t.Errorf("%s: expected %s, got %q", input, expected(truthy), result.Inspect())
+			}
+		}
+	}
+}
+
+func TestNotKeyword(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`not true`, "false"},
+		{`not 0`, "true"},
+		{`not not "DE"`, "true"},
+		{`not 1 == 2`, "true"},
+		{`not 1 < 2 and 3 > 2`, "false"},
+		{`not false or false`, "true"},
+		{`let empty = []; if not empty: { "empty" } else { "full" }`, "empty"},
+		{`!1 == false`, "true"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestMatchLiterals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`match 1 { 1 => "one", 2 => "two" }`, "one"},
+		{`match 3 { 1, 2 => "small", 3, 4 => "medium" }`, "medium"},
+		{`match -1 { -1 => "minus one", _ => "other" }`, "minus one"},
+		{`match 2.5 { 2.5 => "float" }`, "float"},
+		{`match "hi" { "hello" => 1, "hi" => 2 }`, "2"},
+		{`match true { false => 0, true => 1 }`, "1"},
+		{`match 1 { 1.0 => "float", _ => "int" }`, "int"},
+		{`match 1 { "1" => "string", _ => "int" }`, "int"},
+		{`match 7 { _ => "anything" }`, "anything"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestMatchPatterns(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`match [1, 2] { [] => 0, [x] => x, [x, y] => x + y }`, "3"},
+		{`match [1, 2, 3] { [x, y] => 0, [head, ...rest] => rest }`, "[2, 3]"},
+		{`match [1, [2, 3]] { [a, [b, c]] => a + b + c }`, "6"},
+		{`match [0, 5] { [1, y] => "one", [0, y] => y }`, "5"},
+		{`match {"kind": "circle", "r": 2} { {kind: "square", side} => side, {kind: "circle", r} => r * 3 }`, "6"},
+		{`match {"name": "DE"} { {age} => age, {name} => name }`, "DE"},
+		{`match {"point": [1, 2]} { {point: [x, y]} => x * y }`, "2"},
+		{`match 5 { int => "int", str => "str" }`, "int"},
+		{`match "DE" { int => "int", str => "str" }`, "str"},
+		{`match [1] { hash => "hash", array => "array" }`, "array"},
+		{`match 1.5 { float => "float" }`, "float"},
+		{`match 42 { n => n + 1 }`, "43"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestMatchGuardsAndScope(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`match 15 { n if n > 10 => "big", n => "small" }`, "big"},
+		{`match 5 { n if n > 10 => "big", n => "small" }`, "small"},
+		{`match [3, 4] { [x, y] if x > y => "desc", [x, y] => "asc" }`, "asc"},
+		{`let limit = 3; match 4 { n if n > limit => "over", _ => "under" }`, "over"},
+		// Every arm has its own environment
+		{`match 5 { n => n }; n`, "ERROR: identifier not found: n"},
+		{`let x = 1; match 5 { x => x }; x`, "1"},
+		{`match [1, 2] { [x, 9] => x, [y, z] => x }`, "ERROR: identifier not found: x"},
+		{`let total = 0; match 2 { n => { total += n; total += n; } }; total`, "4"},
+		{`let f = fun(v) { match v { 0 => { return "zero"; }, _ => "other" }; "after" }; [f(0), f(1)]`, "[zero, after]"},
+		{`let r = match 1 { 1 => "a" }; r`, "a"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestMatchErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`match 3 { 1 => "one", 2 => "two" }`, "ERROR: No match arm matches 3"},
+		{`match [1, 2, 3] { [x, y] => x }`, "ERROR: No match arm matches [1, 2, 3]"},
+		{`match 1 { n if missing => n }`, "ERROR: identifier not found: missing"},
+		{`match missing { _ => 1 }`, "ERROR: identifier not found: missing"},
+		{`match 1 { len => len }`, "ERROR: Shadowing of 'len' is not allowed"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
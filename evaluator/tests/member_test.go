@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestHashMembers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let person = {"name": "DE"}; person.name`, "DE"},
+		{`let person = {"name": "DE"}; person.age`, "null"},
+		{`let person = {"name": "DE"}; person.name = "Delang"; person["name"]`, "Delang"},
+		{`let person = {}; person.age = 30; person.age + 1`, "31"},
+		{`let person = {"address": {"city": "Amman"}}; person.address.city`, "Amman"},
+		{`let person = {"address": {}}; person.address.city = "Amman"; person.address.city`, "Amman"},
+		{`let counter = {"inc": fun(x) { x + 1 }}; counter.inc(1)`, "2"},
+		{`let person = {"b": 2, "a": 1}; person.keys()`, "[a, b]"},
+		{`let person = {"b": 2, "a": 1}; person.values()`, "[1, 2]"},
+		{`let person = {"keys": "mine"}; person.keys`, "mine"},
+		{`let person = {"a": 1}; [person.has("a"), person.has("b"), person.len()]`, "[true, false, 1]"},
+		{`let x = 5; x.name = 1`, "ERROR: cannot set member 'name' of INTEGER"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`"abc".upper()`, "ABC"},
+		{`"  Hello ".trim().lower()`, "hello"},
+		{`"a,b,c".split(",")`, "[a, b, c]"},
+		{`"delang".contains("lang")`, "true"},
+		{`"delang".startsWith("de") and "delang".endsWith("ng")`, "true"},
+		{`"delang".indexOf("l")`, "2"},
+		{`"a-b".replace("-", "+")`, "a+b"},
+		{`"abc".len()`, "3"},
+		{`[1, 2, 3].map(fun(x) { x * 2 })`, "[2, 4, 6]"},
+		{`[1, 2, 3].map(fun(x, i) { x + i })`, "[1, 3, 5]"},
+		{`[1, 2, 3, 4].filter(fun(x) { x % 2 == 0 })`, "[2, 4]"},
+		{`[1, 2, 3].reduce(fun(acc, x) { acc + x }, 10)`, "16"},
+		{`[1, "a", true].join(", ")`, "1, a, true"},
+		{`[1, 2, 3].contains(2)`, "true"},
+		{`["a", "b"].indexOf("c")`, "-1"},
+		{`let arr = [1]; arr.push(2); arr`, "[1, 2]"},
+		{`[3, 4].first() + [3, 4].last()`, "7"},
+		{`let double = fun(x) { x * 2 }; [1, 2].map(double).len()`, "2"},
+		{`let upper = "de".upper; upper()`, "DE"},
+		{`(5).str() + 1.5.str()`, "51.5"},
+		{`"abc".nope()`, "ERROR: STRING has no member 'nope'"},
+		{`"abc".upper(1)`, "ERROR: wrong number of arguments passed to upper(). got=1, want=0"},
+		{`"abc".split(1)`, "ERROR: argument to `split` must be STRING, got INTEGER"},
+		{`[1, 2].map(fun(x) { x + true })`, "ERROR: type mismatch: INTEGER + BOOLEAN"},
+		{`[1, 2].join(1)`, "ERROR: argument to `join` must be STRING, got INTEGER"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
@@ -149,3 +149,23 @@ func TestGreater_Less_EQ_Operation(t *testing.T) {
 		testBooleanObject(t, evaluated, val.expected)
 	}
 }
+
+func TestNumberLiteralForms(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"0xFF", "255"},
+		{"0b1010 + 0o17", "25"},
+		{"1_000_000 / 1_000", "1000"},
+		{"6.02e23 > 6e23", "true"},
+		{"2.5e-1", "0.25"},
+		{"010", "10"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
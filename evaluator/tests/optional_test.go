@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestConditionalExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`true ? 1 : 2`, "1"},
+		{`false ? 1 : 2`, "2"},
+		{`0 ? "yes" : "no"`, "no"},
+		{`{}.missing ? "yes" : "no"`, "no"},
+		{`let n = 5; n > 3 ? "big" : "small"`, "big"},
+		{`let n = 0; n < 0 ? "negative" : n == 0 ? "zero" : "positive"`, "zero"},
+		{`let x = 1; let y = x == 1 ? x + 1 : x - 1; y`, "2"},
+		{`true ? 1 : undefinedName`, "1"},
+		{`false ? undefinedName : 2`, "2"},
+		{`let f = fun(n) { n == 0 ? 1 : n * f(n - 1) }; f(5)`, "120"},
+		{`undefinedName ? 1 : 2`, "ERROR: identifier not found: undefinedName"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestNullishExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let h = {}; h.missing ?? 1`, "1"},
+		{`2 ?? 1`, "2"},
+		{`0 ?? 1`, "0"},
+		{`false ?? true`, "false"},
+		{`"" ?? "default"`, ""},
+		{`let h = {}; h.a ?? h.b ?? 3`, "3"},
+		{`let h = {"a": 1}; h["b"] ?? 0`, "0"},
+		{`1 ?? undefinedName`, "1"},
+		{`let calls = 0; let f = fun() { calls += 1; }; 1 ?? f(); calls`, "0"},
+		{`let h = {}; h.a ?? 1 + 2`, "3"},
+		{`let h = {}; h.a ?? undefinedName`, "ERROR: identifier not found: undefinedName"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestOptionalChaining(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let user = {"name": "DE"}; user?.name`, "DE"},
+		{`let user = {}.user; user?.name`, "null"},
+		{`let user = {}.user; user?["name"]`, "null"},
+		{`let arr = [1, 2]; arr?[1]`, "2"},
+		{`let user = {"address": {}.address}; user.address?.city`, "null"},
+		{`let user = {}.user; user?.address.city`, "null"},
+		{`let user = {}.user; user?.name.upper()`, "null"},
+		{`let user = {}.user; user?["tags"][0]`, "null"},
+		{`let user = {"name": "de"}; user?.name.upper()`, "DE"},
+		{`let user = {}.user; user?.name ?? "anon"`, "anon"},
+		{`let calls = 0; let f = fun() { calls += 1; "k" }; let h = {}.h; h?[f()]; calls`, "0"},
+		{`let user = {"address": {}.address}; user.address.city`, "ERROR: NULL has no member 'city'"},
+		{`let user = 5; user?.name`, "ERROR: INTEGER has no member 'name'"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
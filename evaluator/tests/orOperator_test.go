@@ -15,8 +15,6 @@ func TestOrLogicalWithBoolean(t *testing.T) {
 		{"true or false", true},
 		{"false or true", true},
 		{"false or false", false},
-		{"1 or true", true},
-		{"1 or false", true},
 		{"0 or true", true},
 		{"0 or false", false},
 	}
@@ -42,6 +40,9 @@ func TestOrLogicalWithInteger(t *testing.T) {
 		{"2 or 2", 2},
 		{"2 or 0", 2},
 		{"0 or 2", 2},
+		// The truthy left side is the result, not true
+		{"1 or true", 1},
+		{"1 or false", 1},
 	}
 
 	for _, val := range tests {
@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestRestParameters(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let f = fun(head, ...others) { [head, others] }; f(1, 2, 3)`, "[1, [2, 3]]"},
+		{`let f = fun(head, ...others) { others }; f(1)`, "[]"},
+		{`let sum = fun(...numbers) { numbers.reduce(fun(acc, n) { acc + n }, 0) }; sum(1, 2, 3, 4)`, "10"},
+		{`let f = fun(a, b = 2, ...others) { [a, b, others] }; f(1)`, "[1, 2, []]"},
+		{`let f = fun(head, ...others) { head }; f()`, "ERROR: wrong number of arguments: want=1, got=0, missing parameter 'head'"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestDefaultParameters(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let f = fun(x, y = x * 2) { x + y }; f(3)`, "9"},
+		{`let base = 100; let f = fun(x = base) { x }; f()`, "100"},
+		{`let calls = 0; let f = fun(x = calls++) { x }; f(); f(5); f(); calls`, "2"},
+		{`let f = fun([a, b] = [1, 2]) { a + b }; f()`, "3"},
+		{`let f = fun(x = missing) { x }; f()`, "ERROR: identifier not found: missing"},
+		{`let f = fun(x = missing) { x }; f(1)`, "1"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestSpreadArguments(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let add = fun(x, y, z) { x + y + z }; let arr = [1, 2, 3]; add(...arr)`, "6"},
+		{`let add = fun(x, y, z) { x + y + z }; add(1, ...[2, 3])`, "6"},
+		{`let add = fun(x, y, z) { x + y + z }; add(...[1], 2, ...[3])`, "6"},
+		{`let f = fun(...all) { all }; f(...[], ...[1, 2])`, "[1, 2]"},
+		{`len(...["abc"])`, "3"},
+		{`let add = fun(x, y) { x + y }; add(...[1, 2, 3])`, "ERROR: wrong number of arguments: want=2, got=3, unexpected argument 3"},
+		{`let f = fun(x) { x }; f(...5)`, "ERROR: Cannot spread INTEGER into arguments, expected ARRAY"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
+
+func TestKeywordArguments(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let f = fun(x, y) { [x, y] }; f(y: 2, x: 1)`, "[1, 2]"},
+		{`let f = fun(x, y = 10, z = 20) { [x, y, z] }; f(1, z: 3)`, "[1, 10, 3]"},
+		{`let f = fun(x, ...others) { [x, others] }; f(x: 1)`, "[1, []]"},
+		{`let f = fun(x, y) { x }; f(1, z: 3)`, "ERROR: unexpected keyword argument 'z'"},
+		{`let f = fun(x, y) { x }; f(1, x: 3)`, "ERROR: parameter 'x' got multiple values"},
+		{`let f = fun(x, y) { x }; f(y: 3)`, "ERROR: wrong number of arguments: want=2, got=1, missing parameter 'x'"},
+		{`let f = fun(x, ...others) { x }; f(1, others: [2])`, "ERROR: rest parameter 'others' cannot be passed by keyword"},
+		{`let f = fun([a, b]) { a }; f(a: 1)`, "ERROR: unexpected keyword argument 'a'"},
+		{`len(x: "abc")`, "ERROR: keyword argument 'x' passed to BUILTIN, only functions declared with fun accept keyword arguments"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+}
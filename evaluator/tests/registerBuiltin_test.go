@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mostafa-DE/delang/evaluator"
+)
+
+type rectangle struct {
+	Width  float64 `de:"width"`
+	Height float64 `de:"height"`
+}
+
+func init() {
+	evaluator.RegisterBuiltin("area", "Returns the area of a rectangle", func(r rectangle) float64 {
+		return r.Width * r.Height
+	})
+
+	evaluator.RegisterBuiltin("repeatWord", "Repeats a word", func(word string, times int) []string {
+		words := make([]string, times)
+
+		for idx := range words {
+			words[idx] = word
+		}
+
+		return words
+	})
+}
+
+func TestRegisterBuiltin(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`area({"width": 2.5, "height": 4})`, "10"},
+		{`repeatWord("de", 3)`, "[de, de, de]"},
+		{`len(repeatWord("de", 2))`, "2"},
+		{`typeof(area)`, "BUILTIN"},
+		{`area(1)`, "ERROR: `area`: argument 1: cannot convert INTEGER to tests.rectangle"},
+		{`repeatWord("de")`, "ERROR: `repeatWord`: wrong number of arguments. got=1, want=2"},
+	}
+
+	for _, tt := range tests {
+		if result := testEval(tt.input); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.input, tt.expected, result.Inspect())
+		}
+	}
+
+	err := evaluator.RegisterBuiltin("len", "", func() {})
+
+	if err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Errorf("expected registering an existing builtin to fail, got %v", err)
+	}
+
+	if builtin, ok := evaluator.GetBuiltin("area"); !ok || builtin.Desc != "Returns the area of a rectangle" {
+		t.Errorf("expected the registered builtin to be documented")
+	}
+}
@@ -26,30 +26,43 @@ func getBooleanObject(val bool) *object.Boolean {
 	return FALSE
 }
 
+/*
+isTruthy is the truthiness of DE, every place that needs a condition uses it:
+`if`, `during`, `!`, `not`, `and`, `or`, `?:`, the guards of match and bool().
+
+The falsy values are false, null, the zero of every number (0, 0.0 and decimal zero),
+the empty string, the empty array and the empty hash. Every other value is truthy.
+*/
 func isTruthy(obj object.Object) bool {
-	if obj == nil {
+	switch obj := obj.(type) {
+	case nil, *object.Null:
 		return false
-	}
 
-	if obj == NULL {
-		return false
-	}
+	case *object.Boolean:
+		return obj.Value
 
-	if obj.Type() == object.INTEGER_OBJ {
-		return obj.(*object.Integer).Value != 0
-	}
+	case *object.Integer:
+		return obj.Value != 0
+
+	case *object.Float:
+		return obj.Value != 0
 
-	if obj.Type() == object.BOOLEAN_OBJ {
-		return obj.(*object.Boolean).Value
+	case *object.Decimal:
+		return !obj.Value.IsZero()
+
+	case *object.String:
+		return obj.Value != ""
+
+	case *object.Array:
+		return len(obj.Elements) != 0
+
+	case *object.Hash:
+		return len(obj.Pairs) != 0
 	}
 
 	return true
 }
 
-func intToBool(val int64) bool {
-	return val != 0
-}
-
 func makeRangeArray(startRange int64, endRange int64) *object.Array {
 	size := endRange - startRange + 1
 	elements := make([]object.Object, size)
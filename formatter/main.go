@@ -0,0 +1,112 @@
+package formatter
+
+import (
+	"strings"
+)
+
+const INDENT = "    "
+
+/*
+Format normalizes the layout of DE source code without touching its tokens:
+
+- Every line is re-indented by the depth of the brackets `{ ( [` that are open before it.
+- Lines that start with closing brackets are outdented, e.g `} else {`.
+- Trailing whitespace is removed and runs of blank lines are collapsed into one.
+
+Brackets inside strings and comments are ignored.
+Comments are kept as they are because the formatter works on the text and not on the AST.
+*/
+func Format(source string) string {
+	var out strings.Builder
+
+	depth := 0
+	blankLines := 0
+	wroteLine := false
+
+	for _, line := range strings.Split(source, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" {
+			blankLines++
+			continue
+		}
+
+		if wroteLine && blankLines > 0 {
+			out.WriteString("\n")
+		}
+
+		blankLines = 0
+
+		opens, closes, leadingCloses := countBrackets(trimmed)
+
+		indent := depth - leadingCloses
+
+		if indent < 0 {
+			indent = 0
+		}
+
+		out.WriteString(strings.Repeat(INDENT, indent))
+		out.WriteString(trimmed)
+		out.WriteString("\n")
+
+		wroteLine = true
+
+		depth += opens - closes
+
+		if depth < 0 {
+			depth = 0
+		}
+	}
+
+	return out.String()
+}
+
+// countBrackets counts the opening and closing brackets of a line that are outside strings and comments,
+// leadingCloses is the number of closing brackets that come before anything else on the line
+func countBrackets(line string) (opens int, closes int, leadingCloses int) {
+	var quote byte
+	leading := true
+
+	for idx := 0; idx < len(line); idx++ {
+		char := line[idx]
+
+		if quote != 0 {
+			if char == quote {
+				quote = 0
+			}
+
+			continue
+		}
+
+		switch char {
+		case '"', '\'':
+			quote = char
+			leading = false
+
+		case '/':
+			if idx+1 < len(line) && line[idx+1] == '/' {
+				return opens, closes, leadingCloses
+			}
+
+			leading = false
+
+		case '{', '(', '[':
+			opens++
+			leading = false
+
+		case '}', ')', ']':
+			closes++
+
+			if leading {
+				leadingCloses++
+			}
+
+		case ' ', '\t':
+
+		default:
+			leading = false
+		}
+	}
+
+	return opens, closes, leadingCloses
+}
@@ -0,0 +1,36 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Mostafa-DE/delang/formatter"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let x = 1;", "let x = 1;\n"},
+		{"let x = 1;   \n\n\n\nlet y = 2;\n\n", "let x = 1;\n\nlet y = 2;\n"},
+		{"if x: {\nlogs(x);\n} else {\nlogs(0);\n}", "if x: {\n    logs(x);\n} else {\n    logs(0);\n}\n"},
+		{"let h = {\n'a': [\n1,\n2\n],\n};", "let h = {\n    'a': [\n        1,\n        2\n    ],\n};\n"},
+		{"logs('{ not a bracket');\n  logs(1);", "logs('{ not a bracket');\nlogs(1);\n"},
+		{"let f = fun() { // {\n  return 1;\n};", "let f = fun() { // {\n    return 1;\n};\n"},
+		{"        }\n}", "}\n}\n"},
+	}
+
+	for _, tt := range tests {
+		got := formatter.Format(tt.input)
+
+		if got != tt.expected {
+			t.Errorf("Format(%q) wrong. expected=%q, got=%q", tt.input, tt.expected, got)
+		}
+	}
+
+	formatted := formatter.Format("during x < 10: {\nx = x + 1;\n}")
+
+	if formatter.Format(formatted) != formatted {
+		t.Errorf("Format is not idempotent for %q", formatted)
+	}
+}
@@ -7,4 +7,4 @@ require (
 	github.com/shopspring/decimal v1.3.1
 )
 
-require golang.org/x/sys v0.0.0-20220520151302-bc2c85ada10a // indirect
+require golang.org/x/sys v0.0.0-20220520151302-bc2c85ada10a
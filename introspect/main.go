@@ -0,0 +1,138 @@
+package introspect
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/evaluator"
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/object"
+	"github.com/Mostafa-DE/delang/token"
+)
+
+// Tokens returns the tokens of the source as the parser sees them, the EOF token is left out
+func Tokens(source string) []token.Token {
+	tokens := []token.Token{}
+	l := lexer.New(source)
+
+	for tok := l.NextToken(); tok.Type != token.EOFILE; tok = l.NextToken() {
+		tokens = append(tokens, tok)
+	}
+
+	return tokens
+}
+
+// FormatTokens prints one token per line with its position, type and literal
+func FormatTokens(tokens []token.Token) string {
+	var out strings.Builder
+
+	for _, tok := range tokens {
+		fmt.Fprintf(&out, "%d:%d\t%s\t%q\n", tok.Line, tok.Column, tok.Type, tok.Literal)
+	}
+
+	return out.String()
+}
+
+/*
+Tree draws the AST under node, one node per line with its children indented below it:
+
+	Program
+	└── LetStatement
+	    ├── Identifier x
+	    └── InfixExpression +
+	        ├── Integer 1
+	        └── Integer 2
+*/
+func Tree(node ast.Node) string {
+	var out strings.Builder
+
+	out.WriteString(label(node) + "\n")
+	writeChildren(&out, node, "")
+
+	return out.String()
+}
+
+func writeChildren(out *strings.Builder, node ast.Node, indent string) {
+	kids := children(node)
+
+	for idx, child := range kids {
+		branch, nextIndent := "├── ", indent+"│   "
+
+		if idx == len(kids)-1 {
+			branch, nextIndent = "└── ", indent+"    "
+		}
+
+		out.WriteString(indent + branch + label(child) + "\n")
+		writeChildren(out, child, nextIndent)
+	}
+}
+
+// children returns the direct children of a node, in the order ast.Inspect visits them
+func children(node ast.Node) []ast.Node {
+	kids := []ast.Node{}
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		if n == node {
+			return true
+		}
+
+		kids = append(kids, n)
+
+		return false
+	})
+
+	return kids
+}
+
+// label is the type of a node followed by what tells it apart from nodes of the same type
+func label(node ast.Node) string {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", node), "*ast.")
+
+	switch node := node.(type) {
+	case *ast.Identifier:
+		return name + " " + node.Value
+	case *ast.Integer, *ast.Float, *ast.Decimal, *ast.Boolean:
+		return name + " " + node.String()
+	case *ast.StringLiteral:
+		return fmt.Sprintf("%s %q", name, node.Value)
+	case *ast.PrefixExpression:
+		return name + " " + node.Operator
+	case *ast.InfixExpression:
+		return name + " " + node.Operator
+	}
+
+	return name
+}
+
+// Timing is what evaluating a program cost
+type Timing struct {
+	Duration    time.Duration
+	Allocations uint64 // The number of heap allocations
+	Bytes       uint64 // The number of bytes allocated on the heap
+}
+
+func (t Timing) String() string {
+	return fmt.Sprintf("%s, %d allocations, %d bytes", t.Duration, t.Allocations, t.Bytes)
+}
+
+// Time evaluates a program and measures the wall-clock time and the heap allocations it took
+func Time(program *ast.Program, env *object.Environment) (object.Object, Timing) {
+	var before, after runtime.MemStats
+
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	result := evaluator.Eval(program, env)
+
+	duration := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	return result, Timing{
+		Duration:    duration,
+		Allocations: after.Mallocs - before.Mallocs,
+		Bytes:       after.TotalAlloc - before.TotalAlloc,
+	}
+}
@@ -0,0 +1,66 @@
+package introspect
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/object"
+	"github.com/Mostafa-DE/delang/parser"
+)
+
+const USAGE = "Usage: de inspect <tokens|ast|type|time> file.de"
+
+// Run is the entry point of `de inspect`, it shows how DE reads and runs a file
+func Run() {
+	if len(os.Args) != 4 {
+		fmt.Println(USAGE)
+		os.Exit(1)
+	}
+
+	command, filename := os.Args[2], os.Args[3]
+	content, err := os.ReadFile(filename)
+
+	if err != nil {
+		fmt.Println("Error reading file:", err)
+		os.Exit(1)
+	}
+
+	source := string(content)
+
+	if command == "tokens" {
+		fmt.Print(FormatTokens(Tokens(source)))
+		return
+	}
+
+	p := parser.New(lexer.New(source))
+	program := p.ParseProgram()
+
+	if len(p.Errors()) != 0 {
+		fmt.Println("Error parsing program:")
+		fmt.Println(p.Errors()[0])
+		os.Exit(1)
+	}
+
+	switch command {
+	case "ast":
+		fmt.Print(Tree(program))
+
+	case "type", "time":
+		result, timing := Time(program, object.NewEnvironment())
+
+		if result == nil {
+			result = &object.Null{}
+		}
+
+		if command == "type" {
+			fmt.Println(result.Type())
+		} else {
+			fmt.Println(timing)
+		}
+
+	default:
+		fmt.Println(USAGE)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Mostafa-DE/delang/introspect"
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/object"
+	"github.com/Mostafa-DE/delang/parser"
+)
+
+func TestTokens(t *testing.T) {
+	expected := "1:1\tLET\t\"let\"\n1:5\tIDENT\t\"name\"\n1:10\t=\t\"=\"\n1:12\tSTRING\t\"de\"\n1:16\t;\t\";\"\n"
+
+	if got := introspect.FormatTokens(introspect.Tokens("let name = 'de';")); got != expected {
+		t.Errorf("Tokens wrong. expected=%q, got=%q", expected, got)
+	}
+}
+
+func TestTree(t *testing.T) {
+	input := `
+		let add = fun(a, b) { return a + b; };
+		if !done: { logs({'b': 1.5, 'a': true}); }
+	`
+
+	expected := `Program
+├── LetStatement
+│   ├── Identifier add
+│   └── Function
+│       ├── Identifier a
+│       ├── Identifier b
+│       └── BlockStatement
+│           └── ReturnStatement
+│               └── InfixExpression +
+│                   ├── Identifier a
+│                   └── Identifier b
+└── ExpressionStatement
+    └── IfExpression
+        ├── PrefixExpression !
+        │   └── Identifier done
+        └── BlockStatement
+            └── ExpressionStatement
+                └── CallFunction
+                    ├── Identifier logs
+                    └── Hash
+                        ├── StringLiteral "a"
+                        ├── Boolean true
+                        ├── StringLiteral "b"
+                        └── Float 1.5
+`
+
+	program := parser.New(lexer.New(input)).ParseProgram()
+
+	if got := introspect.Tree(program); got != expected {
+		t.Errorf("Tree wrong. expected=\n%s\ngot=\n%s", expected, got)
+	}
+}
+
+func TestTime(t *testing.T) {
+	program := parser.New(lexer.New("let items = [1, 2, 3]; len(items)")).ParseProgram()
+	env := object.NewEnvironment()
+
+	result, timing := introspect.Time(program, env)
+
+	if result.Inspect() != "3" {
+		t.Errorf("Result wrong. expected=3, got=%s", result.Inspect())
+	}
+
+	if timing.Duration <= 0 || timing.Allocations == 0 || timing.Bytes == 0 {
+		t.Errorf("Timing should measure the evaluation. got=%+v", timing)
+	}
+
+	if _, ok := env.Get("items"); !ok {
+		t.Errorf("Time should evaluate in the given environment")
+	}
+}
@@ -11,6 +11,18 @@ type Lexer struct {
 	currentPosition  int  // current position in input, points to the character in the input that corresponds to the ch byte.
 	readNextPosition int  // current reading position in input, points to the “next” character in the input.
 	currentChar      byte // current char under examination.
+	line             int  // line of currentChar, starting from 1.
+	column           int  // byte column of currentChar, starting from 1.
+	openString       bool // the input ended inside a string.
+	errors           []Error
+	pendingError     string // the message of the ILLEGAL token being read, NextToken gives it a position
+}
+
+// Error describes a malformed token, the lexer gives an ILLEGAL token for it at the same position
+type Error struct {
+	Msg    string
+	Line   int
+	Column int
 }
 
 func New(input string) *Lexer {
@@ -19,7 +31,7 @@ func New(input string) *Lexer {
 	// But in our language we want to allow single double quotes to represent strings
 	input = strings.ReplaceAll(input, `'`, `"`)
 
-	l := &Lexer{input: input}
+	l := &Lexer{input: input, line: 1}
 	l.readChar()
 	return l
 }
@@ -29,25 +41,45 @@ func newToken(tokenType token.TokenType, currentChar byte) token.Token {
 }
 
 func (l *Lexer) NextToken() token.Token {
-	var tok token.Token
+	l.skipWhiteSpaceAndComments()
+
+	line, column := l.line, l.column
+	tok := l.readToken()
+	tok.Line = line
+	tok.Column = column
+
+	if l.pendingError != "" {
+		l.errors = append(l.errors, Error{Msg: l.pendingError, Line: line, Column: column})
+		l.pendingError = ""
+	}
+
+	return tok
+}
+
+// Errors returns the malformed tokens read so far, in the order of the input
+func (l *Lexer) Errors() []Error {
+	return l.errors
+}
 
-	l.skipWhiteSpace()
+// illegal returns an ILLEGAL token for literal and records why it is illegal
+func (l *Lexer) illegal(literal string, msg string) token.Token {
+	l.pendingError = msg
+
+	return token.Token{Type: token.ILLEGAL, Literal: literal}
+}
+
+func (l *Lexer) readToken() token.Token {
+	var tok token.Token
 
 	switch l.currentChar {
 	case '=':
-		if l.peekChar() == '=' {
-			prevChar := l.currentChar // "="
-			l.readChar()
-			tok = token.Token{Type: token.EQUAL, Literal: string(prevChar) + string(l.currentChar)}
-		} else {
-			tok = newToken(token.ASSIGN, l.currentChar)
-		}
+		tok = l.readOperator(token.ASSIGN, map[byte]token.TokenType{'=': token.EQUAL, '>': token.ARROW})
 
 	case '+':
-		tok = newToken(token.PLUS, l.currentChar)
+		tok = l.readOperator(token.PLUS, map[byte]token.TokenType{'=': token.PLUS_ASSIGN, '+': token.INCREMENT})
 
 	case '-':
-		tok = newToken(token.MINUS, l.currentChar)
+		tok = l.readOperator(token.MINUS, map[byte]token.TokenType{'=': token.MINUS_ASSIGN, '-': token.DECREMENT})
 
 	case '{':
 		tok = newToken(token.LEFTBRAC, l.currentChar)
@@ -64,6 +96,15 @@ func (l *Lexer) NextToken() token.Token {
 	case ':':
 		tok = newToken(token.COLON, l.currentChar)
 
+	case '.':
+		if l.peekChar() == '.' && l.peekNChar(2) == '.' {
+			l.readChar()
+			l.readChar()
+			tok = token.Token{Type: token.ELLIPSIS, Literal: "..."}
+		} else {
+			tok = newToken(token.DOT, l.currentChar)
+		}
+
 	case '!':
 		if l.peekChar() == '=' {
 			prevChar := l.currentChar // "!"
@@ -74,14 +115,10 @@ func (l *Lexer) NextToken() token.Token {
 		}
 
 	case '/':
-		if l.peekChar() == '/' {
-			l.skipComment()
-			return l.NextToken()
-		}
-		tok = newToken(token.SLASH, l.currentChar)
+		tok = l.readOperator(token.SLASH, map[byte]token.TokenType{'=': token.SLASH_ASSIGN})
 
 	case '*':
-		tok = newToken(token.ASTERISK, l.currentChar)
+		tok = l.readOperator(token.ASTERISK, map[byte]token.TokenType{'=': token.ASTERISK_ASSIGN})
 
 	case '<':
 		if l.peekNChar(1) == '=' {
@@ -118,31 +155,10 @@ func (l *Lexer) NextToken() token.Token {
 		tok = newToken(token.RIGHTSQPRAC, l.currentChar)
 
 	case '%':
-		tok = newToken(token.MOD, l.currentChar)
+		tok = l.readOperator(token.MOD, map[byte]token.TokenType{'=': token.MOD_ASSIGN})
 
-	case 'a':
-		if l.peekNChar(1) == 'n' && l.peekNChar(2) == 'd' {
-			tok = token.Token{Type: token.AND, Literal: "and"}
-			l.readChar()
-			l.readChar()
-		} else {
-			tok.Literal = l.readIdentifier()
-			tok.Type = token.LookupIdent(tok.Literal)
-
-			return tok
-		}
-
-	case 'o':
-		if l.peekNChar(1) == 'r' {
-			tok = token.Token{Type: token.OR, Literal: "or"}
-			l.readChar()
-		} else {
-			tok.Literal = l.readIdentifier()
-			tok.Type = token.LookupIdent(tok.Literal)
-
-			return tok
-
-		}
+	case '?':
+		tok = l.readOperator(token.QUESTION, map[byte]token.TokenType{'?': token.NULLISH, '.': token.OPTIONAL_DOT, '[': token.OPTIONAL_INDEX})
 
 	case 0: // End of the line
 		tok.Literal = ""
@@ -156,15 +172,7 @@ func (l *Lexer) NextToken() token.Token {
 			return tok // This return is important because we don't want to call readChar() again
 
 		} else if isNumber(l.currentChar) {
-			tok.Literal = l.readNumber()
-			if strings.Contains(tok.Literal, ".") {
-				tok.Type = token.FLOAT
-				return tok
-			}
-
-			tok.Type = token.INT
-
-			return tok
+			return l.readNumber()
 
 		} else {
 			tok = newToken(token.ILLEGAL, l.currentChar)
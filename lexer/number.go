@@ -0,0 +1,149 @@
+package lexer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mostafa-DE/delang/token"
+)
+
+// prefixedBases are the integers written in another base, the key is the letter after the leading 0
+var prefixedBases = map[byte]struct {
+	name    string
+	isDigit func(byte) bool
+}{
+	'x': {"hexadecimal", isHexDigit},
+	'b': {"binary", func(char byte) bool { return char == '0' || char == '1' }},
+	'o': {"octal", func(char byte) bool { return char >= '0' && char <= '7' }},
+}
+
+/*
+readNumber reads a number literal:
+
+- INT: `42`, `1_000_000`, `0xFF`, `0b1010` or `0o755`, a plain integer is always base 10 even with leading zeros.
+- FLOAT: `3.14`, `6.02e23` or `1e-9`.
+- DECIMAL: an INT or a FLOAT with the `d` suffix, e.g `19.99d`.
+
+`_` may only separate two digits. A dot that is not followed by a digit is member access, e.g `1.5.str()`.
+Anything else glued to the number, like `1.2.3` or `12px`, gives an ILLEGAL token, see Errors for the reason.
+*/
+func (l *Lexer) readNumber() token.Token {
+	position := l.currentPosition
+
+	if base, ok := prefixedBases[lower(l.peekChar())]; ok && l.currentChar == '0' {
+		l.readChar()
+		l.readChar()
+
+		digits := l.currentPosition
+		l.readWhile(func(char byte) bool { return isLetter(char) || isNumber(char) })
+		literal := l.input[position:l.currentPosition]
+
+		if l.currentPosition == digits {
+			return l.illegal(literal, fmt.Sprintf("Invalid number '%s': expected %s digits after '%s'", literal, base.name, literal[:2]))
+		}
+
+		for _, char := range []byte(l.input[digits:l.currentPosition]) {
+			if char != '_' && !base.isDigit(char) {
+				return l.illegal(literal, fmt.Sprintf("Invalid number '%s': '%c' is not a digit of %s numbers", literal, char, base.name))
+			}
+		}
+
+		return l.checkUnderscores(token.Token{Type: token.INT, Literal: literal}, base.isDigit)
+	}
+
+	tokenType := token.TokenType(token.INT)
+	l.readWhile(isDigitOrUnderscore)
+
+	if l.currentChar == '.' && isNumber(l.peekChar()) {
+		tokenType = token.FLOAT
+		l.readChar()
+		l.readWhile(isDigitOrUnderscore)
+	}
+
+	if lower(l.currentChar) == 'e' {
+		tokenType = token.FLOAT
+		l.readChar()
+
+		if l.currentChar == '+' || l.currentChar == '-' {
+			l.readChar()
+		}
+
+		if !isNumber(l.currentChar) {
+			return l.illegal(l.readRest(position), fmt.Sprintf("Invalid number '%s': expected digits after the exponent", l.input[position:l.currentPosition]))
+		}
+
+		l.readWhile(isDigitOrUnderscore)
+	}
+
+	if l.currentChar == 'd' && !isLetter(l.peekChar()) && !isNumber(l.peekChar()) {
+		tokenType = token.DECIMAL
+		l.readChar()
+	}
+
+	if l.currentChar == '.' && isNumber(l.peekChar()) {
+		literal := l.readRest(position)
+
+		return l.illegal(literal, fmt.Sprintf("Invalid number '%s': a number has at most one decimal point", literal))
+	}
+
+	if isLetter(l.currentChar) || isNumber(l.currentChar) {
+		char := l.currentChar
+		literal := l.readRest(position)
+
+		return l.illegal(literal, fmt.Sprintf("Invalid number '%s': unexpected '%c' after the number", literal, char))
+	}
+
+	return l.checkUnderscores(token.Token{Type: tokenType, Literal: l.input[position:l.currentPosition]}, isNumber)
+}
+
+// checkUnderscores turns a number into an ILLEGAL token when one of its `_` is not between two digits
+func (l *Lexer) checkUnderscores(tok token.Token, isDigit func(byte) bool) token.Token {
+	literal := tok.Literal
+
+	for idx := strings.IndexByte(literal, '_'); idx != -1; idx = nextIndex(literal, '_', idx) {
+		if idx == len(literal)-1 || !isDigit(literal[idx-1]) || !isDigit(literal[idx+1]) {
+			return l.illegal(literal, fmt.Sprintf("Invalid number '%s': '_' must be between two digits", literal))
+		}
+	}
+
+	return tok
+}
+
+// readRest reads what is left of a malformed number so that it is reported as one token
+func (l *Lexer) readRest(position int) string {
+	l.readWhile(func(char byte) bool {
+		return isLetter(char) || isNumber(char) || char == '.' && isNumber(l.peekChar())
+	})
+
+	return l.input[position:l.currentPosition]
+}
+
+func (l *Lexer) readWhile(accept func(byte) bool) {
+	for accept(l.currentChar) {
+		l.readChar()
+	}
+}
+
+func nextIndex(s string, char byte, after int) int {
+	if idx := strings.IndexByte(s[after+1:], char); idx != -1 {
+		return after + 1 + idx
+	}
+
+	return -1
+}
+
+func isDigitOrUnderscore(char byte) bool {
+	return isNumber(char) || char == '_'
+}
+
+func isHexDigit(char byte) bool {
+	return isNumber(char) || lower(char) >= 'a' && lower(char) <= 'f'
+}
+
+func lower(char byte) byte {
+	if char >= 'A' && char <= 'Z' {
+		return char + 'a' - 'A'
+	}
+
+	return char
+}
@@ -0,0 +1,129 @@
+package lexer
+
+import (
+	"testing"
+
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/token"
+)
+
+// lexAll returns every token of the input up to and without the EOF token
+func lexAll(input string) []token.Token {
+	tokens := []token.Token{}
+	l := lexer.New(input)
+
+	for tok := l.NextToken(); tok.Type != token.EOFILE; tok = l.NextToken() {
+		tokens = append(tokens, tok)
+	}
+
+	return tokens
+}
+
+func TestConformanceKeywords(t *testing.T) {
+	for _, spec := range token.KeywordSpecs() {
+		tokens := lexAll(spec.Literal)
+
+		if len(tokens) != 1 || tokens[0].Type != spec.Type || tokens[0].Literal != spec.Literal {
+			t.Errorf("%q should be one %s token. got=%v", spec.Literal, spec.Type, tokens)
+		}
+
+		// A keyword is only a keyword when it is the whole identifier
+		for _, ident := range []string{spec.Literal + "s", spec.Literal + "_", spec.Literal + "1", "_" + spec.Literal, "x" + spec.Literal} {
+			tokens := lexAll(ident)
+
+			if len(tokens) != 1 || tokens[0].Type != token.IDENT || tokens[0].Literal != ident {
+				t.Errorf("%q should be one IDENT token. got=%v", ident, tokens)
+			}
+		}
+	}
+}
+
+func TestConformanceOperators(t *testing.T) {
+	for _, spec := range token.OperatorSpecs() {
+		tokens := lexAll(spec.Literal)
+
+		if len(tokens) != 1 || tokens[0].Type != spec.Type || tokens[0].Literal != spec.Literal {
+			t.Errorf("%q should be one %s token. got=%v", spec.Literal, spec.Type, tokens)
+		}
+
+		// Operators need no spaces around them
+		tokens = lexAll("a" + spec.Literal + "b")
+
+		if len(tokens) != 3 || tokens[1].Type != spec.Type {
+			t.Errorf("%q should be IDENT %s IDENT. got=%v", "a"+spec.Literal+"b", spec.Type, tokens)
+		}
+	}
+}
+
+func TestConformanceIdentifiersStartingWithKeywords(t *testing.T) {
+	idents := []string{
+		"order", "origin", "android", "andy", "ord", "o", "a", "an", "oregon",
+		"format", "iffy", "inner", "letter", "constant", "falsey", "truth", "nothing",
+		"returned", "forEach", "matches", "skipped", "elsewhere", "funny", "breaker", "duringX",
+		"AND", "Or", "NOT",
+	}
+
+	for _, ident := range idents {
+		tokens := lexAll(ident)
+
+		if len(tokens) != 1 || tokens[0].Type != token.IDENT || tokens[0].Literal != ident {
+			t.Errorf("%q should be one IDENT token. got=%v", ident, tokens)
+		}
+	}
+}
+
+func TestConformanceKeywordBoundaries(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []token.TokenType
+	}{
+		{"a and b", []token.TokenType{token.IDENT, token.AND, token.IDENT}},
+		{"order or origin", []token.TokenType{token.IDENT, token.OR, token.IDENT}},
+		{"andy and android", []token.TokenType{token.IDENT, token.AND, token.IDENT}},
+		{"x and(y)", []token.TokenType{token.IDENT, token.AND, token.LEFTPAR, token.IDENT, token.RIGHTPAR}},
+		{"x or[1]", []token.TokenType{token.IDENT, token.OR, token.LEFTSQPRAC, token.INT, token.RIGHTSQPRAC}},
+		{"not(a)", []token.TokenType{token.NOT, token.LEFTPAR, token.IDENT, token.RIGHTPAR}},
+		{"(a)and(b)", []token.TokenType{token.LEFTPAR, token.IDENT, token.RIGHTPAR, token.AND, token.LEFTPAR, token.IDENT, token.RIGHTPAR}},
+		{"1or 2", []token.TokenType{token.ILLEGAL, token.INT}},
+		{"user.order", []token.TokenType{token.IDENT, token.DOT, token.IDENT}},
+		{"let origin = 1;", []token.TokenType{token.LET, token.IDENT, token.ASSIGN, token.INT, token.SEMICOLON}},
+	}
+
+	for _, tt := range tests {
+		tokens := lexAll(tt.input)
+
+		if len(tokens) != len(tt.expected) {
+			t.Errorf("%q: expected %d tokens, got=%v", tt.input, len(tt.expected), tokens)
+			continue
+		}
+
+		for idx, tok := range tokens {
+			if tok.Type != tt.expected[idx] {
+				t.Errorf("%q: token %d expected=%s, got=%s (%q)", tt.input, idx, tt.expected[idx], tok.Type, tok.Literal)
+			}
+		}
+	}
+}
+
+func TestConformanceKeywordPositions(t *testing.T) {
+	tokens := lexAll("order and\n  origin or not x")
+
+	expected := []struct {
+		literal string
+		line    int
+		column  int
+	}{
+		{"order", 1, 1}, {"and", 1, 7}, {"origin", 2, 3}, {"or", 2, 10}, {"not", 2, 13}, {"x", 2, 17},
+	}
+
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, got=%v", len(expected), tokens)
+	}
+
+	for idx, tok := range tokens {
+		if tok.Literal != expected[idx].literal || tok.Line != expected[idx].line || tok.Column != expected[idx].column {
+			t.Errorf("token %d expected %q at %d:%d, got %q at %d:%d",
+				idx, expected[idx].literal, expected[idx].line, expected[idx].column, tok.Literal, tok.Line, tok.Column)
+		}
+	}
+}
@@ -534,6 +534,126 @@ func TestLexingDictIndexExpression(t *testing.T) {
 	testLexer(t, l, tests)
 }
 
+func TestLexingMemberExpression(t *testing.T) {
+	input := `person.name = 'DE'; 1.5.str(); arr.map(f)`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENT, "person"},
+		{token.DOT, "."},
+		{token.IDENT, "name"},
+		{token.ASSIGN, "="},
+		{token.STRING, "DE"},
+		{token.SEMICOLON, ";"},
+
+		{token.FLOAT, "1.5"},
+		{token.DOT, "."},
+		{token.IDENT, "str"},
+		{token.LEFTPAR, "("},
+		{token.RIGHTPAR, ")"},
+		{token.SEMICOLON, ";"},
+
+		{token.IDENT, "arr"},
+		{token.DOT, "."},
+		{token.IDENT, "map"},
+		{token.LEFTPAR, "("},
+		{token.IDENT, "f"},
+		{token.RIGHTPAR, ")"},
+		{token.EOFILE, ""},
+	}
+
+	l := lexer.New(input)
+
+	testLexer(t, l, tests)
+}
+
+func TestLexingAssignmentOperators(t *testing.T) {
+	input := `x += 1; x -= 2; x *= 3; x /= 4; x %= 5; x++; x--; 1 - -1`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENT, "x"}, {token.PLUS_ASSIGN, "+="}, {token.INT, "1"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "x"}, {token.MINUS_ASSIGN, "-="}, {token.INT, "2"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "x"}, {token.ASTERISK_ASSIGN, "*="}, {token.INT, "3"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "x"}, {token.SLASH_ASSIGN, "/="}, {token.INT, "4"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "x"}, {token.MOD_ASSIGN, "%="}, {token.INT, "5"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "x"}, {token.INCREMENT, "++"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "x"}, {token.DECREMENT, "--"}, {token.SEMICOLON, ";"},
+		{token.INT, "1"}, {token.MINUS, "-"}, {token.MINUS, "-"}, {token.INT, "1"},
+		{token.EOFILE, ""},
+	}
+
+	l := lexer.New(input)
+
+	testLexer(t, l, tests)
+}
+
+func TestLexingEllipsis(t *testing.T) {
+	input := `let [a, ...rest] = arr; x.. .y`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.LET, "let"}, {token.LEFTSQPRAC, "["}, {token.IDENT, "a"}, {token.COMMA, ","},
+		{token.ELLIPSIS, "..."}, {token.IDENT, "rest"}, {token.RIGHTSQPRAC, "]"},
+		{token.ASSIGN, "="}, {token.IDENT, "arr"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "x"}, {token.DOT, "."}, {token.DOT, "."}, {token.DOT, "."}, {token.IDENT, "y"},
+		{token.EOFILE, ""},
+	}
+
+	l := lexer.New(input)
+
+	testLexer(t, l, tests)
+}
+
+func TestLexingMatchExpression(t *testing.T) {
+	input := `match x { 1, [a] => a, n if n >= 2 => n, _ => 0 } a == b =>`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.MATCH, "match"}, {token.IDENT, "x"}, {token.LEFTBRAC, "{"},
+		{token.INT, "1"}, {token.COMMA, ","}, {token.LEFTSQPRAC, "["}, {token.IDENT, "a"}, {token.RIGHTSQPRAC, "]"},
+		{token.ARROW, "=>"}, {token.IDENT, "a"}, {token.COMMA, ","},
+		{token.IDENT, "n"}, {token.IF, "if"}, {token.IDENT, "n"}, {token.GREATERTHANEQ, ">="}, {token.INT, "2"},
+		{token.ARROW, "=>"}, {token.IDENT, "n"}, {token.COMMA, ","},
+		{token.IDENT, "_"}, {token.ARROW, "=>"}, {token.INT, "0"}, {token.RIGHTBRAC, "}"},
+		{token.IDENT, "a"}, {token.EQUAL, "=="}, {token.IDENT, "b"}, {token.ARROW, "=>"},
+		{token.EOFILE, ""},
+	}
+
+	l := lexer.New(input)
+
+	testLexer(t, l, tests)
+}
+
+func TestLexingConditionalAndOptionalOperators(t *testing.T) {
+	input := `a ? b : c; a ?? b; a?.b; a?[0]; a ? [1] : .5`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENT, "a"}, {token.QUESTION, "?"}, {token.IDENT, "b"}, {token.COLON, ":"}, {token.IDENT, "c"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "a"}, {token.NULLISH, "??"}, {token.IDENT, "b"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "a"}, {token.OPTIONAL_DOT, "?."}, {token.IDENT, "b"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "a"}, {token.OPTIONAL_INDEX, "?["}, {token.INT, "0"}, {token.RIGHTSQPRAC, "]"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "a"}, {token.QUESTION, "?"}, {token.LEFTSQPRAC, "["}, {token.INT, "1"}, {token.RIGHTSQPRAC, "]"},
+		{token.COLON, ":"}, {token.DOT, "."}, {token.INT, "5"},
+		{token.EOFILE, ""},
+	}
+
+	l := lexer.New(input)
+
+	testLexer(t, l, tests)
+}
+
 func TestLexingConditionExpression(t *testing.T) {
 	input := `
 		if 5 < 10: {
@@ -767,3 +887,45 @@ func TestLexingDecimal(t *testing.T) {
 
 	testLexer(t, l, tests)
 }
+
+func TestTokenPositions(t *testing.T) {
+	input := "let x = 5; // comment\n  x + 'ab';\n// another comment\nlen(x)"
+
+	tests := []struct {
+		expectedType   token.TokenType
+		expectedLine   int
+		expectedColumn int
+	}{
+		{token.LET, 1, 1},
+		{token.IDENT, 1, 5},
+		{token.ASSIGN, 1, 7},
+		{token.INT, 1, 9},
+		{token.SEMICOLON, 1, 10},
+		{token.IDENT, 2, 3},
+		{token.PLUS, 2, 5},
+		{token.STRING, 2, 7},
+		{token.SEMICOLON, 2, 11},
+		{token.IDENT, 4, 1},
+		{token.LEFTPAR, 4, 4},
+		{token.IDENT, 4, 5},
+		{token.RIGHTPAR, 4, 6},
+		{token.EOFILE, 4, 7},
+	}
+
+	l := lexer.New(input)
+
+	for idx, val := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != val.expectedType {
+			t.Fatalf("Failed at index [%d] - tokenType wrong. expected=%q but got=%q", idx, val.expectedType, tok.Type)
+		}
+
+		if tok.Line != val.expectedLine || tok.Column != val.expectedColumn {
+			t.Fatalf(
+				"Failed at index [%d] - position wrong. expected=%d:%d but got=%d:%d",
+				idx, val.expectedLine, val.expectedColumn, tok.Line, tok.Column,
+			)
+		}
+	}
+}
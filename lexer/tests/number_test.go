@@ -0,0 +1,98 @@
+package lexer
+
+import (
+	"testing"
+
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/token"
+)
+
+func TestLexingNumberLiterals(t *testing.T) {
+	tests := []struct {
+		input        string
+		expectedType token.TokenType
+	}{
+		{"0", token.INT},
+		{"42", token.INT},
+		{"007", token.INT},
+		{"1_000_000", token.INT},
+		{"0xFF", token.INT},
+		{"0XfF_00", token.INT},
+		{"0b1010", token.INT},
+		{"0B1_0", token.INT},
+		{"0o755", token.INT},
+		{"3.14", token.FLOAT},
+		{"1_000.000_1", token.FLOAT},
+		{"6.02e23", token.FLOAT},
+		{"1e9", token.FLOAT},
+		{"1E-9", token.FLOAT},
+		{"2.5e+3", token.FLOAT},
+		{"19.99d", token.DECIMAL},
+		{"5d", token.DECIMAL},
+		{"1.5e3d", token.DECIMAL},
+	}
+
+	for _, tt := range tests {
+		tokens := lexAll(tt.input)
+
+		if len(tokens) != 1 || tokens[0].Type != tt.expectedType || tokens[0].Literal != tt.input {
+			t.Errorf("%q should be one %s token. got=%v", tt.input, tt.expectedType, tokens)
+		}
+	}
+}
+
+func TestLexingNumbersFollowedByMembers(t *testing.T) {
+	input := `1.5.str() 19.99d.str() 0xFF.str() [1..2]`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.FLOAT, "1.5"}, {token.DOT, "."}, {token.IDENT, "str"}, {token.LEFTPAR, "("}, {token.RIGHTPAR, ")"},
+		{token.DECIMAL, "19.99d"}, {token.DOT, "."}, {token.IDENT, "str"}, {token.LEFTPAR, "("}, {token.RIGHTPAR, ")"},
+		{token.INT, "0xFF"}, {token.DOT, "."}, {token.IDENT, "str"}, {token.LEFTPAR, "("}, {token.RIGHTPAR, ")"},
+		{token.LEFTSQPRAC, "["}, {token.INT, "1"}, {token.DOT, "."}, {token.DOT, "."}, {token.INT, "2"}, {token.RIGHTSQPRAC, "]"},
+		{token.EOFILE, ""},
+	}
+
+	testLexer(t, lexer.New(input), tests)
+}
+
+func TestLexingMalformedNumbers(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedLiteral string
+		expectedError   string
+	}{
+		{"1.2.3", "1.2.3", "Invalid number '1.2.3': a number has at most one decimal point"},
+		{"1.2.3.str()", "1.2.3", "Invalid number '1.2.3': a number has at most one decimal point"},
+		{"12px", "12px", "Invalid number '12px': unexpected 'p' after the number"},
+		{"1e", "1e", "Invalid number '1e': expected digits after the exponent"},
+		{"1e+x", "1e+x", "Invalid number '1e+x': expected digits after the exponent"},
+		{"0x", "0x", "Invalid number '0x': expected hexadecimal digits after '0x'"},
+		{"0xG1", "0xG1", "Invalid number '0xG1': 'G' is not a digit of hexadecimal numbers"},
+		{"0b102", "0b102", "Invalid number '0b102': '2' is not a digit of binary numbers"},
+		{"0o78", "0o78", "Invalid number '0o78': '8' is not a digit of octal numbers"},
+		{"1__000", "1__000", "Invalid number '1__000': '_' must be between two digits"},
+		{"1000_", "1000_", "Invalid number '1000_': '_' must be between two digits"},
+		{"1_.5", "1_.5", "Invalid number '1_.5': '_' must be between two digits"},
+		{"0x_FF", "0x_FF", "Invalid number '0x_FF': '_' must be between two digits"},
+		{"5days", "5days", "Invalid number '5days': unexpected 'd' after the number"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		tok := l.NextToken()
+
+		if tok.Type != token.ILLEGAL || tok.Literal != tt.expectedLiteral {
+			t.Errorf("%q should start with the ILLEGAL token %q. got=%s %q", tt.input, tt.expectedLiteral, tok.Type, tok.Literal)
+			continue
+		}
+
+		errors := l.Errors()
+
+		if len(errors) != 1 || errors[0].Msg != tt.expectedError || errors[0].Line != 1 || errors[0].Column != 1 {
+			t.Errorf("%q: expected the error %q at 1:1. got=%v", tt.input, tt.expectedError, errors)
+		}
+	}
+}
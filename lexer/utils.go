@@ -1,5 +1,7 @@
 package lexer
 
+import "github.com/Mostafa-DE/delang/token"
+
 func isLetter(char byte) bool {
 	return char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char == '_'
 }
@@ -10,6 +12,18 @@ func (l *Lexer) skipWhiteSpace() {
 	}
 }
 
+func (l *Lexer) skipWhiteSpaceAndComments() {
+	for {
+		l.skipWhiteSpace()
+
+		if l.currentChar != '/' || l.peekChar() != '/' {
+			return
+		}
+
+		l.skipComment()
+	}
+}
+
 func isNumber(char byte) bool {
 	return char >= '0' && char <= '9'
 }
@@ -23,25 +37,12 @@ func (l *Lexer) readIdentifier() string {
 	return l.input[position:l.currentPosition]
 }
 
-func (l *Lexer) readNumber() string {
-	// In DE we only support `int` and `float` types
-	// For alternative float you can use `decimal` type
-	// Refer to the documentation for more information
-	// https://delang.mostafade.com/play/dataTypes/decimal1
-
-	position := l.currentPosition
-	for isNumber(l.currentChar) {
-		l.readChar()
-	}
-
-	for l.currentChar == '.' || isNumber(l.currentChar) {
-		l.readChar()
+func (l *Lexer) readChar() {
+	if l.currentChar == '\n' {
+		l.line += 1
+		l.column = 0
 	}
 
-	return l.input[position:l.currentPosition]
-}
-
-func (l *Lexer) readChar() {
 	if l.readNextPosition >= len(l.input) {
 		l.currentChar = 0 // 0 in ASCII means NUL wich indicate that we reach the end of file
 	} else {
@@ -50,6 +51,7 @@ func (l *Lexer) readChar() {
 
 	l.currentPosition = l.readNextPosition
 	l.readNextPosition += 1 // Always point to Next index
+	l.column += 1
 }
 
 func (l *Lexer) peekChar() byte {
@@ -74,6 +76,18 @@ func (l *Lexer) peekNChar(NumCharToLook int64) byte {
 	}
 }
 
+// readOperator reads a one character operator or, when the next character allows it, a two character one like `+=`
+func (l *Lexer) readOperator(single token.TokenType, double map[byte]token.TokenType) token.Token {
+	if tokenType, ok := double[l.peekChar()]; ok {
+		first := l.currentChar
+		l.readChar()
+
+		return token.Token{Type: tokenType, Literal: string(first) + string(l.currentChar)}
+	}
+
+	return newToken(single, l.currentChar)
+}
+
 func (l *Lexer) readString() string {
 	// TODO: add support for character escaping
 	position := l.currentPosition + 1
@@ -84,9 +98,16 @@ func (l *Lexer) readString() string {
 		}
 	}
 
+	l.openString = l.currentChar == 0
+
 	return l.input[position:l.currentPosition]
 }
 
+// EndsInString reports whether the input ended before a string was closed, e.g `logs('hello`
+func (l *Lexer) EndsInString() bool {
+	return l.openString
+}
+
 func (l *Lexer) skipComment() {
 	for l.currentChar != '\n' && l.currentChar != 0 {
 		l.readChar()
@@ -0,0 +1,62 @@
+package linter
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Mostafa-DE/delang/ast"
+)
+
+// Rule IDs reported by the linter, they are also the names used to suppress a diagnostic
+// e.g. `// lint:ignore unused-variable`
+const (
+	UNUSED_VARIABLE  = "unused-variable"
+	SHADOW_BUILTIN   = "shadow-builtin"
+	SHADOW_VARIABLE  = "shadow-variable"
+	UNREACHABLE_CODE = "unreachable-code"
+	CONST_ASSIGN     = "const-assign"
+	CONST_REDECLARE  = "const-redeclare"
+)
+
+const (
+	ERROR   = "error"
+	WARNING = "warning"
+)
+
+type Diagnostic struct {
+	Rule     string
+	Severity string
+	Message  string
+	Line     int
+	Column   int
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s (%s)", d.Line, d.Column, d.Severity, d.Message, d.Rule)
+}
+
+// Lint runs every rule over the program and returns the diagnostics sorted by position.
+// The source is needed to honour the `// lint:ignore` comments, the lexer drops comments
+// so they are not part of the AST.
+func Lint(program *ast.Program, source string) []Diagnostic {
+	resolution := Resolve(program)
+	suppressions := parseSuppressions(source)
+
+	diagnostics := []Diagnostic{}
+
+	for _, d := range resolution.diagnostics {
+		if !suppressions.covers(d) {
+			diagnostics = append(diagnostics, d)
+		}
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].Line != diagnostics[j].Line {
+			return diagnostics[i].Line < diagnostics[j].Line
+		}
+
+		return diagnostics[i].Column < diagnostics[j].Column
+	})
+
+	return diagnostics
+}
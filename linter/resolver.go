@@ -0,0 +1,498 @@
+package linter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/object"
+	"github.com/Mostafa-DE/delang/token"
+)
+
+// Binding kinds
+const (
+	LET_BINDING   = "let"
+	CONST_BINDING = "const"
+	PARAM_BINDING = "param"
+	LOOP_BINDING  = "loop"
+	// FUNCTION_BINDING is a function declaration, `fun name() {}`, it is visible in its whole block
+	FUNCTION_BINDING = "fun"
+	// MATCH_BINDING is a name bound by the pattern of a match arm, like parameters it may reuse an outer name
+	MATCH_BINDING = "match"
+)
+
+// Binding is a name declared by a let/const statement, a function parameter or a for loop
+type Binding struct {
+	Name  string
+	Kind  string
+	Ident *ast.Identifier   // The identifier where the name is declared
+	Value ast.Expression    // The initial value of a let/const binding, nil otherwise
+	Uses  []*ast.Identifier // Every identifier that reads the binding
+	Scope *Scope            // The part of the source where the binding is visible
+}
+
+// Scope is the span of source of a block, the outermost scope is the whole program
+type Scope struct {
+	Outer *Scope
+	Start token.Token // The token that opens the scope, zero for the program
+	End   token.Token // The token that closes the scope, zero when it runs to the end of the source
+}
+
+// Contains reports whether a 1-based line and byte column falls between the tokens that open and close the scope
+func (scope *Scope) Contains(line int, column int) bool {
+	if scope.Start.Line != 0 && !isBefore(scope.Start, line, column) {
+		return false
+	}
+
+	return scope.End.Line == 0 || !isBefore(scope.End, line, column)
+}
+
+func (scope *Scope) depth() int {
+	depth := 0
+
+	for outer := scope.Outer; outer != nil; outer = outer.Outer {
+		depth++
+	}
+
+	return depth
+}
+
+// isBefore reports whether the token starts before the given line and column
+func isBefore(tok token.Token, line int, column int) bool {
+	return tok.Line < line || tok.Line == line && tok.Column < column
+}
+
+// Resolution is the result of resolving every identifier of a program to its binding
+type Resolution struct {
+	Bindings []*Binding
+	Uses     map[*ast.Identifier]*Binding
+
+	diagnostics []Diagnostic
+}
+
+// VisibleAt returns the bindings that can be read at a 1-based line and byte column,
+// when nested scopes declare the same name only the innermost binding is returned.
+func (res *Resolution) VisibleAt(line int, column int) []*Binding {
+	visible := []*Binding{}
+	indexes := make(map[string]int)
+
+	for _, binding := range res.Bindings {
+		if !binding.Scope.Contains(line, column) {
+			continue
+		}
+
+		// Only names declared before the position can be read, function declarations are hoisted
+		end := binding.Ident.Token
+		end.Column += len(end.Literal)
+
+		if binding.Kind != FUNCTION_BINDING && !isBefore(end, line, column) {
+			continue
+		}
+
+		idx, ok := indexes[binding.Name]
+
+		if !ok {
+			indexes[binding.Name] = len(visible)
+			visible = append(visible, binding)
+		} else if binding.Scope.depth() >= visible[idx].Scope.depth() {
+			visible[idx] = binding
+		}
+	}
+
+	return visible
+}
+
+type scope struct {
+	outer    *scope
+	span     *Scope
+	bindings map[string]*Binding
+	declared []*Binding
+	// Function bodies are resolved when the scope they are defined in is closed,
+	// a function is only called after the statements that follow it have declared their names.
+	deferred []func()
+	// The body of a loop runs many times in the same environment
+	isLoop bool
+}
+
+type resolver struct {
+	resolution *Resolution
+	current    *scope
+}
+
+// Resolve walks the program following the scoping rules of the evaluator,
+// every `if`, `during`, `for` and function call gets its own environment.
+func Resolve(program *ast.Program) *Resolution {
+	r := &resolver{
+		resolution: &Resolution{Uses: make(map[*ast.Identifier]*Binding)},
+	}
+
+	r.openScope(&Scope{}, false)
+	r.resolveStatements(program.Statements)
+	r.closeScope()
+
+	return r.resolution
+}
+
+// openScope starts a scope that covers the given span of the source
+func (r *resolver) openScope(span *Scope, isLoop bool) {
+	if r.current != nil {
+		span.Outer = r.current.span
+	}
+
+	r.current = &scope{outer: r.current, span: span, bindings: make(map[string]*Binding), isLoop: isLoop}
+}
+
+func (r *resolver) closeScope() {
+	closing := r.current
+
+	for idx := 0; idx < len(closing.deferred); idx++ {
+		closing.deferred[idx]()
+	}
+
+	for _, binding := range closing.declared {
+		if len(binding.Uses) > 0 || binding.Kind == PARAM_BINDING || strings.HasPrefix(binding.Name, "_") {
+			continue
+		}
+
+		r.report(binding.Ident.Token, UNUSED_VARIABLE, WARNING, "'%s' is declared but never used", binding.Name)
+	}
+
+	r.current = closing.outer
+}
+
+func (r *resolver) report(tok token.Token, rule string, severity string, format string, a ...interface{}) {
+	r.resolution.diagnostics = append(r.resolution.diagnostics, Diagnostic{
+		Rule:     rule,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, a...),
+		Line:     tok.Line,
+		Column:   tok.Column,
+	})
+}
+
+func (r *resolver) lookup(name string) *Binding {
+	for s := r.current; s != nil; s = s.outer {
+		if binding, ok := s.bindings[name]; ok {
+			return binding
+		}
+	}
+
+	return nil
+}
+
+func (r *resolver) declare(ident *ast.Identifier, kind string, value ast.Expression) {
+	if ident == nil || ident.Value == "" {
+		return
+	}
+
+	name := ident.Value
+
+	if object.CheckShadowing(name) {
+		r.report(ident.Token, SHADOW_BUILTIN, ERROR, "'%s' shadows a builtin function", name)
+		return
+	}
+
+	if existing, ok := r.current.bindings[name]; ok {
+		switch {
+		case kind == CONST_BINDING:
+			r.report(ident.Token, CONST_REDECLARE, ERROR, "Cannot redeclare constant '%s'", name)
+
+		case existing.Kind == CONST_BINDING:
+			r.report(ident.Token, CONST_ASSIGN, ERROR, "Cannot reassign constant '%s'", name)
+		}
+	} else if kind != PARAM_BINDING && kind != MATCH_BINDING && r.lookup(name) != nil {
+		r.report(ident.Token, SHADOW_VARIABLE, WARNING, "'%s' shadows a variable declared in an outer scope", name)
+	}
+
+	if kind == CONST_BINDING && r.current.isLoop {
+		r.report(ident.Token, CONST_REDECLARE, ERROR, "Constant '%s' is redeclared on every iteration of the loop", name)
+	}
+
+	binding := &Binding{Name: name, Kind: kind, Ident: ident, Value: value, Scope: r.current.span}
+
+	r.current.bindings[name] = binding
+	r.current.declared = append(r.current.declared, binding)
+	r.resolution.Bindings = append(r.resolution.Bindings, binding)
+}
+
+// declarePattern declares every name a destructuring pattern binds, defaults are resolved before the name they belong to
+func (r *resolver) declarePattern(pattern ast.Expression, kind string) {
+	switch pattern := pattern.(type) {
+	case *ast.Identifier:
+		r.declare(pattern, kind, nil)
+
+	case *ast.ArrayPattern:
+		for _, element := range pattern.Elements {
+			r.resolveNode(element.Default)
+			r.declarePattern(element.Target, kind)
+		}
+
+		r.declare(pattern.Rest, kind, nil)
+
+	case *ast.HashPattern:
+		for _, entry := range pattern.Entries {
+			r.resolveNode(entry.Element.Default)
+			r.declarePattern(entry.Element.Target, kind)
+		}
+	}
+}
+
+func (r *resolver) use(ident *ast.Identifier) {
+	binding := r.lookup(ident.Value)
+
+	if binding == nil {
+		return
+	}
+
+	binding.Uses = append(binding.Uses, ident)
+	r.resolution.Uses[ident] = binding
+}
+
+func (r *resolver) resolveStatements(statements []ast.Statement) {
+	reported := false
+
+	// Function declarations are hoisted, so they are declared before the statements of the block are resolved
+	for _, statement := range statements {
+		if declaration, ok := statement.(*ast.FunctionStatement); ok {
+			r.declare(declaration.Function.Name, FUNCTION_BINDING, declaration.Function)
+		}
+	}
+
+	for idx, statement := range statements {
+		if !reported && idx > 0 && terminates(statements[idx-1]) {
+			tok, _ := ast.StatementToken(statement)
+			r.report(tok, UNREACHABLE_CODE, WARNING, "Unreachable code")
+
+			reported = true
+		}
+
+		r.resolveNode(statement)
+	}
+}
+
+func (r *resolver) resolveNode(node ast.Node) {
+	switch node := node.(type) {
+	case *ast.ExpressionStatement:
+		r.resolveNode(node.Expression)
+
+	case *ast.LetStatement:
+		r.resolveNode(node.Value)
+		r.declare(node.Name, LET_BINDING, node.Value)
+		r.declarePattern(node.Pattern, LET_BINDING)
+
+	case *ast.ConstStatement:
+		r.resolveNode(node.Value)
+		r.declare(node.Name, CONST_BINDING, node.Value)
+		r.declarePattern(node.Pattern, CONST_BINDING)
+
+	case *ast.ReturnStatement:
+		r.resolveNode(node.ReturnValue)
+
+	case *ast.BlockStatement:
+		if node != nil {
+			r.resolveStatements(node.Statements)
+		}
+
+	case *ast.Identifier:
+		if node != nil {
+			r.use(node)
+		}
+
+	case *ast.PrefixExpression:
+		r.resolveNode(node.Right)
+
+	case *ast.InfixExpression:
+		r.resolveNode(node.Left)
+		r.resolveNode(node.Right)
+
+	case *ast.IfExpression:
+		span := blockScope(node.Consequence)
+
+		if node.Alternative != nil {
+			span.End = node.Alternative.End
+		}
+
+		r.openScope(span, false)
+		r.resolveNode(node.Condition)
+		r.resolveNode(node.Consequence)
+		r.resolveNode(node.Alternative)
+		r.closeScope()
+
+	case *ast.ConditionalExpression:
+		r.resolveNode(node.Condition)
+		r.resolveNode(node.Consequence)
+		r.resolveNode(node.Alternative)
+
+	case *ast.DuringExpression:
+		r.resolveNode(node.Condition)
+		r.openScope(blockScope(node.Body), true)
+		r.resolveNode(node.Body)
+		r.closeScope()
+
+	case *ast.ForStatement:
+		if node == nil {
+			return
+		}
+
+		r.openScope(blockScope(node.Body), true)
+		r.resolveNode(node.Expression)
+		r.declare(node.IdxIdent, LOOP_BINDING, nil)
+		r.declare(node.VarIdent, LOOP_BINDING, nil)
+		r.declarePattern(node.VarPattern, LOOP_BINDING)
+		r.resolveNode(node.Body)
+		r.closeScope()
+
+	case *ast.MatchExpression:
+		r.resolveNode(node.Value)
+
+		for _, arm := range node.Arms {
+			r.openScope(blockScope(arm.Body), false)
+
+			for _, pattern := range arm.Patterns {
+				for _, ident := range ast.PatternNames(pattern) {
+					if ident.Value != "_" {
+						r.declare(ident, MATCH_BINDING, nil)
+					}
+				}
+			}
+
+			r.resolveNode(arm.Guard)
+			r.resolveNode(arm.Body)
+			r.closeScope()
+		}
+
+	case *ast.Function:
+		r.resolveFunction(node)
+
+	case *ast.FunctionStatement:
+		r.resolveFunction(node.Function)
+
+	case *ast.CallFunction:
+		r.resolveNode(node.Function)
+
+		for _, arg := range node.Arguments {
+			r.resolveNode(arg)
+		}
+
+	case *ast.SpreadArgument:
+		r.resolveNode(node.Value)
+
+	case *ast.KeywordArgument:
+		r.resolveNode(node.Value)
+
+	case *ast.Array:
+		for _, el := range node.Elements {
+			r.resolveNode(el)
+		}
+
+	case *ast.Hash:
+		for key, value := range node.Pairs {
+			r.resolveNode(key)
+			r.resolveNode(value)
+		}
+
+	case *ast.IndexExpression:
+		r.resolveNode(node.Ident)
+		r.resolveNode(node.Index)
+
+	case *ast.MemberExpression:
+		r.resolveNode(node.Object)
+
+	case *ast.AssignExpression:
+		r.resolveNode(node.Value)
+
+		ident, ok := node.Target.(*ast.Identifier)
+
+		if !ok {
+			// Assigning to an index or a member reads the variable that holds the array or the hash
+			r.resolveNode(node.Target)
+			return
+		}
+
+		r.resolveAssignTarget(ident)
+
+		// A compound assignment like `x += 1` reads the variable as well
+		if node.Operator != "=" {
+			r.use(ident)
+		}
+	}
+}
+
+func (r *resolver) resolveFunction(function *ast.Function) {
+	if function.Body == nil {
+		return
+	}
+
+	defining := r.current
+
+	defining.deferred = append(defining.deferred, func() {
+		previous := r.current
+		r.current = defining
+
+		r.openScope(blockScope(function.Body), false)
+
+		for _, param := range function.Parameters {
+			r.resolveNode(param.Default)
+			r.declarePattern(param.Target, PARAM_BINDING)
+		}
+
+		r.resolveNode(function.Body)
+		r.closeScope()
+
+		r.current = previous
+	})
+}
+
+func (r *resolver) resolveAssignTarget(ident *ast.Identifier) {
+	if ident == nil {
+		return
+	}
+
+	binding := r.lookup(ident.Value)
+
+	if binding == nil {
+		return
+	}
+
+	if binding.Kind == CONST_BINDING {
+		r.report(ident.Token, CONST_ASSIGN, ERROR, "Cannot reassign constant '%s'", ident.Value)
+	}
+}
+
+// blockScope returns the span of a block, a block that failed to parse spans the whole source
+func blockScope(block *ast.BlockStatement) *Scope {
+	if block == nil {
+		return &Scope{}
+	}
+
+	return &Scope{Start: block.Token, End: block.End}
+}
+
+// terminates reports whether the statements that follow the given one can never run
+func terminates(statement ast.Statement) bool {
+	switch statement := statement.(type) {
+	case *ast.ReturnStatement, *ast.BreakStatement, *ast.SkipStatement:
+		return true
+
+	case *ast.ExpressionStatement:
+		ifExpression, ok := statement.Expression.(*ast.IfExpression)
+
+		if !ok || ifExpression.Consequence == nil || ifExpression.Alternative == nil {
+			return false
+		}
+
+		return blockTerminates(ifExpression.Consequence) && blockTerminates(ifExpression.Alternative)
+	}
+
+	return false
+}
+
+func blockTerminates(block *ast.BlockStatement) bool {
+	for _, statement := range block.Statements {
+		if terminates(statement) {
+			return true
+		}
+	}
+
+	return false
+}
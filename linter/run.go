@@ -0,0 +1,60 @@
+package linter
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/parser"
+)
+
+// Run is the entry point of `de lint file.de [file.de...]`
+// It exits with status 1 when any file has a syntax error or a diagnostic.
+func Run() {
+	if len(os.Args) < 3 {
+		fmt.Println("Please provide a file to lint")
+		os.Exit(1)
+	}
+
+	failed := false
+
+	for _, filename := range os.Args[2:] {
+		if !lintFile(filename) {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func lintFile(filename string) bool {
+	content, err := os.ReadFile(filename)
+
+	if err != nil {
+		fmt.Println("Error reading file:", err)
+		return false
+	}
+
+	source := string(content)
+
+	p := parser.New(lexer.New(source))
+	program := p.ParseProgram()
+
+	if len(p.Errors()) != 0 {
+		for _, msg := range p.Errors() {
+			fmt.Printf("%s: syntax error: %s\n", filename, msg)
+		}
+
+		return false
+	}
+
+	diagnostics := Lint(program, source)
+
+	for _, d := range diagnostics {
+		fmt.Printf("%s:%s\n", filename, d)
+	}
+
+	return len(diagnostics) == 0
+}
@@ -0,0 +1,112 @@
+package linter
+
+import (
+	"strings"
+)
+
+/*
+	Diagnostics can be silenced with comments:
+
+	- `// lint:ignore rule-a, rule-b` silences the rules on its line, a comment alone on its line
+	  silences them on the line below it instead.
+	- `// lint:file-ignore rule-a` silences the rules in the whole file.
+
+	Leaving the rule list empty silences every rule.
+*/
+
+const (
+	IGNORE_DIRECTIVE      = "lint:ignore"
+	FILE_IGNORE_DIRECTIVE = "lint:file-ignore"
+)
+
+// ALL_RULES stands for every rule when a directive has no rule list
+const ALL_RULES = "*"
+
+type suppressions struct {
+	lines map[int][]string // line => silenced rules
+	file  []string
+}
+
+func parseSuppressions(source string) *suppressions {
+	s := &suppressions{lines: make(map[int][]string)}
+
+	for idx, line := range strings.Split(source, "\n") {
+		comment, ok := findComment(line)
+
+		if !ok {
+			continue
+		}
+
+		comment = strings.TrimSpace(comment)
+
+		switch {
+		case strings.HasPrefix(comment, FILE_IGNORE_DIRECTIVE):
+			s.file = append(s.file, parseRules(comment[len(FILE_IGNORE_DIRECTIVE):])...)
+
+		case strings.HasPrefix(comment, IGNORE_DIRECTIVE):
+			rules := parseRules(comment[len(IGNORE_DIRECTIVE):])
+			lineNumber := idx + 1
+
+			// A comment after code only covers that code, otherwise it covers the line below it
+			if !strings.HasPrefix(strings.TrimSpace(line), "//") {
+				s.lines[lineNumber] = append(s.lines[lineNumber], rules...)
+			} else {
+				s.lines[lineNumber+1] = append(s.lines[lineNumber+1], rules...)
+			}
+		}
+	}
+
+	return s
+}
+
+func (s *suppressions) covers(d Diagnostic) bool {
+	return contains(s.file, d.Rule) || contains(s.lines[d.Line], d.Rule)
+}
+
+// findComment returns the text after `//` if the line has a comment outside of a string
+func findComment(line string) (string, bool) {
+	var quote byte
+
+	for idx := 0; idx < len(line); idx++ {
+		char := line[idx]
+
+		switch {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+
+		case char == '"' || char == '\'':
+			quote = char
+
+		case char == '/' && idx+1 < len(line) && line[idx+1] == '/':
+			return line[idx+2:], true
+		}
+	}
+
+	return "", false
+}
+
+func parseRules(list string) []string {
+	rules := []string{}
+
+	for _, rule := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		rules = append(rules, rule)
+	}
+
+	if len(rules) == 0 {
+		return []string{ALL_RULES}
+	}
+
+	return rules
+}
+
+func contains(rules []string, rule string) bool {
+	for _, r := range rules {
+		if r == rule || r == ALL_RULES {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,259 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/linter"
+	"github.com/Mostafa-DE/delang/parser"
+)
+
+type expectedDiagnostic struct {
+	rule   string
+	line   int
+	column int
+}
+
+func lint(t *testing.T, input string) []linter.Diagnostic {
+	p := parser.New(lexer.New(input))
+	program := p.ParseProgram()
+
+	if len(p.Errors()) != 0 {
+		t.Fatalf("Parser has errors: %v", p.Errors())
+	}
+
+	return linter.Lint(program, input)
+}
+
+func testDiagnostics(t *testing.T, input string, expected []expectedDiagnostic) {
+	diagnostics := lint(t, input)
+
+	if len(diagnostics) != len(expected) {
+		t.Fatalf("Wrong number of diagnostics for %q. expected=%d, got=%d (%v)", input, len(expected), len(diagnostics), diagnostics)
+	}
+
+	for idx, val := range expected {
+		d := diagnostics[idx]
+
+		if d.Rule != val.rule || d.Line != val.line || d.Column != val.column {
+			t.Errorf(
+				"Diagnostic [%d] wrong for %q. expected=%s at %d:%d, got=%s",
+				idx, input, val.rule, val.line, val.column, d,
+			)
+		}
+	}
+}
+
+func TestUnusedVariables(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []expectedDiagnostic
+	}{
+		{"let x = 1;", []expectedDiagnostic{{linter.UNUSED_VARIABLE, 1, 5}}},
+		{"let x = 1; logs(x);", []expectedDiagnostic{}},
+		{"let x = 1; x = 2;", []expectedDiagnostic{{linter.UNUSED_VARIABLE, 1, 5}}},
+		{"let _x = 1;", []expectedDiagnostic{}},
+		{"const x = 1;\nlet y = 2;\nlogs(y);", []expectedDiagnostic{{linter.UNUSED_VARIABLE, 1, 7}}},
+		{"for i, v in [1]: { logs(v); }", []expectedDiagnostic{{linter.UNUSED_VARIABLE, 1, 5}}},
+		{"for _, v in [1]: { logs(v); }", []expectedDiagnostic{}},
+		{"let f = fun(a, b) { return a; }; f(1, 2);", []expectedDiagnostic{}},
+		// Function bodies see the names declared after them
+		{"let f = fun() { return g(); }; let g = fun() { return 1; }; f();", []expectedDiagnostic{}},
+		{"let f = fun(n) { if n == 0: { return 0; }; return f(n - 1); }; f(3);", []expectedDiagnostic{}},
+		{"let h = {name: 1}; let name = 'k'; h[name];", []expectedDiagnostic{}},
+		{"let total = 0; total += 1;", []expectedDiagnostic{}},
+		{"let grid = [[0]]; grid[0][0] = 1;", []expectedDiagnostic{}},
+		{"let p = {}; p.name = 'DE';", []expectedDiagnostic{}},
+		{"let [a, b] = [1, 2]; logs(a);", []expectedDiagnostic{{linter.UNUSED_VARIABLE, 1, 9}}},
+		{"let {name: n, age} = {}; logs(age);", []expectedDiagnostic{{linter.UNUSED_VARIABLE, 1, 12}}},
+		{"for [k, v] in [[1, 2]]: { logs(k); }", []expectedDiagnostic{{linter.UNUSED_VARIABLE, 1, 9}}},
+		{"let f = fun([x, y = x]) { return y; }; f([1]);", []expectedDiagnostic{}},
+		{"let base = 1; let f = fun(x = base, ...rest) { return x; }; f();", []expectedDiagnostic{}},
+		{"let arr = [1]; let f = fun(x) { return x; }; f(...arr); f(x: arr);", []expectedDiagnostic{}},
+		// Function declarations are hoisted to the top of their block
+		{"f(); fun f() { return g(); } fun g() { return 1; }", []expectedDiagnostic{}},
+		{"fun f() { return 1; }", []expectedDiagnostic{{linter.UNUSED_VARIABLE, 1, 5}}},
+		{"if true: { fun f() { return 1; } }; f();", []expectedDiagnostic{{linter.UNUSED_VARIABLE, 1, 16}}},
+		{"let a = 1; let b = 2; logs(true ? a : b);", []expectedDiagnostic{}},
+		{"let h = null; let k = 'a'; logs(h?.name ?? h?[k]);", []expectedDiagnostic{}},
+		{"let v = 1; match v { [a, b] => a, n if n > 0 => n, _ => 0 };", []expectedDiagnostic{{linter.UNUSED_VARIABLE, 1, 26}}},
+	}
+
+	for _, tt := range tests {
+		testDiagnostics(t, tt.input, tt.expected)
+	}
+}
+
+func TestShadowing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []expectedDiagnostic
+	}{
+		{"let len = 1; logs(len);", []expectedDiagnostic{{linter.SHADOW_BUILTIN, 1, 5}}},
+		{"const typeof = 1;", []expectedDiagnostic{{linter.SHADOW_BUILTIN, 1, 7}}},
+		{"let f = fun(str) { return 1; }; f(1);", []expectedDiagnostic{{linter.SHADOW_BUILTIN, 1, 13}}},
+		{"let {len} = {}; logs(1);", []expectedDiagnostic{{linter.SHADOW_BUILTIN, 1, 6}}},
+		{"fun len() { return 1; }", []expectedDiagnostic{{linter.SHADOW_BUILTIN, 1, 5}}},
+		{"let x = 1; if x > 0: { let x = 2; logs(x); }", []expectedDiagnostic{{linter.SHADOW_VARIABLE, 1, 28}}},
+		{"let x = 1; for _, x in [1]: { logs(x); }; logs(x);", []expectedDiagnostic{{linter.SHADOW_VARIABLE, 1, 19}}},
+		// Parameters are allowed to reuse outer names
+		{"let x = 1; let f = fun(x) { return x; }; f(x);", []expectedDiagnostic{}},
+		// So are the names bound by match arms
+		{"let x = 1; match x { x if x > 0 => x, _ => 0 };", []expectedDiagnostic{}},
+		// Redeclaring in the same scope is not shadowing
+		{"let x = 1; let x = x + 1; logs(x);", []expectedDiagnostic{}},
+	}
+
+	for _, tt := range tests {
+		testDiagnostics(t, tt.input, tt.expected)
+	}
+}
+
+func TestUnreachableCode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []expectedDiagnostic
+	}{
+		{"let f = fun() { return 1; logs(2); logs(3); }; f();", []expectedDiagnostic{{linter.UNREACHABLE_CODE, 1, 27}}},
+		{"for _, v in [1]: { break; logs(v); }", []expectedDiagnostic{{linter.UNREACHABLE_CODE, 1, 27}}},
+		{"for _, v in [1]: { skip;\nlogs(v); }", []expectedDiagnostic{{linter.UNREACHABLE_CODE, 2, 1}}},
+		{
+			"let f = fun(x) { if x: { return 1; } else { return 2; }; logs(x); }; f(1);",
+			[]expectedDiagnostic{{linter.UNREACHABLE_CODE, 1, 58}},
+		},
+		{"let f = fun(x) { if x: { return 1; }; return 2; }; f(1);", []expectedDiagnostic{}},
+	}
+
+	for _, tt := range tests {
+		testDiagnostics(t, tt.input, tt.expected)
+	}
+}
+
+func TestConstMisuse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []expectedDiagnostic
+	}{
+		{"const x = 1; x = 2; logs(x);", []expectedDiagnostic{{linter.CONST_ASSIGN, 1, 14}}},
+		{"const x = 1; let f = fun() { x = 2; }; f(); logs(x);", []expectedDiagnostic{{linter.CONST_ASSIGN, 1, 30}}},
+		{"const x = 1; let x = 2; logs(x);", []expectedDiagnostic{{linter.UNUSED_VARIABLE, 1, 7}, {linter.CONST_ASSIGN, 1, 18}}},
+		{"let x = 1; const x = 2; logs(x);", []expectedDiagnostic{{linter.UNUSED_VARIABLE, 1, 5}, {linter.CONST_REDECLARE, 1, 18}}},
+		{"const x = 1; if true: { const x = 2; logs(x); }; logs(x);", []expectedDiagnostic{{linter.SHADOW_VARIABLE, 1, 31}}},
+		{"during true: { const y = 1; logs(y); break; }", []expectedDiagnostic{{linter.CONST_REDECLARE, 1, 22}}},
+		{"const x = 1; x += 2; logs(x);", []expectedDiagnostic{{linter.CONST_ASSIGN, 1, 14}}},
+		{"const x = 1; x++;", []expectedDiagnostic{{linter.CONST_ASSIGN, 1, 14}}},
+		{"const [a, ...rest] = [1]; rest = []; logs(a, rest);", []expectedDiagnostic{{linter.CONST_ASSIGN, 1, 27}}},
+	}
+
+	for _, tt := range tests {
+		testDiagnostics(t, tt.input, tt.expected)
+	}
+}
+
+func TestSuppressionComments(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []expectedDiagnostic
+	}{
+		{"let x = 1; // lint:ignore unused-variable", []expectedDiagnostic{}},
+		{"// lint:ignore unused-variable\nlet x = 1;", []expectedDiagnostic{}},
+		{"let unused = 4; // lint:ignore unused-variable\nlet unused2 = 4;", []expectedDiagnostic{{linter.UNUSED_VARIABLE, 2, 5}}},
+		{"// lint:ignore\nlet len = 1;", []expectedDiagnostic{}},
+		{"let x = 1; // lint:ignore shadow-builtin", []expectedDiagnostic{{linter.UNUSED_VARIABLE, 1, 5}}},
+		{"// lint:file-ignore unused-variable\n\nlet x = 1;\nlet y = 2;", []expectedDiagnostic{}},
+		{"let x = '// lint:ignore';", []expectedDiagnostic{{linter.UNUSED_VARIABLE, 1, 5}}},
+	}
+
+	for _, tt := range tests {
+		testDiagnostics(t, tt.input, tt.expected)
+	}
+}
+
+func TestResolveUses(t *testing.T) {
+	p := parser.New(lexer.New("let x = 1; let f = fun(x) { return x; }; f(x);"))
+	program := p.ParseProgram()
+
+	resolution := linter.Resolve(program)
+
+	if len(resolution.Bindings) != 3 {
+		t.Fatalf("Wrong number of bindings. expected=3, got=%d", len(resolution.Bindings))
+	}
+
+	for ident, binding := range resolution.Uses {
+		if ident.Value != binding.Name {
+			t.Errorf("Identifier %q resolved to binding %q", ident.Value, binding.Name)
+		}
+
+		// The `x` inside the function body is the parameter, the one passed to f(x) is the global
+		if ident.Token.Column == 36 && binding.Kind != linter.PARAM_BINDING {
+			t.Errorf("Identifier at column 36 should resolve to the parameter, got %s", binding.Kind)
+		}
+
+		if ident.Token.Column == 45 && binding.Kind != linter.LET_BINDING {
+			t.Errorf("Identifier at column 45 should resolve to the global, got %s", binding.Kind)
+		}
+	}
+}
+
+func TestResolvePartialPrograms(t *testing.T) {
+	inputs := []string{
+		"let f = fun( { x += 1; }",
+		"let [x, y = x * ] = [4]; y",
+		"x[1 + ] = 5",
+		"fun counter() { let count = 0; return fun( { count++; count }; }",
+		"during true:= { 1; }",
+		"match x { x + 1 =",
+		"if x: { let y = 1; } else",
+		"fun () {",
+	}
+
+	for _, input := range inputs {
+		p := parser.New(lexer.New(input))
+		program := p.ParseProgram()
+
+		if len(p.Errors()) == 0 {
+			t.Errorf("%q: expected parser errors", input)
+		}
+
+		// Editors resolve the document on every keystroke, half-written code must not break it
+		linter.Resolve(program)
+	}
+}
+
+func TestVisibleAt(t *testing.T) {
+	input := "let a = 1;\nfor i in [1]: {\n    let b = i;\n    \n}\nmatch a { n => n, _ => 0 };\nlet a = 2;\n"
+
+	resolution := linter.Resolve(parser.New(lexer.New(input)).ParseProgram())
+
+	tests := []struct {
+		line     int
+		column   int
+		expected []string
+	}{
+		{1, 1, []string{}},
+		{4, 5, []string{"a", "i", "b"}},
+		{5, 2, []string{"a"}},
+		{6, 17, []string{"a", "n"}},
+		{8, 1, []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		names := []string{}
+
+		for _, binding := range resolution.VisibleAt(tt.line, tt.column) {
+			names = append(names, binding.Name)
+		}
+
+		if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("Visible at %d:%d wrong. expected=%v, got=%v", tt.line, tt.column, tt.expected, names)
+		}
+	}
+
+	// The redeclared `a` hides the first one once it is declared
+	visible := resolution.VisibleAt(8, 1)
+
+	if len(visible) != 1 || visible[0].Ident.Token.Line != 7 {
+		t.Errorf("Expected the `a` declared on line 7, got %+v", visible)
+	}
+}
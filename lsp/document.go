@@ -0,0 +1,133 @@
+package lsp
+
+import (
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/linter"
+	"github.com/Mostafa-DE/delang/parser"
+	"github.com/Mostafa-DE/delang/token"
+)
+
+// document is an open file along with the result of parsing and linting it
+type document struct {
+	uri         string
+	text        string
+	lines       []string
+	tokens      []token.Token
+	parseErrors []parser.ParseError
+	resolution  *linter.Resolution
+	diagnostics []linter.Diagnostic
+}
+
+func newDocument(uri string, text string) *document {
+	doc := &document{uri: uri, text: text, lines: strings.Split(text, "\n")}
+
+	l := lexer.New(text)
+
+	for tok := l.NextToken(); tok.Type != token.EOFILE; tok = l.NextToken() {
+		doc.tokens = append(doc.tokens, tok)
+	}
+
+	p := parser.New(lexer.New(text))
+	program := p.ParseProgram()
+
+	doc.parseErrors = p.DetailedErrors()
+	doc.resolution = linter.Resolve(program)
+
+	if len(doc.parseErrors) == 0 {
+		doc.diagnostics = linter.Lint(program, text)
+	}
+
+	return doc
+}
+
+// toPosition converts a 1-based line and byte column to an LSP position
+func (doc *document) toPosition(line int, column int) Position {
+	if line < 1 || line > len(doc.lines) {
+		return Position{Line: max(line-1, 0)}
+	}
+
+	text := doc.lines[line-1]
+	offset := min(max(column-1, 0), len(text))
+
+	return Position{Line: line - 1, Character: len(utf16.Encode([]rune(text[:offset])))}
+}
+
+// fromPosition converts an LSP position to a 1-based line and byte column
+func (doc *document) fromPosition(pos Position) (int, int) {
+	if pos.Line < 0 || pos.Line >= len(doc.lines) {
+		return pos.Line + 1, 1
+	}
+
+	text := doc.lines[pos.Line]
+	units := 0
+	offset := 0
+
+	for offset < len(text) && units < pos.Character {
+		r, size := utf8.DecodeRuneInString(text[offset:])
+		units += len(utf16.Encode([]rune{r}))
+		offset += size
+	}
+
+	return pos.Line + 1, offset + 1
+}
+
+// tokenRange returns the range covered by a token
+func (doc *document) tokenRange(tok token.Token) Range {
+	length := len(tok.Literal)
+
+	if tok.Type == token.STRING {
+		length += 2 // The quotes are not part of the literal
+	}
+
+	return Range{
+		Start: doc.toPosition(tok.Line, tok.Column),
+		End:   doc.toPosition(tok.Line, tok.Column+max(length, 1)),
+	}
+}
+
+// wordRange returns the range of the identifier that starts at the given position,
+// or a single character when there is no identifier there
+func (doc *document) wordRange(line int, column int) Range {
+	for _, tok := range doc.tokens {
+		if tok.Line == line && tok.Column == column {
+			return doc.tokenRange(tok)
+		}
+	}
+
+	return Range{Start: doc.toPosition(line, column), End: doc.toPosition(line, column+1)}
+}
+
+// tokenAt returns the token under the cursor
+func (doc *document) tokenAt(pos Position) (token.Token, bool) {
+	line, column := doc.fromPosition(pos)
+
+	for _, tok := range doc.tokens {
+		// The end is inclusive so that a cursor placed right after a word still points to it
+		if tok.Line == line && column >= tok.Column && column <= tok.Column+len(tok.Literal) {
+			return tok, true
+		}
+	}
+
+	return token.Token{}, false
+}
+
+// bindingAt returns the binding that the identifier under the cursor declares or reads
+func (doc *document) bindingAt(tok token.Token) *linter.Binding {
+	for ident, binding := range doc.resolution.Uses {
+		if ident.Token.Line == tok.Line && ident.Token.Column == tok.Column {
+			return binding
+		}
+	}
+
+	for _, binding := range doc.resolution.Bindings {
+		if binding.Ident.Token.Line == tok.Line && binding.Ident.Token.Column == tok.Column {
+			return binding
+		}
+	}
+
+	return nil
+}
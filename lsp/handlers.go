@@ -0,0 +1,315 @@
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/evaluator"
+	"github.com/Mostafa-DE/delang/formatter"
+	"github.com/Mostafa-DE/delang/linter"
+	"github.com/Mostafa-DE/delang/token"
+)
+
+const SOURCE = "de"
+
+func (s *Server) noop(params json.RawMessage) (interface{}, *ResponseError) {
+	return nil, nil
+}
+
+func (s *Server) initialize(params json.RawMessage) (interface{}, *ResponseError) {
+	s.initialized = true
+
+	return InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync:           TEXT_DOCUMENT_SYNC_FULL,
+			HoverProvider:              true,
+			DefinitionProvider:         true,
+			CompletionProvider:         &CompletionOptions{},
+			DocumentFormattingProvider: true,
+		},
+		ServerInfo: ServerInfo{Name: "de-lsp"},
+	}, nil
+}
+
+func (s *Server) shutdownRequest(params json.RawMessage) (interface{}, *ResponseError) {
+	s.shutdown = true
+
+	return nil, nil
+}
+
+func (s *Server) didOpen(params json.RawMessage) (interface{}, *ResponseError) {
+	var p DidOpenTextDocumentParams
+
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	s.update(p.TextDocument.URI, p.TextDocument.Text)
+
+	return nil, nil
+}
+
+func (s *Server) didChange(params json.RawMessage) (interface{}, *ResponseError) {
+	var p DidChangeTextDocumentParams
+
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if len(p.ContentChanges) == 0 {
+		return nil, nil
+	}
+
+	// The server asks for full document sync so the last change holds the whole text
+	s.update(p.TextDocument.URI, p.ContentChanges[len(p.ContentChanges)-1].Text)
+
+	return nil, nil
+}
+
+func (s *Server) didClose(params json.RawMessage) (interface{}, *ResponseError) {
+	var p DidCloseTextDocumentParams
+
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	delete(s.documents, p.TextDocument.URI)
+
+	// Clear the diagnostics of the closed file
+	s.conn.Notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: p.TextDocument.URI, Diagnostics: []Diagnostic{}})
+
+	return nil, nil
+}
+
+func (s *Server) update(uri string, text string) {
+	doc := newDocument(uri, text)
+	s.documents[uri] = doc
+
+	s.conn.Notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: uri, Diagnostics: diagnostics(doc)})
+}
+
+func diagnostics(doc *document) []Diagnostic {
+	result := []Diagnostic{}
+
+	for _, err := range doc.parseErrors {
+		result = append(result, Diagnostic{
+			Range:    doc.wordRange(err.Line, err.Column),
+			Severity: SEVERITY_ERROR,
+			Source:   SOURCE,
+			Message:  err.Msg,
+		})
+	}
+
+	for _, d := range doc.diagnostics {
+		severity := SEVERITY_WARNING
+
+		if d.Severity == linter.ERROR {
+			severity = SEVERITY_ERROR
+		}
+
+		result = append(result, Diagnostic{
+			Range:    doc.wordRange(d.Line, d.Column),
+			Severity: severity,
+			Code:     d.Rule,
+			Source:   SOURCE,
+			Message:  d.Message,
+		})
+	}
+
+	return result
+}
+
+func (s *Server) document(uri string) (*document, *ResponseError) {
+	doc, ok := s.documents[uri]
+
+	if !ok {
+		return nil, &ResponseError{Code: INVALID_PARAMS, Message: "Document is not open: " + uri}
+	}
+
+	return doc, nil
+}
+
+func (s *Server) hover(params json.RawMessage) (interface{}, *ResponseError) {
+	var p TextDocumentPositionParams
+
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	doc, err := s.document(p.TextDocument.URI)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tok, ok := doc.tokenAt(p.Position)
+
+	if !ok || tok.Type != token.IDENT {
+		return nil, nil
+	}
+
+	tokRange := doc.tokenRange(tok)
+
+	if binding := doc.bindingAt(tok); binding != nil {
+		return Hover{
+			Contents: MarkupContent{Kind: "markdown", Value: "```de\n" + describeBinding(binding) + "\n```"},
+			Range:    &tokRange,
+		}, nil
+	}
+
+	if builtin, ok := evaluator.GetBuiltin(tok.Literal); ok {
+		return Hover{
+			Contents: MarkupContent{
+				Kind:  "markdown",
+				Value: fmt.Sprintf("```de\n%s(...)\n```\nbuiltin function\n\n%s", builtin.Name, builtin.Desc),
+			},
+			Range: &tokRange,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func describeBinding(binding *linter.Binding) string {
+	switch binding.Kind {
+	case linter.PARAM_BINDING:
+		return "(parameter) " + binding.Name
+
+	case linter.LOOP_BINDING:
+		return "(loop variable) " + binding.Name
+
+	case linter.MATCH_BINDING:
+		return "(match variable) " + binding.Name
+
+	case linter.FUNCTION_BINDING:
+		return "fun " + binding.Name + strings.TrimPrefix(functionSignature(binding.Value.(*ast.Function)), "fun")
+	}
+
+	if function, ok := binding.Value.(*ast.Function); ok {
+		return fmt.Sprintf("%s %s = %s", binding.Kind, binding.Name, functionSignature(function))
+	}
+
+	return binding.Kind + " " + binding.Name
+}
+
+func functionSignature(function *ast.Function) string {
+	params := []string{}
+
+	for _, param := range function.Parameters {
+		params = append(params, param.String())
+	}
+
+	return "fun(" + strings.Join(params, ", ") + ")"
+}
+
+func (s *Server) definition(params json.RawMessage) (interface{}, *ResponseError) {
+	var p TextDocumentPositionParams
+
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	doc, err := s.document(p.TextDocument.URI)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tok, ok := doc.tokenAt(p.Position)
+
+	if !ok || tok.Type != token.IDENT {
+		return nil, nil
+	}
+
+	binding := doc.bindingAt(tok)
+
+	if binding == nil {
+		return nil, nil
+	}
+
+	return Location{URI: doc.uri, Range: doc.tokenRange(binding.Ident.Token)}, nil
+}
+
+func (s *Server) completion(params json.RawMessage) (interface{}, *ResponseError) {
+	var p TextDocumentPositionParams
+
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	doc, err := s.document(p.TextDocument.URI)
+
+	if err != nil {
+		return nil, err
+	}
+
+	line, column := doc.fromPosition(p.Position)
+
+	items := []CompletionItem{}
+	seen := make(map[string]bool)
+
+	for _, binding := range doc.resolution.VisibleAt(line, column) {
+		seen[binding.Name] = true
+
+		kind := COMPLETION_VARIABLE
+
+		if binding.Kind == linter.CONST_BINDING {
+			kind = COMPLETION_CONSTANT
+		}
+
+		if _, ok := binding.Value.(*ast.Function); ok {
+			kind = COMPLETION_FUNCTION
+		}
+
+		items = append(items, CompletionItem{Label: binding.Name, Kind: kind, Detail: describeBinding(binding)})
+	}
+
+	for _, name := range evaluator.BuiltinNames() {
+		if seen[name] {
+			continue
+		}
+
+		builtin, _ := evaluator.GetBuiltin(name)
+
+		items = append(items, CompletionItem{
+			Label:         name,
+			Kind:          COMPLETION_FUNCTION,
+			Detail:        "builtin function",
+			Documentation: builtin.Desc,
+		})
+	}
+
+	for _, keyword := range token.Keywords() {
+		items = append(items, CompletionItem{Label: keyword, Kind: COMPLETION_KEYWORD})
+	}
+
+	return items, nil
+}
+
+func (s *Server) formatting(params json.RawMessage) (interface{}, *ResponseError) {
+	var p DocumentFormattingParams
+
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	doc, err := s.document(p.TextDocument.URI)
+
+	if err != nil {
+		return nil, err
+	}
+
+	formatted := formatter.Format(doc.text)
+
+	if formatted == doc.text {
+		return []TextEdit{}, nil
+	}
+
+	// Replace the whole document, the end position is past the last line so it covers everything
+	return []TextEdit{{
+		Range:   Range{Start: Position{Line: 0, Character: 0}, End: Position{Line: len(doc.lines), Character: 0}},
+		NewText: formatted,
+	}}, nil
+}
@@ -0,0 +1,96 @@
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/Mostafa-DE/delang/transport"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	PARSE_ERROR            = -32700
+	INVALID_REQUEST        = -32600
+	METHOD_NOT_FOUND       = -32601
+	INVALID_PARAMS         = -32602
+	SERVER_NOT_INITIALIZED = -32002
+)
+
+// Message is a JSON-RPC request, notification or response.
+// Requests have an ID and a Method, notifications only a Method and responses only an ID.
+type Message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *ResponseError   `json:"error,omitempty"`
+}
+
+type ResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+// Conn is a JSON-RPC connection, it is used by the server and by clients such as the tests
+type Conn struct {
+	conn *transport.Conn
+}
+
+func NewConn(r io.Reader, w io.Writer) *Conn {
+	return &Conn{conn: transport.NewConn(r, w)}
+}
+
+func (c *Conn) Read() (*Message, error) {
+	body, err := c.conn.Read()
+
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &Message{}
+
+	if err := json.Unmarshal(body, msg); err != nil {
+		return nil, &ResponseError{Code: PARSE_ERROR, Message: err.Error()}
+	}
+
+	return msg, nil
+}
+
+func (c *Conn) Notify(method string, params interface{}) error {
+	return c.conn.Write(struct {
+		JSONRPC string      `json:"jsonrpc"`
+		Method  string      `json:"method"`
+		Params  interface{} `json:"params"`
+	}{"2.0", method, params})
+}
+
+func (c *Conn) Request(id int, method string, params interface{}) error {
+	return c.conn.Write(struct {
+		JSONRPC string      `json:"jsonrpc"`
+		ID      int         `json:"id"`
+		Method  string      `json:"method"`
+		Params  interface{} `json:"params,omitempty"`
+	}{"2.0", id, method, params})
+}
+
+func (c *Conn) Reply(id *json.RawMessage, result interface{}) error {
+	return c.conn.Write(struct {
+		JSONRPC string           `json:"jsonrpc"`
+		ID      *json.RawMessage `json:"id"`
+		Result  interface{}      `json:"result"`
+	}{"2.0", id, result})
+}
+
+func (c *Conn) ReplyError(id *json.RawMessage, err *ResponseError) error {
+	return c.conn.Write(struct {
+		JSONRPC string           `json:"jsonrpc"`
+		ID      *json.RawMessage `json:"id"`
+		Error   *ResponseError   `json:"error"`
+	}{"2.0", id, err})
+}
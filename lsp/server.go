@@ -0,0 +1,118 @@
+package lsp
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+)
+
+type Server struct {
+	conn        *Conn
+	documents   map[string]*document
+	initialized bool
+	shutdown    bool
+}
+
+func NewServer(in io.Reader, out io.Writer) *Server {
+	return &Server{conn: NewConn(in, out), documents: make(map[string]*document)}
+}
+
+// Run is the entry point of `de lsp`, the server speaks LSP over stdin and stdout
+func Run() {
+	if err := NewServer(os.Stdin, os.Stdout).Serve(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// Serve handles messages until the client sends `exit` or closes the connection
+func (s *Server) Serve() error {
+	for {
+		msg, err := s.conn.Read()
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			var rpcErr *ResponseError
+
+			if errors.As(err, &rpcErr) {
+				s.conn.ReplyError(nil, rpcErr)
+				continue
+			}
+
+			return err
+		}
+
+		if msg.Method == "exit" {
+			return nil
+		}
+
+		s.handle(msg)
+	}
+}
+
+func (s *Server) handle(msg *Message) {
+	isRequest := msg.ID != nil
+
+	if !s.initialized && msg.Method != "initialize" {
+		if isRequest {
+			s.conn.ReplyError(msg.ID, &ResponseError{Code: SERVER_NOT_INITIALIZED, Message: "Server is not initialized"})
+		}
+
+		return
+	}
+
+	if s.shutdown && isRequest {
+		s.conn.ReplyError(msg.ID, &ResponseError{Code: INVALID_REQUEST, Message: "Server is shutting down"})
+		return
+	}
+
+	handler, ok := handlers[msg.Method]
+
+	if !ok {
+		if isRequest {
+			s.conn.ReplyError(msg.ID, &ResponseError{Code: METHOD_NOT_FOUND, Message: "Method not found: " + msg.Method})
+		}
+
+		return
+	}
+
+	result, err := handler(s, msg.Params)
+
+	if !isRequest {
+		return
+	}
+
+	if err != nil {
+		s.conn.ReplyError(msg.ID, err)
+		return
+	}
+
+	s.conn.Reply(msg.ID, result)
+}
+
+type handlerFunc func(s *Server, params json.RawMessage) (interface{}, *ResponseError)
+
+var handlers = map[string]handlerFunc{
+	"initialize":              (*Server).initialize,
+	"initialized":             (*Server).noop,
+	"shutdown":                (*Server).shutdownRequest,
+	"textDocument/didOpen":    (*Server).didOpen,
+	"textDocument/didChange":  (*Server).didChange,
+	"textDocument/didClose":   (*Server).didClose,
+	"textDocument/didSave":    (*Server).noop,
+	"textDocument/hover":      (*Server).hover,
+	"textDocument/definition": (*Server).definition,
+	"textDocument/completion": (*Server).completion,
+	"textDocument/formatting": (*Server).formatting,
+}
+
+func decodeParams(params json.RawMessage, target interface{}) *ResponseError {
+	if err := json.Unmarshal(params, target); err != nil {
+		return &ResponseError{Code: INVALID_PARAMS, Message: err.Error()}
+	}
+
+	return nil
+}
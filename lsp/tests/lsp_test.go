@@ -0,0 +1,389 @@
+package tests
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mostafa-DE/delang/lsp"
+)
+
+const URI = "file:///test.de"
+
+// client drives a server running in the same process through a pair of pipes
+type client struct {
+	t       *testing.T
+	conn    *lsp.Conn
+	nextID  int
+	pending []*lsp.Message // Notifications received while waiting for a response
+	done    chan error
+}
+
+func newClient(t *testing.T) *client {
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+
+	c := &client{t: t, conn: lsp.NewConn(clientIn, clientOut), done: make(chan error, 1)}
+
+	go func() {
+		c.done <- lsp.NewServer(serverIn, serverOut).Serve()
+		serverOut.Close()
+	}()
+
+	t.Cleanup(func() {
+		c.conn.Notify("exit", nil)
+
+		select {
+		case err := <-c.done:
+			if err != nil {
+				t.Errorf("Serve() returned an error: %v", err)
+			}
+
+		case <-time.After(time.Second):
+			t.Errorf("Server did not stop after exit")
+		}
+	})
+
+	return c
+}
+
+func (c *client) request(method string, params interface{}, result interface{}) *lsp.ResponseError {
+	c.nextID++
+	id := c.nextID
+
+	if err := c.conn.Request(id, method, params); err != nil {
+		c.t.Fatalf("Failed to send %s: %v", method, err)
+	}
+
+	for {
+		msg, err := c.conn.Read()
+
+		if err != nil {
+			c.t.Fatalf("Failed to read the response of %s: %v", method, err)
+		}
+
+		if msg.ID == nil {
+			c.pending = append(c.pending, msg)
+			continue
+		}
+
+		var gotID int
+		json.Unmarshal(*msg.ID, &gotID)
+
+		if gotID != id {
+			c.t.Fatalf("Response id wrong. expected=%d, got=%d", id, gotID)
+		}
+
+		if msg.Error != nil {
+			return msg.Error
+		}
+
+		if result != nil {
+			if err := json.Unmarshal(msg.Result, result); err != nil {
+				c.t.Fatalf("Failed to decode the result of %s: %v", method, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+func (c *client) notify(method string, params interface{}) {
+	if err := c.conn.Notify(method, params); err != nil {
+		c.t.Fatalf("Failed to send %s: %v", method, err)
+	}
+}
+
+// diagnostics waits for the next publishDiagnostics notification
+func (c *client) diagnostics() lsp.PublishDiagnosticsParams {
+	var msg *lsp.Message
+
+	if len(c.pending) > 0 {
+		msg, c.pending = c.pending[0], c.pending[1:]
+	} else {
+		var err error
+		msg, err = c.conn.Read()
+
+		if err != nil {
+			c.t.Fatalf("Failed to read notification: %v", err)
+		}
+	}
+
+	if msg.Method != "textDocument/publishDiagnostics" {
+		c.t.Fatalf("Expected publishDiagnostics, got %q", msg.Method)
+	}
+
+	var params lsp.PublishDiagnosticsParams
+	json.Unmarshal(msg.Params, &params)
+
+	return params
+}
+
+func (c *client) open(text string) lsp.PublishDiagnosticsParams {
+	var result lsp.InitializeResult
+
+	if err := c.request("initialize", map[string]interface{}{}, &result); err != nil {
+		c.t.Fatalf("initialize failed: %v", err)
+	}
+
+	c.notify("initialized", map[string]interface{}{})
+	c.notify("textDocument/didOpen", lsp.DidOpenTextDocumentParams{
+		TextDocument: lsp.TextDocumentItem{URI: URI, Version: 1, Text: text},
+	})
+
+	return c.diagnostics()
+}
+
+func positionParams(line int, character int) lsp.TextDocumentPositionParams {
+	return lsp.TextDocumentPositionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: URI},
+		Position:     lsp.Position{Line: line, Character: character},
+	}
+}
+
+func TestInitialize(t *testing.T) {
+	c := newClient(t)
+
+	if err := c.request("textDocument/hover", positionParams(0, 0), nil); err == nil || err.Code != lsp.SERVER_NOT_INITIALIZED {
+		t.Fatalf("Expected a not initialized error, got %v", err)
+	}
+
+	var result lsp.InitializeResult
+
+	if err := c.request("initialize", map[string]interface{}{}, &result); err != nil {
+		t.Fatalf("initialize failed: %v", err)
+	}
+
+	capabilities := result.Capabilities
+
+	if capabilities.TextDocumentSync != lsp.TEXT_DOCUMENT_SYNC_FULL || !capabilities.HoverProvider ||
+		!capabilities.DefinitionProvider || capabilities.CompletionProvider == nil || !capabilities.DocumentFormattingProvider {
+		t.Errorf("Capabilities wrong. got=%+v", capabilities)
+	}
+
+	if err := c.request("unknown/method", nil, nil); err == nil || err.Code != lsp.METHOD_NOT_FOUND {
+		t.Errorf("Expected a method not found error, got %v", err)
+	}
+
+	if err := c.request("shutdown", nil, nil); err != nil {
+		t.Errorf("shutdown failed: %v", err)
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	c := newClient(t)
+
+	published := c.open("let x = 1;\nlet y = ;")
+
+	if len(published.Diagnostics) == 0 {
+		t.Fatalf("Expected parser diagnostics")
+	}
+
+	if published.Diagnostics[0].Severity != lsp.SEVERITY_ERROR || published.Diagnostics[0].Range.Start.Line != 1 {
+		t.Errorf("Parser diagnostic wrong. got=%+v", published.Diagnostics[0])
+	}
+
+	c.notify("textDocument/didChange", lsp.DidChangeTextDocumentParams{
+		TextDocument:   lsp.TextDocumentIdentifier{URI: URI},
+		ContentChanges: []lsp.TextDocumentContentChangeEvent{{Text: "const x = 1;\nlogs('é'); x = 2;\nlogs(x);"}},
+	})
+
+	published = c.diagnostics()
+
+	if len(published.Diagnostics) != 1 {
+		t.Fatalf("Expected 1 diagnostic, got %+v", published.Diagnostics)
+	}
+
+	d := published.Diagnostics[0]
+	// 'é' takes two bytes but a single UTF-16 code unit
+	expected := lsp.Range{Start: lsp.Position{Line: 1, Character: 11}, End: lsp.Position{Line: 1, Character: 12}}
+
+	if d.Code != "const-assign" || d.Severity != lsp.SEVERITY_ERROR || d.Range != expected {
+		t.Errorf("Linter diagnostic wrong. got=%+v", d)
+	}
+}
+
+func TestHover(t *testing.T) {
+	c := newClient(t)
+
+	c.open("let add = fun(a, b) { return a + b; };\nadd(1, len([1]));")
+
+	tests := []struct {
+		line      int
+		character int
+		contains  string
+	}{
+		{1, 1, "let add = fun(a, b)"},
+		{0, 29, "(parameter) a"},
+		{1, 8, "Returns the length of a string or an array"},
+	}
+
+	for _, tt := range tests {
+		var hover lsp.Hover
+
+		if err := c.request("textDocument/hover", positionParams(tt.line, tt.character), &hover); err != nil {
+			t.Fatalf("hover failed: %v", err)
+		}
+
+		if !strings.Contains(hover.Contents.Value, tt.contains) {
+			t.Errorf("Hover at %d:%d wrong. expected to contain %q, got=%q", tt.line, tt.character, tt.contains, hover.Contents.Value)
+		}
+	}
+
+	var hover *lsp.Hover
+
+	if err := c.request("textDocument/hover", positionParams(1, 4), &hover); err != nil || hover != nil {
+		t.Errorf("Expected no hover over a parenthesis, got %+v (%v)", hover, err)
+	}
+}
+
+func TestDefinition(t *testing.T) {
+	c := newClient(t)
+
+	c.open("let total = 0;\nlet f = fun(n) {\n    return n + total;\n};\nf(1);")
+
+	tests := []struct {
+		line      int
+		character int
+		expected  lsp.Range
+	}{
+		// `total` inside the function body
+		{2, 16, lsp.Range{Start: lsp.Position{Line: 0, Character: 4}, End: lsp.Position{Line: 0, Character: 9}}},
+		// `n` inside the function body goes to the parameter
+		{2, 11, lsp.Range{Start: lsp.Position{Line: 1, Character: 12}, End: lsp.Position{Line: 1, Character: 13}}},
+		// `f` in the call
+		{4, 0, lsp.Range{Start: lsp.Position{Line: 1, Character: 4}, End: lsp.Position{Line: 1, Character: 5}}},
+	}
+
+	for _, tt := range tests {
+		var location lsp.Location
+
+		if err := c.request("textDocument/definition", positionParams(tt.line, tt.character), &location); err != nil {
+			t.Fatalf("definition failed: %v", err)
+		}
+
+		if location.URI != URI || location.Range != tt.expected {
+			t.Errorf("Definition at %d:%d wrong. expected=%+v, got=%+v", tt.line, tt.character, tt.expected, location)
+		}
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	c := newClient(t)
+
+	c.open("let counter = 0;\nconst LIMIT = 10;\n")
+
+	var items []lsp.CompletionItem
+
+	if err := c.request("textDocument/completion", positionParams(2, 0), &items); err != nil {
+		t.Fatalf("completion failed: %v", err)
+	}
+
+	expected := map[string]int{
+		"counter": lsp.COMPLETION_VARIABLE,
+		"LIMIT":   lsp.COMPLETION_CONSTANT,
+		"len":     lsp.COMPLETION_FUNCTION,
+		"during":  lsp.COMPLETION_KEYWORD,
+	}
+
+	for _, item := range items {
+		if kind, ok := expected[item.Label]; ok {
+			if item.Kind != kind {
+				t.Errorf("Completion kind of %q wrong. expected=%d, got=%d", item.Label, kind, item.Kind)
+			}
+
+			delete(expected, item.Label)
+		}
+	}
+
+	if len(expected) != 0 {
+		t.Errorf("Missing completion items: %v", expected)
+	}
+}
+
+func TestCompletionScopes(t *testing.T) {
+	c := newClient(t)
+
+	c.open("let outer = 1;\nlet f = fun(param) {\n    let inner = 2;\n    \n};\nif true: { let branch = 3; }\n\nlet later = 4;\nfun hoisted() { }\n")
+
+	tests := []struct {
+		line      int
+		character int
+		offered   []string
+		hidden    []string
+	}{
+		// Inside the function body, after `inner`
+		{3, 4, []string{"outer", "f", "param", "inner", "hoisted"}, []string{"branch", "later"}},
+		// After the `if`, the names of the function body and of the block are out of scope
+		{6, 0, []string{"outer", "f", "hoisted"}, []string{"param", "inner", "branch", "later"}},
+	}
+
+	for _, tt := range tests {
+		var items []lsp.CompletionItem
+
+		if err := c.request("textDocument/completion", positionParams(tt.line, tt.character), &items); err != nil {
+			t.Fatalf("completion failed: %v", err)
+		}
+
+		labels := make(map[string]bool)
+
+		for _, item := range items {
+			labels[item.Label] = true
+		}
+
+		for _, name := range tt.offered {
+			if !labels[name] {
+				t.Errorf("Completion at %d:%d should offer %q", tt.line, tt.character, name)
+			}
+		}
+
+		for _, name := range tt.hidden {
+			if labels[name] {
+				t.Errorf("Completion at %d:%d should not offer %q", tt.line, tt.character, name)
+			}
+		}
+	}
+}
+
+func TestCompletionPartialDocument(t *testing.T) {
+	c := newClient(t)
+
+	// The document is being typed, the function and the destructuring are not finished yet
+	c.open("let total = 0;\nlet f = fun( { total += 1; }\nlet [x, y = x * ] = [4];\n")
+
+	var items []lsp.CompletionItem
+
+	if err := c.request("textDocument/completion", positionParams(3, 0), &items); err != nil {
+		t.Fatalf("completion failed: %v", err)
+	}
+
+	for _, item := range items {
+		if item.Label == "total" {
+			return
+		}
+	}
+
+	t.Errorf("Completion should offer %q in a document with parse errors", "total")
+}
+
+func TestFormatting(t *testing.T) {
+	c := newClient(t)
+
+	c.open("let f = fun(x) {\nif x: {\nreturn 1;   \n}\n\n\n\nreturn 2;\n};\n")
+
+	var edits []lsp.TextEdit
+
+	params := lsp.DocumentFormattingParams{TextDocument: lsp.TextDocumentIdentifier{URI: URI}}
+
+	if err := c.request("textDocument/formatting", params, &edits); err != nil {
+		t.Fatalf("formatting failed: %v", err)
+	}
+
+	expected := "let f = fun(x) {\n    if x: {\n        return 1;\n    }\n\n    return 2;\n};\n"
+
+	if len(edits) != 1 || edits[0].NewText != expected {
+		t.Fatalf("Formatting wrong. expected=%q, got=%+v", expected, edits)
+	}
+}
@@ -4,13 +4,18 @@ import (
 	"os"
 	"strings"
 
+	"github.com/Mostafa-DE/delang/debugger"
 	"github.com/Mostafa-DE/delang/execFile"
+	"github.com/Mostafa-DE/delang/introspect"
+	"github.com/Mostafa-DE/delang/linter"
+	"github.com/Mostafa-DE/delang/lsp"
 	"github.com/Mostafa-DE/delang/repl"
+	"github.com/Mostafa-DE/delang/server"
 )
 
 func main() {
-	if len(os.Args) == 1 {
-		repl.StartSession()
+	if len(os.Args) == 1 || os.Args[1] == "--quiet" {
+		repl.StartSession(repl.Options{Quiet: len(os.Args) > 1})
 	} else {
 		pathArr := strings.Split(os.Args[1], "/")
 
@@ -20,7 +25,25 @@ func main() {
 			return
 		}
 
-		execFile.Run()
+		switch os.Args[1] {
+		case "lint":
+			linter.Run()
+
+		case "lsp":
+			lsp.Run()
+
+		case "debug":
+			debugger.Run()
+
+		case "inspect":
+			introspect.Run()
+
+		case "serve":
+			server.Run()
+
+		default:
+			execFile.Run()
+		}
 	}
 }
 
@@ -0,0 +1,111 @@
+package object
+
+import (
+	"fmt"
+	"reflect"
+)
+
+/*
+NewBuiltin wraps a plain Go function so scripts can call it, e.g. `func(a int, b string) (float64, error)`.
+The arguments are converted with ToGo and checked against the parameters of the function,
+the result is converted with FromGo. The function can return nothing, a value, an error or a value and an error,
+a non-nil error becomes an Error object. A variadic function accepts any number of trailing arguments.
+*/
+func NewBuiltin(name string, desc string, fn interface{}) (*Builtin, error) {
+	fnValue := reflect.ValueOf(fn)
+
+	// A nil interface has no type, the kind is checked before the type is used
+	if fnValue.Kind() != reflect.Func || fnValue.IsNil() {
+		return nil, fmt.Errorf("builtin `%s` must be a function, got %T", name, fn)
+	}
+
+	fnType := fnValue.Type()
+
+	returnsError := fnType.NumOut() > 0 && fnType.Out(fnType.NumOut()-1) == errorType
+
+	switch {
+	case fnType.NumOut() > 2,
+		fnType.NumOut() == 2 && !returnsError:
+		return nil, fmt.Errorf("builtin `%s` must return at most a value and an error, got %s", name, fnType)
+	}
+
+	builtin := &Builtin{Name: name, Desc: desc}
+
+	builtin.Func = func(args ...Object) (result Object) {
+		// A panic in the host function must not take the interpreter down
+		defer func() {
+			if r := recover(); r != nil {
+				result = throwError("%s panicked: %v", builtinLabel(name), r)
+			}
+		}()
+
+		in, err := builtinArguments(fnType, args)
+
+		if err != nil {
+			return throwError("%s: %s", builtinLabel(name), err)
+		}
+
+		out := fnValue.Call(in)
+
+		if returnsError {
+			if err, _ := out[len(out)-1].Interface().(error); err != nil {
+				return throwError("%s", err)
+			}
+
+			out = out[:len(out)-1]
+		}
+
+		if len(out) == 0 {
+			return &Null{}
+		}
+
+		return FromGo(out[0].Interface())
+	}
+
+	return builtin, nil
+}
+
+func builtinLabel(name string) string {
+	if name == "" {
+		return "builtin function"
+	}
+
+	return fmt.Sprintf("`%s`", name)
+}
+
+// builtinArguments checks the number of arguments and converts each of them to the type of its parameter
+func builtinArguments(fnType reflect.Type, args []Object) ([]reflect.Value, error) {
+	fixed := fnType.NumIn()
+
+	if fnType.IsVariadic() {
+		fixed--
+
+		if len(args) < fixed {
+			return nil, fmt.Errorf("wrong number of arguments. got=%d, want at least %d", len(args), fixed)
+		}
+	} else if len(args) != fixed {
+		return nil, fmt.Errorf("wrong number of arguments. got=%d, want=%d", len(args), fixed)
+	}
+
+	in := make([]reflect.Value, len(args))
+
+	for idx, arg := range args {
+		var paramType reflect.Type
+
+		if idx < fixed {
+			paramType = fnType.In(idx)
+		} else {
+			paramType = fnType.In(fixed).Elem()
+		}
+
+		value := reflect.New(paramType).Elem()
+
+		if err := toValue(arg, value, ""); err != nil {
+			return nil, fmt.Errorf("argument %d: %s", idx+1, err)
+		}
+
+		in[idx] = value
+	}
+
+	return in, nil
+}
@@ -0,0 +1,690 @@
+package object
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+/*
+Conversion between Go values and DE objects for code that embeds the interpreter.
+
+  - bool, the integer types, float32/float64, string, decimal.Decimal and *big.Int map to their DE counterparts.
+  - time.Time is a string in RFC 3339 format.
+  - An error is an Error object.
+  - Slices and arrays are arrays, maps are hashes.
+  - Structs are hashes keyed by field name, the `de` tag renames a field (`de:"name"`),
+    skips it (`de:"-"`) or leaves it out when it is the zero value (`de:"name,omitempty"`).
+  - Pointers and interfaces are followed, nil is null and a value that contains itself is an error.
+  - Functions become builtins, see NewBuiltin.
+*/
+
+const TAG_NAME = "de"
+
+var (
+	objectType  = reflect.TypeOf((*Object)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	decimalType = reflect.TypeOf(decimal.Decimal{})
+	bigIntType  = reflect.TypeOf((*big.Int)(nil))
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// FromGo converts a Go value to a DE object, values that cannot be converted give an Error object
+func FromGo(value interface{}) Object {
+	if value == nil {
+		return &Null{}
+	}
+
+	obj, err := fromValue(reflect.ValueOf(value), make(map[visit]bool))
+
+	if err != nil {
+		return throwError("%s", err)
+	}
+
+	return obj
+}
+
+// fromValue converts value, seen holds the pointers, maps and slices that are being converted to find cycles
+func fromValue(value reflect.Value, seen map[visit]bool) (Object, error) {
+	if !value.IsValid() {
+		return &Null{}, nil
+	}
+
+	if value.Type().Implements(objectType) {
+		if value.Kind() == reflect.Pointer && value.IsNil() {
+			return &Null{}, nil
+		}
+
+		return value.Interface().(Object), nil
+	}
+
+	switch value.Type() {
+	case decimalType:
+		return &Decimal{Value: value.Interface().(decimal.Decimal)}, nil
+
+	case bigIntType:
+		if value.IsNil() {
+			return &Null{}, nil
+		}
+
+		return NewInteger(new(big.Int).Set(value.Interface().(*big.Int))), nil
+
+	case timeType:
+		return &String{Value: value.Interface().(time.Time).Format(time.RFC3339Nano)}, nil
+	}
+
+	if value.Type().Implements(errorType) && value.Kind() != reflect.Struct {
+		// An error can be any named type, `type code int` has no nil value to check
+		switch value.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			if value.IsNil() {
+				return &Null{}, nil
+			}
+		}
+
+		return &Error{Msg: value.Interface().(error).Error()}, nil
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		return &Boolean{Value: value.Bool()}, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Integer{Value: value.Int()}, nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return NewInteger(new(big.Int).SetUint64(value.Uint())), nil
+
+	case reflect.Float32, reflect.Float64:
+		return &Float{Value: value.Float()}, nil
+
+	case reflect.String:
+		return &String{Value: value.String()}, nil
+
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return &Null{}, nil
+		}
+
+		if value.Kind() == reflect.Pointer {
+			leave, err := enterValue(value, seen)
+
+			if err != nil {
+				return nil, err
+			}
+
+			defer leave()
+		}
+
+		return fromValue(value.Elem(), seen)
+
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return &Null{}, nil
+		}
+
+		if value.Kind() == reflect.Slice {
+			leave, err := enterValue(value, seen)
+
+			if err != nil {
+				return nil, err
+			}
+
+			defer leave()
+		}
+
+		elements := make([]Object, value.Len())
+
+		for idx := range elements {
+			element, err := fromValue(value.Index(idx), seen)
+
+			if err != nil {
+				return nil, err
+			}
+
+			elements[idx] = element
+		}
+
+		return &Array{Elements: elements}, nil
+
+	case reflect.Map:
+		if value.IsNil() {
+			return &Null{}, nil
+		}
+
+		leave, err := enterValue(value, seen)
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer leave()
+
+		hash := &Hash{Pairs: make(map[HashKey]HashPair, value.Len())}
+		iter := value.MapRange()
+
+		for iter.Next() {
+			key, err := fromValue(iter.Key(), seen)
+
+			if err != nil {
+				return nil, err
+			}
+
+			hashable, ok := key.(Hashable)
+
+			if !ok {
+				return nil, fmt.Errorf("unusable as hash key: %s", key.Type())
+			}
+
+			val, err := fromValue(iter.Value(), seen)
+
+			if err != nil {
+				return nil, err
+			}
+
+			hash.Pairs[hashable.HashKey()] = HashPair{Key: key, Value: val}
+		}
+
+		return hash, nil
+
+	case reflect.Struct:
+		hash := &Hash{Pairs: make(map[HashKey]HashPair)}
+
+		for _, field := range structFields(value.Type()) {
+			fieldValue := value.Field(field.index)
+
+			if field.omitEmpty && fieldValue.IsZero() {
+				continue
+			}
+
+			val, err := fromValue(fieldValue, seen)
+
+			if err != nil {
+				return nil, err
+			}
+
+			key := &String{Value: field.name}
+			hash.Pairs[key.HashKey()] = HashPair{Key: key, Value: val}
+		}
+
+		return hash, nil
+
+	case reflect.Func:
+		if value.IsNil() {
+			return &Null{}, nil
+		}
+
+		return NewBuiltin("", "", value.Interface())
+	}
+
+	return nil, fmt.Errorf("cannot convert %s to a DE object", value.Type())
+}
+
+// visit is a pointer, map or slice being converted, the type tells a struct from its first field and the length a slice from a shorter one
+type visit struct {
+	ptr    uintptr
+	typ    reflect.Type
+	length int
+}
+
+// enterValue marks value as being converted, meeting it again before leave is called means the value contains itself
+func enterValue(value reflect.Value, seen map[visit]bool) (leave func(), err error) {
+	key := visit{ptr: value.Pointer(), typ: value.Type()}
+
+	if value.Kind() == reflect.Slice {
+		key.length = value.Len()
+	}
+
+	if seen[key] {
+		return nil, fmt.Errorf("cannot convert %s to a DE object, the value contains itself", value.Type())
+	}
+
+	seen[key] = true
+
+	return func() { delete(seen, key) }, nil
+}
+
+type structField struct {
+	name      string
+	index     int
+	omitEmpty bool
+}
+
+// structFields lists the exported fields of a struct with the names given by their `de` tag
+func structFields(structType reflect.Type) []structField {
+	var fields []structField
+
+	for idx := 0; idx < structType.NumField(); idx++ {
+		field := structType.Field(idx)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get(TAG_NAME)
+
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fields = append(fields, structField{name: name, index: idx, omitEmpty: options == "omitempty"})
+	}
+
+	return fields
+}
+
+/*
+ToGo stores a DE object in the value target points to, converting it to the type of that value.
+An interface{} target receives int64 (*big.Int outside its range), float64, decimal.Decimal, string, bool, nil, []interface{},
+map[string]interface{} (map[interface{}]interface{} when a key is not a string) or an error.
+*/
+func ToGo(obj Object, target interface{}) error {
+	value := reflect.ValueOf(target)
+
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return errors.New("ToGo needs a non-nil pointer as target")
+	}
+
+	return toValue(obj, value.Elem(), "")
+}
+
+// conversionError names where in the object the conversion failed, e.g. `cannot convert STRING to int at person.age`
+func conversionError(obj Object, target reflect.Type, path string) error {
+	return fmt.Errorf("cannot convert %s to %s%s", obj.Type(), target, atPath(path))
+}
+
+func toValue(obj Object, target reflect.Value, path string) error {
+	targetType := target.Type()
+
+	// Targets of type Object (or *Hash, ...) take the object as it is, interface{} takes its natural value
+	if reflect.TypeOf(obj).AssignableTo(targetType) && targetType.NumMethod() > 0 || reflect.TypeOf(obj) == targetType {
+		target.Set(reflect.ValueOf(obj))
+		return nil
+	}
+
+	_, isNull := obj.(*Null)
+
+	switch targetType {
+	case decimalType:
+		switch obj := obj.(type) {
+		case *Decimal:
+			target.Set(reflect.ValueOf(obj.Value))
+		case *Integer:
+			target.Set(reflect.ValueOf(decimal.NewFromInt(obj.Value)))
+		case *BigInteger:
+			target.Set(reflect.ValueOf(decimal.NewFromBigInt(obj.Value, 0)))
+		default:
+			return conversionError(obj, targetType, path)
+		}
+
+		return nil
+
+	case bigIntType:
+		switch obj := obj.(type) {
+		case *Integer:
+			target.Set(reflect.ValueOf(big.NewInt(obj.Value)))
+		case *BigInteger:
+			target.Set(reflect.ValueOf(new(big.Int).Set(obj.Value)))
+		case *Null:
+			target.Set(reflect.Zero(targetType))
+		default:
+			return conversionError(obj, targetType, path)
+		}
+
+		return nil
+
+	case timeType:
+		str, ok := obj.(*String)
+
+		if !ok {
+			return conversionError(obj, targetType, path)
+		}
+
+		parsed, err := time.Parse(time.RFC3339Nano, str.Value)
+
+		if err != nil {
+			return fmt.Errorf("cannot convert '%s' to time.Time%s, expected RFC 3339 format", str.Value, atPath(path))
+		}
+
+		target.Set(reflect.ValueOf(parsed))
+		return nil
+
+	case errorType:
+		switch obj := obj.(type) {
+		case *Error:
+			target.Set(reflect.ValueOf(errors.New(obj.Msg)))
+		case *Null:
+			target.Set(reflect.Zero(targetType))
+		default:
+			return conversionError(obj, targetType, path)
+		}
+
+		return nil
+	}
+
+	switch targetType.Kind() {
+	case reflect.Interface:
+		if isNull {
+			target.Set(reflect.Zero(targetType))
+			return nil
+		}
+
+		natural, err := naturalValue(obj, path)
+
+		if err != nil {
+			return err
+		}
+
+		if !reflect.TypeOf(natural).AssignableTo(targetType) {
+			return conversionError(obj, targetType, path)
+		}
+
+		target.Set(reflect.ValueOf(natural))
+		return nil
+
+	case reflect.Pointer:
+		if isNull {
+			target.Set(reflect.Zero(targetType))
+			return nil
+		}
+
+		elem := reflect.New(targetType.Elem())
+
+		if err := toValue(obj, elem.Elem(), path); err != nil {
+			return err
+		}
+
+		target.Set(elem)
+		return nil
+
+	case reflect.Bool:
+		boolean, ok := obj.(*Boolean)
+
+		if !ok {
+			return conversionError(obj, targetType, path)
+		}
+
+		target.SetBool(boolean.Value)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if bigInteger, ok := obj.(*BigInteger); ok {
+			return fmt.Errorf("%s overflows %s%s", bigInteger.Value, targetType, atPath(path))
+		}
+
+		integer, ok := obj.(*Integer)
+
+		if !ok {
+			return conversionError(obj, targetType, path)
+		}
+
+		if target.OverflowInt(integer.Value) {
+			return fmt.Errorf("%d overflows %s%s", integer.Value, targetType, atPath(path))
+		}
+
+		target.SetInt(integer.Value)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if bigInteger, ok := obj.(*BigInteger); ok {
+			if !bigInteger.Value.IsUint64() || target.OverflowUint(bigInteger.Value.Uint64()) {
+				return fmt.Errorf("%s overflows %s%s", bigInteger.Value, targetType, atPath(path))
+			}
+
+			target.SetUint(bigInteger.Value.Uint64())
+			return nil
+		}
+
+		integer, ok := obj.(*Integer)
+
+		if !ok {
+			return conversionError(obj, targetType, path)
+		}
+
+		if integer.Value < 0 || target.OverflowUint(uint64(integer.Value)) {
+			return fmt.Errorf("%d overflows %s%s", integer.Value, targetType, atPath(path))
+		}
+
+		target.SetUint(uint64(integer.Value))
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		switch obj := obj.(type) {
+		case *Float:
+			target.SetFloat(obj.Value)
+		case *Integer:
+			target.SetFloat(float64(obj.Value))
+		case *BigInteger:
+			float, _ := new(big.Float).SetInt(obj.Value).Float64()
+			target.SetFloat(float)
+		case *Decimal:
+			target.SetFloat(obj.Value.InexactFloat64())
+		default:
+			return conversionError(obj, targetType, path)
+		}
+
+		return nil
+
+	case reflect.String:
+		str, ok := obj.(*String)
+
+		if !ok {
+			return conversionError(obj, targetType, path)
+		}
+
+		target.SetString(str.Value)
+		return nil
+
+	case reflect.Slice:
+		if isNull {
+			target.Set(reflect.Zero(targetType))
+			return nil
+		}
+
+		array, ok := obj.(*Array)
+
+		if !ok {
+			return conversionError(obj, targetType, path)
+		}
+
+		slice := reflect.MakeSlice(targetType, len(array.Elements), len(array.Elements))
+
+		for idx, element := range array.Elements {
+			if err := toValue(element, slice.Index(idx), fmt.Sprintf("%s[%d]", path, idx)); err != nil {
+				return err
+			}
+		}
+
+		target.Set(slice)
+		return nil
+
+	case reflect.Array:
+		array, ok := obj.(*Array)
+
+		if !ok {
+			return conversionError(obj, targetType, path)
+		}
+
+		if len(array.Elements) != targetType.Len() {
+			return fmt.Errorf("cannot convert an array of %d elements to %s%s", len(array.Elements), targetType, atPath(path))
+		}
+
+		for idx, element := range array.Elements {
+			if err := toValue(element, target.Index(idx), fmt.Sprintf("%s[%d]", path, idx)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+
+	case reflect.Map:
+		if isNull {
+			target.Set(reflect.Zero(targetType))
+			return nil
+		}
+
+		hash, ok := obj.(*Hash)
+
+		if !ok {
+			return conversionError(obj, targetType, path)
+		}
+
+		result := reflect.MakeMapWithSize(targetType, len(hash.Pairs))
+
+		for _, pair := range hash.Pairs {
+			key := reflect.New(targetType.Key()).Elem()
+
+			if err := toValue(pair.Key, key, path); err != nil {
+				return err
+			}
+
+			value := reflect.New(targetType.Elem()).Elem()
+
+			if err := toValue(pair.Value, value, joinPath(path, pair.Key.Inspect())); err != nil {
+				return err
+			}
+
+			result.SetMapIndex(key, value)
+		}
+
+		target.Set(result)
+		return nil
+
+	case reflect.Struct:
+		hash, ok := obj.(*Hash)
+
+		if !ok {
+			return conversionError(obj, targetType, path)
+		}
+
+		// Keys the struct does not have are ignored, fields the hash does not have keep their value
+		for _, field := range structFields(targetType) {
+			pair, ok := hash.Pairs[(&String{Value: field.name}).HashKey()]
+
+			if !ok {
+				continue
+			}
+
+			if err := toValue(pair.Value, target.Field(field.index), joinPath(path, field.name)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return conversionError(obj, targetType, path)
+}
+
+// naturalValue is the Go value an interface{} target receives
+func naturalValue(obj Object, path string) (interface{}, error) {
+	switch obj := obj.(type) {
+	case *Integer:
+		return obj.Value, nil
+
+	case *BigInteger:
+		return new(big.Int).Set(obj.Value), nil
+
+	case *Float:
+		return obj.Value, nil
+
+	case *Decimal:
+		return obj.Value, nil
+
+	case *String:
+		return obj.Value, nil
+
+	case *Boolean:
+		return obj.Value, nil
+
+	case *Null:
+		return nil, nil
+
+	case *Error:
+		return errors.New(obj.Msg), nil
+
+	case *Array:
+		elements := make([]interface{}, len(obj.Elements))
+
+		for idx, element := range obj.Elements {
+			value, err := naturalValue(element, fmt.Sprintf("%s[%d]", path, idx))
+
+			if err != nil {
+				return nil, err
+			}
+
+			elements[idx] = value
+		}
+
+		return elements, nil
+
+	case *Hash:
+		stringKeys := true
+
+		for _, pair := range obj.Pairs {
+			if _, ok := pair.Key.(*String); !ok {
+				stringKeys = false
+			}
+		}
+
+		if stringKeys {
+			result := make(map[string]interface{}, len(obj.Pairs))
+
+			for _, pair := range obj.Pairs {
+				value, err := naturalValue(pair.Value, joinPath(path, pair.Key.Inspect()))
+
+				if err != nil {
+					return nil, err
+				}
+
+				result[pair.Key.Inspect()] = value
+			}
+
+			return result, nil
+		}
+
+		result := make(map[interface{}]interface{}, len(obj.Pairs))
+
+		for _, pair := range obj.Pairs {
+			key, _ := naturalValue(pair.Key, path)
+			value, err := naturalValue(pair.Value, joinPath(path, pair.Key.Inspect()))
+
+			if err != nil {
+				return nil, err
+			}
+
+			result[key] = value
+		}
+
+		return result, nil
+	}
+
+	return nil, conversionError(obj, reflect.TypeOf((*interface{})(nil)).Elem(), path)
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func atPath(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	return " at " + path
+}
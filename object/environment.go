@@ -1,5 +1,7 @@
 package object
 
+import "sort"
+
 // Environment is a map of strings to Objects that we can use to store and retrieve values
 // from the environment we're currently in (e.g. global or local)
 
@@ -9,6 +11,15 @@ type Environment struct {
 	store       StoreType
 	constValues map[string]struct{}
 	outer       *Environment
+	runtime     *Runtime
+}
+
+// Names that the interpreter stores in the environment for its own use
+var internalNames = map[string]struct{}{
+	"_getDecimalData": {},
+	"bufferLogs":      {},
+	"timeoutLoop":     {},
+	"timeoutExceeded": {},
 }
 
 func NewEnvironment() *Environment {
@@ -16,16 +27,45 @@ func NewEnvironment() *Environment {
 		"_getDecimalData": decimalData(),
 	}
 
-	return &Environment{store: s, outer: nil, constValues: make(map[string]struct{})}
+	return &Environment{store: s, outer: nil, constValues: make(map[string]struct{}), runtime: NewRuntime()}
 }
 
 func NewLocalEnvironment(outer *Environment) *Environment {
 	env := NewEnvironment()
 	env.outer = outer
+	env.runtime = outer.runtime
 
 	return env
 }
 
+// Runtime returns the runtime shared by this environment and all the environments around it
+func (e *Environment) Runtime() *Runtime {
+	return e.runtime
+}
+
+// Names returns the names declared directly in this environment sorted alphabetically,
+// the names the interpreter uses internally are left out
+func (e *Environment) Names() []string {
+	names := []string{}
+
+	for name := range e.store {
+		if _, ok := internalNames[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// IsConst reports whether name is declared as a constant in this environment
+func (e *Environment) IsConst(name string) bool {
+	_, ok := e.constValues[name]
+
+	return ok
+}
+
 func (e *Environment) Get(name string) (Object, bool) {
 	obj, ok := e.store[name]
 
@@ -37,7 +77,7 @@ func (e *Environment) Get(name string) (Object, bool) {
 }
 
 func (e *Environment) Set(name string, val Object, isConst bool) Object {
-	if checkShadowing(name) {
+	if CheckShadowing(name) {
 		return throwError("Shadowing of '%s' is not allowed", name)
 	}
 
@@ -114,7 +154,8 @@ func decimalData() *Hash {
 	}
 }
 
-func checkShadowing(name string) bool {
+// CheckShadowing reports whether name belongs to a builtin that scripts are not allowed to redeclare
+func CheckShadowing(name string) bool {
 	arr := []string{
 		"_getDecimalData",
 		"len",
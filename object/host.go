@@ -0,0 +1,35 @@
+package object
+
+/*
+Host objects are Go values an embedding application hands to scripts, a database row or an HTTP request for example.
+Besides Type and Inspect they can implement any of the interfaces below, the evaluator checks for them
+when the object is indexed, assigned to by index, called or iterated over with `for`.
+*/
+
+// Indexable objects support `obj[key]`, a missing key or an unsupported index should give an Error object
+type Indexable interface {
+	Object
+	Index(key Object) Object
+}
+
+// Settable objects support `obj[key] = value`, the result is an Error object or nil
+type Settable interface {
+	Object
+	SetIndex(key Object, value Object) Object
+}
+
+// Callable objects can be called like functions, `obj(args)`
+type Callable interface {
+	Object
+	Call(args ...Object) Object
+}
+
+/*
+Iterable objects can be iterated over with `for key, value in obj: { ... }`.
+Iterate calls yield for every element and stops as soon as yield returns false,
+which happens when the loop breaks or fails. The result is an Error object or nil.
+*/
+type Iterable interface {
+	Object
+	Iterate(yield func(key Object, value Object) bool) Object
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"hash/fnv"
+	"math/big"
 	"strings"
 
 	"github.com/Mostafa-DE/delang/ast"
@@ -19,6 +20,11 @@ type Integer struct {
 	Value int64
 }
 
+// BigInteger is an integer outside the int64 range, it is still an INTEGER for the language, see NewInteger
+type BigInteger struct {
+	Value *big.Int
+}
+
 type Float struct {
 	Value float64
 }
@@ -56,7 +62,8 @@ type Buffer struct {
 }
 
 type Function struct {
-	Parameters []*ast.Identifier
+	Name       string // The name of a function declaration, empty for anonymous functions
+	Parameters []*ast.Parameter
 	Body       *ast.BlockStatement
 	// The environment in which the function was defined, This allow a closure
 	Env *Environment
@@ -113,6 +120,24 @@ func (integer *Integer) Inspect() string {
 	return fmt.Sprintf("%d", integer.Value)
 }
 
+func (integer *BigInteger) Type() string {
+	return INTEGER_OBJ
+}
+
+func (integer *BigInteger) Inspect() string {
+	return integer.Value.String()
+}
+
+// NewInteger gives an *Integer when value fits in int64 and a *BigInteger otherwise,
+// so a value has one representation and results that shrink back into range become fast again
+func NewInteger(value *big.Int) Object {
+	if value.IsInt64() {
+		return &Integer{Value: value.Int64()}
+	}
+
+	return &BigInteger{Value: value}
+}
+
 func (float *Float) Type() string {
 	return FLOAT_OBJ
 }
@@ -174,6 +199,11 @@ func (function *Function) Inspect() string {
 	}
 
 	out.WriteString("fun")
+
+	if function.Name != "" {
+		out.WriteString(" " + function.Name)
+	}
+
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
 	out.WriteString(") {\n")
@@ -204,18 +234,9 @@ func (array *Array) Type() string {
 }
 
 func (array *Array) Inspect() string {
-	var out bytes.Buffer
-
-	elements := []string{}
-	for _, element := range array.Elements {
-		elements = append(elements, element.Inspect())
-	}
-
-	out.WriteString("[")
-	out.WriteString(strings.Join(elements, ", "))
-	out.WriteString("]")
+	text, _ := InspectLimit(array, 0)
 
-	return out.String()
+	return text
 }
 
 func (buffer *Buffer) Type() string {
@@ -238,18 +259,88 @@ func (hash *Hash) Type() string {
 }
 
 func (hash *Hash) Inspect() string {
-	var out bytes.Buffer
-	pairs := []string{}
+	text, _ := InspectLimit(hash, 0)
+
+	return text
+}
+
+/*
+InspectLimit is Inspect that stops writing once the text is longer than limit bytes, zero means no limit.
+ok is false when the text was cut short, it is then longer than limit.
+An array that holds another one twice on every level prints twice as long on every level,
+`a = [a, a]` repeated 30 times would take minutes to print in full.
+*/
+func InspectLimit(obj Object, limit int) (text string, ok bool) {
+	w := &inspectWriter{limit: limit}
+	w.inspect(obj)
+
+	return w.out.String(), !w.full()
+}
+
+type inspectWriter struct {
+	out   strings.Builder
+	limit int
+}
 
-	for _, pair := range hash.Pairs {
-		pairs = append(pairs, fmt.Sprintf("'%s': '%s'", pair.Key.Inspect(), pair.Value.Inspect()))
+func (w *inspectWriter) full() bool {
+	return w.limit > 0 && w.out.Len() > w.limit
+}
+
+func (w *inspectWriter) write(text string) {
+	if !w.full() {
+		w.out.WriteString(text)
 	}
+}
 
-	out.WriteString("{")
-	out.WriteString(strings.Join(pairs, ", "))
-	out.WriteString("}")
+func (w *inspectWriter) inspect(obj Object) {
+	switch obj := obj.(type) {
+	case *Array:
+		w.write("[")
 
-	return out.String()
+		for idx, element := range obj.Elements {
+			if w.full() {
+				return
+			}
+
+			if idx > 0 {
+				w.write(", ")
+			}
+
+			w.inspect(element)
+		}
+
+		w.write("]")
+
+	case *Hash:
+		w.write("{")
+		idx := 0
+
+		for _, pair := range obj.Pairs {
+			if w.full() {
+				return
+			}
+
+			if idx > 0 {
+				w.write(", ")
+			}
+
+			w.write("'")
+			w.inspect(pair.Key)
+			w.write("': '")
+			w.inspect(pair.Value)
+			w.write("'")
+
+			idx++
+		}
+
+		w.write("}")
+
+	case *Return:
+		w.inspect(obj.Value)
+
+	default:
+		w.write(obj.Inspect())
+	}
 }
 
 func (b *Boolean) HashKey() HashKey {
@@ -268,6 +359,15 @@ func (i *Integer) HashKey() HashKey {
 	return HashKey{Type: i.Type(), Value: uint64(i.Value)}
 }
 
+func (i *BigInteger) HashKey() HashKey {
+	h := fnv.New64a()
+
+	h.Write([]byte(i.Value.String()))
+
+	// A separate key type keeps the hashes of big integers from colliding with the values of small ones
+	return HashKey{Type: "BIG_" + i.Type(), Value: h.Sum64()}
+}
+
 func (str *String) HashKey() HashKey {
 	// TODO: Consider improve the performance by caching the hash key
 	h := fnv.New64a()
@@ -0,0 +1,42 @@
+package object
+
+import (
+	"io"
+	"os"
+
+	"github.com/Mostafa-DE/delang/ast"
+)
+
+// Runtime holds the settings of one interpreter, every environment of a program shares the same runtime
+type Runtime struct {
+	Output io.Writer // Where `logs` writes, os.Stdout by default
+	Input  io.Reader // Where `input` reads, os.Stdin by default
+	Hooks  *Hooks
+	Limits Limits
+}
+
+// Limits bound the values a single operation can build, zero means no limit.
+// A new runtime has none, integers are arbitrary precision, the sandbox sets them for untrusted programs.
+type Limits struct {
+	MaxIntegerBits   int // Integers with more bits are an "integer too large" error
+	MaxStringLength  int // The number of bytes of a string built by `+`, replace, join, str or logs
+	MaxRangeLength   int // The number of elements range builds
+	MaxDecimalDigits int // The number of digits `+`, `-` and `*` write out to compute a decimal
+}
+
+// Hooks let tools such as the debugger observe the evaluation of a program
+type Hooks struct {
+	// BeforeNode runs before a statement or an expression is evaluated,
+	// returning an error stops the evaluation with that error
+	BeforeNode func(node ast.Node, env *Environment) *Error
+
+	// EnterFunction runs when a user function is called, env is the local environment of the call
+	EnterFunction func(function *Function, args []Object, env *Environment)
+
+	// ExitFunction runs when a user function returns
+	ExitFunction func(function *Function, result Object, env *Environment)
+}
+
+func NewRuntime() *Runtime {
+	return &Runtime{Output: os.Stdout, Input: os.Stdin}
+}
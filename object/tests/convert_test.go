@@ -0,0 +1,352 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mostafa-DE/delang/object"
+	"github.com/shopspring/decimal"
+)
+
+// errorCode is an error that is neither a pointer nor a struct, it cannot be nil
+type errorCode int
+
+func (code errorCode) Error() string {
+	return fmt.Sprintf("error code %d", int(code))
+}
+
+type address struct {
+	City string `de:"city"`
+}
+
+type person struct {
+	Name     string             `de:"name"`
+	Age      int                `de:"age"`
+	Balance  decimal.Decimal    `de:"balance"`
+	Born     time.Time          `de:"born"`
+	Tags     []string           `de:"tags"`
+	Scores   map[string]float64 `de:"scores"`
+	Address  *address           `de:"address"`
+	Nickname string             `de:"nickname,omitempty"`
+	Password string             `de:"-"`
+	Untagged bool
+	secret   string
+}
+
+func hashValue(t *testing.T, obj object.Object, key string) object.Object {
+	t.Helper()
+
+	hash, ok := obj.(*object.Hash)
+
+	if !ok {
+		t.Fatalf("expected a hash, got %T (%+v)", obj, obj)
+	}
+
+	pair, ok := hash.Pairs[(&object.String{Value: key}).HashKey()]
+
+	if !ok {
+		return nil
+	}
+
+	return pair.Value
+}
+
+func TestFromGoScalars(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected string
+		objType  string
+	}{
+		{nil, "null", object.NULL_OBJ},
+		{true, "true", object.BOOLEAN_OBJ},
+		{42, "42", object.INTEGER_OBJ},
+		{int8(-3), "-3", object.INTEGER_OBJ},
+		{uint32(7), "7", object.INTEGER_OBJ},
+		{float32(1.5), "1.5", object.FLOAT_OBJ},
+		{2.25, "2.25", object.FLOAT_OBJ},
+		{"hello", "hello", object.STRING_OBJ},
+		{decimal.RequireFromString("19.99"), "19.99", object.DECIMAL_OBJ},
+		{time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC), "2024-05-01T10:30:00Z", object.STRING_OBJ},
+		{errors.New("boom"), "ERROR: boom", object.ERROR_OBJ},
+		{errorCode(3), "ERROR: error code 3", object.ERROR_OBJ},
+		{[]int{1, 2, 3}, "[1, 2, 3]", object.ARRAY_OBJ},
+		{[2]bool{true, false}, "[true, false]", object.ARRAY_OBJ},
+		{(*address)(nil), "null", object.NULL_OBJ},
+		{&object.Integer{Value: 5}, "5", object.INTEGER_OBJ},
+		{uint64(1 << 63), "9223372036854775808", object.INTEGER_OBJ},
+		{new(big.Int).Lsh(big.NewInt(1), 100), "1267650600228229401496703205376", object.INTEGER_OBJ},
+		{make(chan int), "ERROR: cannot convert chan int to a DE object", object.ERROR_OBJ},
+	}
+
+	for _, tt := range tests {
+		obj := object.FromGo(tt.value)
+
+		if obj.Type() != tt.objType || obj.Inspect() != tt.expected {
+			t.Errorf("FromGo(%#v): expected %s %q, got %s %q", tt.value, tt.objType, tt.expected, obj.Type(), obj.Inspect())
+		}
+	}
+}
+
+func TestFromGoStruct(t *testing.T) {
+	p := person{
+		Name:     "Mostafa",
+		Age:      30,
+		Balance:  decimal.RequireFromString("10.50"),
+		Tags:     []string{"admin"},
+		Scores:   map[string]float64{"math": 9.5},
+		Address:  &address{City: "Amman"},
+		Password: "hunter2",
+		Untagged: true,
+		secret:   "hidden",
+	}
+
+	obj := object.FromGo(p)
+
+	if name := hashValue(t, obj, "name"); name == nil || name.Inspect() != "Mostafa" {
+		t.Errorf("expected name to be Mostafa, got %v", name)
+	}
+
+	if age := hashValue(t, obj, "age"); age == nil || age.Inspect() != "30" {
+		t.Errorf("expected age to be 30, got %v", age)
+	}
+
+	if city := hashValue(t, hashValue(t, obj, "address"), "city"); city == nil || city.Inspect() != "Amman" {
+		t.Errorf("expected the nested address to be converted, got %v", city)
+	}
+
+	if math := hashValue(t, hashValue(t, obj, "scores"), "math"); math == nil || math.Inspect() != "9.5" {
+		t.Errorf("expected the scores map to be converted, got %v", math)
+	}
+
+	if untagged := hashValue(t, obj, "Untagged"); untagged == nil || untagged.Inspect() != "true" {
+		t.Errorf("expected a field without tag to keep its name, got %v", untagged)
+	}
+
+	for _, key := range []string{"nickname", "Password", "password", "secret"} {
+		if value := hashValue(t, obj, key); value != nil {
+			t.Errorf("expected %s to be left out, got %v", key, value)
+		}
+	}
+}
+
+type node struct {
+	Name string `de:"name"`
+	Next *node  `de:"next"`
+}
+
+func TestFromGoCycles(t *testing.T) {
+	loop := &node{Name: "a"}
+	loop.Next = loop
+
+	selfMap := map[string]interface{}{}
+	selfMap["self"] = selfMap
+
+	selfSlice := []interface{}{nil}
+	selfSlice[0] = selfSlice
+
+	tests := []struct {
+		value    interface{}
+		expected string
+	}{
+		{loop, "ERROR: cannot convert *tests.node to a DE object, the value contains itself"},
+		{selfMap, "ERROR: cannot convert map[string]interface {} to a DE object, the value contains itself"},
+		{selfSlice, "ERROR: cannot convert []interface {} to a DE object, the value contains itself"},
+	}
+
+	for _, tt := range tests {
+		if obj := object.FromGo(tt.value); obj.Inspect() != tt.expected {
+			t.Errorf("FromGo(%T): expected %q, got %q", tt.value, tt.expected, obj.Inspect())
+		}
+	}
+
+	// The same value twice is not a cycle
+	shared := &node{Name: "shared"}
+	obj := object.FromGo([]*node{shared, shared, {Name: "b", Next: shared}})
+
+	if obj.Type() != object.ARRAY_OBJ || len(obj.(*object.Array).Elements) != 3 {
+		t.Errorf("expected a shared pointer to be converted every time, got %s", obj.Inspect())
+	}
+}
+
+func TestToGoRoundTrip(t *testing.T) {
+	born := time.Date(1994, 2, 3, 4, 5, 6, 0, time.UTC)
+	original := person{
+		Name:     "Mostafa",
+		Age:      30,
+		Balance:  decimal.RequireFromString("10.5"),
+		Born:     born,
+		Tags:     []string{"admin", "dev"},
+		Scores:   map[string]float64{"math": 9.5},
+		Address:  &address{City: "Amman"},
+		Nickname: "de",
+		Untagged: true,
+	}
+
+	var converted person
+
+	if err := object.ToGo(object.FromGo(original), &converted); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !converted.Balance.Equal(original.Balance) {
+		t.Errorf("expected balance %s, got %s", original.Balance, converted.Balance)
+	}
+
+	converted.Balance = original.Balance
+
+	if !reflect.DeepEqual(converted, original) {
+		t.Errorf("expected %+v, got %+v", original, converted)
+	}
+}
+
+func TestToGoInterface(t *testing.T) {
+	obj := object.FromGo(map[string]interface{}{
+		"count": 2,
+		"items": []interface{}{"a", 1.5, nil, true},
+	})
+
+	var value interface{}
+
+	if err := object.ToGo(obj, &value); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"count": int64(2),
+		"items": []interface{}{"a", 1.5, nil, true},
+	}
+
+	if !reflect.DeepEqual(value, expected) {
+		t.Errorf("expected %#v, got %#v", expected, value)
+	}
+
+	var keepObject object.Object
+
+	if err := object.ToGo(obj, &keepObject); err != nil || keepObject != obj {
+		t.Errorf("expected an Object target to receive the object itself, got %v (%v)", keepObject, err)
+	}
+}
+
+func TestToGoBigInteger(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	var asBig *big.Int
+	var asUint uint64
+	var asDecimal decimal.Decimal
+	var asInterface interface{}
+
+	if err := object.ToGo(object.FromGo(huge), &asBig); err != nil || asBig.Cmp(huge) != 0 {
+		t.Errorf("expected a *big.Int target to receive %s, got %v (%v)", huge, asBig, err)
+	}
+
+	if err := object.ToGo(object.FromGo(uint64(1<<64-1)), &asUint); err != nil || asUint != 1<<64-1 {
+		t.Errorf("expected a uint64 target to receive %d, got %d (%v)", uint64(1<<64-1), asUint, err)
+	}
+
+	if err := object.ToGo(object.FromGo(huge), &asDecimal); err != nil || asDecimal.String() != huge.String() {
+		t.Errorf("expected a decimal target to receive %s, got %s (%v)", huge, asDecimal, err)
+	}
+
+	if err := object.ToGo(object.FromGo(huge), &asInterface); err != nil || asInterface.(*big.Int).Cmp(huge) != 0 {
+		t.Errorf("expected an interface{} target to receive a *big.Int, got %#v (%v)", asInterface, err)
+	}
+
+	if obj := object.FromGo(big.NewInt(42)); obj.Inspect() != "42" {
+		t.Errorf("expected a small *big.Int to become the integer 42, got %T %q", obj, obj.Inspect())
+	}
+}
+
+func TestToGoErrors(t *testing.T) {
+	hash := object.FromGo(map[string]interface{}{"name": "DE", "age": "thirty", "tags": []interface{}{"a", 2}})
+
+	tests := []struct {
+		obj      object.Object
+		target   interface{}
+		expected string
+	}{
+		{&object.String{Value: "1"}, new(int), "cannot convert STRING to int"},
+		{&object.Integer{Value: 300}, new(int8), "300 overflows int8"},
+		{&object.Integer{Value: -1}, new(uint), "-1 overflows uint"},
+		{object.FromGo(uint64(1 << 63)), new(int64), "9223372036854775808 overflows int64"},
+		{&object.Float{Value: 1.5}, new(int), "cannot convert FLOAT to int"},
+		{&object.String{Value: "yesterday"}, new(time.Time), "cannot convert 'yesterday' to time.Time, expected RFC 3339 format"},
+		{hash, new(person), "cannot convert STRING to int at age"},
+		{hash, new(struct {
+			Tags []string `de:"tags"`
+		}), "cannot convert INTEGER to string at tags[1]"},
+		{object.FromGo([]int{1}), new([2]int), "cannot convert an array of 1 elements to [2]int"},
+		{&object.Integer{Value: 1}, 5, "ToGo needs a non-nil pointer as target"},
+	}
+
+	for _, tt := range tests {
+		err := object.ToGo(tt.obj, tt.target)
+
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("expected error %q, got %v", tt.expected, err)
+		}
+	}
+}
+
+func TestNewBuiltin(t *testing.T) {
+	divide, err := object.NewBuiltin("divide", "Divides two numbers", func(a int, b int) (float64, error) {
+		if b == 0 {
+			return 0, errors.New("division by zero")
+		}
+
+		return float64(a) / float64(b), nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	join, _ := object.NewBuiltin("join", "", func(sep string, parts ...string) string {
+		return strings.Join(parts, sep)
+	})
+
+	explode, _ := object.NewBuiltin("explode", "", func() { panic("kaboom") })
+
+	tests := []struct {
+		builtin  *object.Builtin
+		args     []object.Object
+		expected string
+	}{
+		{divide, []object.Object{object.FromGo(7), object.FromGo(2)}, "3.5"},
+		{divide, []object.Object{object.FromGo(7), object.FromGo(0)}, "ERROR: division by zero"},
+		{divide, []object.Object{object.FromGo(7)}, "ERROR: `divide`: wrong number of arguments. got=1, want=2"},
+		{divide, []object.Object{object.FromGo(7), object.FromGo("2")}, "ERROR: `divide`: argument 2: cannot convert STRING to int"},
+		{join, []object.Object{object.FromGo("-"), object.FromGo("a"), object.FromGo("b")}, "a-b"},
+		{join, []object.Object{object.FromGo("-")}, ""},
+		{join, []object.Object{}, "ERROR: `join`: wrong number of arguments. got=0, want at least 1"},
+		{explode, nil, "ERROR: `explode` panicked: kaboom"},
+	}
+
+	for _, tt := range tests {
+		if result := tt.builtin.Func(tt.args...); result.Inspect() != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.builtin.Name, tt.expected, result.Inspect())
+		}
+	}
+
+	if _, err := object.NewBuiltin("bad", "", 42); err == nil {
+		t.Errorf("expected an error for a value that is not a function")
+	}
+
+	if _, err := object.NewBuiltin("bad", "", nil); err == nil || err.Error() != "builtin `bad` must be a function, got <nil>" {
+		t.Errorf("expected an error for nil, got %v", err)
+	}
+
+	var nilFunc func()
+
+	if _, err := object.NewBuiltin("bad", "", nilFunc); err == nil {
+		t.Errorf("expected an error for a nil function")
+	}
+
+	if _, err := object.NewBuiltin("bad", "", func() (int, string) { return 0, "" }); err == nil {
+		t.Errorf("expected an error for a function returning two values that are not a value and an error")
+	}
+}
@@ -0,0 +1,42 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Mostafa-DE/delang/object"
+)
+
+func TestInspectLimit(t *testing.T) {
+	array := &object.Array{Elements: []object.Object{&object.Integer{Value: 1}, &object.String{Value: "two"}}}
+
+	tests := []struct {
+		value    object.Object
+		limit    int
+		expected string
+		ok       bool
+	}{
+		{array, 0, "[1, two]", true},
+		{array, 8, "[1, two]", true},
+		{array, 4, "[1, two", false},
+		{&object.Array{Elements: []object.Object{array, array}}, 0, "[[1, two], [1, two]]", true},
+	}
+
+	for _, tt := range tests {
+		text, ok := object.InspectLimit(tt.value, tt.limit)
+
+		if text != tt.expected || ok != tt.ok {
+			t.Errorf("InspectLimit(%s, %d) wrong. expected=(%q, %t), got=(%q, %t)", tt.value.Inspect(), tt.limit, tt.expected, tt.ok, text, ok)
+		}
+	}
+
+	// Every level holds the one below twice, printing it in full would take 2^40 elements
+	nested := object.Object(&object.Integer{Value: 1})
+
+	for i := 0; i < 40; i++ {
+		nested = &object.Array{Elements: []object.Object{nested, nested}}
+	}
+
+	if text, ok := object.InspectLimit(nested, 1000); ok || len(text) > 1100 {
+		t.Errorf("Expected the text to be cut short after 1000 bytes, got %d bytes (%t)", len(text), ok)
+	}
+}
@@ -14,9 +14,15 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 		return nil
 	}
 
+	errorCount := len(p.errors)
 	leftExp := prefixParseFunc()
 
-	for !p.peekTokenTypeIs(token.SEMICOLON) && precedence < p.peekPrecedence() {
+	// The operand already reported why it failed, an operator after it would wrap a nil or half-built operand
+	if leftExp == nil || len(p.errors) > errorCount {
+		return leftExp
+	}
+
+	for !p.peekTokenTypeIs(token.SEMICOLON) && precedence < p.peekPrecedence() && len(p.errors) == errorCount {
 		infixParseFunc := p.infixParseFuns[p.peekToken.Type]
 		if infixParseFunc == nil {
 			return leftExp
@@ -51,14 +57,18 @@ func initRegisterPrefix(p *Parser) {
 		{token.IDENT, p.parseIdentifier},
 		{token.INT, p.parseInteger},
 		{token.FLOAT, p.parseFloat},
+		{token.DECIMAL, p.parseDecimal},
+		{token.ILLEGAL, p.parseIllegal},
 		{token.EXCLAMATION, p.parsePrefixExpression},
 		{token.MINUS, p.parsePrefixExpression},
+		{token.NOT, p.parsePrefixExpression},
 		{token.TRUE, p.parseBoolean},
 		{token.FALSE, p.parseBoolean},
 		{token.LEFTPAR, p.parseGroupedExpression},
 		{token.RIGHTPAR, p.parseGroupedExpression},
 		{token.IF, p.parseIfExpression},
 		{token.DURING, p.parseDuringExpression},
+		{token.MATCH, p.parseMatchExpression},
 		{token.FUNCTION, p.parseFunction},
 		{token.STRING, p.parseStringLiteral},
 		{token.LEFTSQPRAC, p.parseArray},
@@ -88,6 +98,19 @@ func initRegisterInfix(p *Parser) {
 		{token.GREATERTHANEQ, p.parseInfixExpression},
 		{token.LEFTPAR, p.parseCallFunction},
 		{token.LEFTSQPRAC, p.parseIndexExpression},
+		{token.DOT, p.parseMemberExpression},
+		{token.OPTIONAL_DOT, p.parseMemberExpression},
+		{token.OPTIONAL_INDEX, p.parseIndexExpression},
+		{token.QUESTION, p.parseConditionalExpression},
+		{token.NULLISH, p.parseInfixExpression},
+		{token.ASSIGN, p.parseAssignExpression},
+		{token.PLUS_ASSIGN, p.parseAssignExpression},
+		{token.MINUS_ASSIGN, p.parseAssignExpression},
+		{token.ASTERISK_ASSIGN, p.parseAssignExpression},
+		{token.SLASH_ASSIGN, p.parseAssignExpression},
+		{token.MOD_ASSIGN, p.parseAssignExpression},
+		{token.INCREMENT, p.parseAssignExpression},
+		{token.DECREMENT, p.parseAssignExpression},
 		{token.AND, p.parseInfixExpression},
 		{token.OR, p.parseInfixExpression},
 	}
@@ -133,6 +156,19 @@ func (p *Parser) parseStatement() ast.Statement {
 	case token.FOR:
 		return p.parseForStatement()
 
+	case token.FUNCTION:
+		// `fun name() {}` declares a function, `fun() {}` is a function expression
+		if p.peekTokenTypeIs(token.IDENT) {
+			// A nil *ast.FunctionStatement is not a nil ast.Statement, the declaration that failed is left out
+			if statement := p.parseFunctionStatement(); statement != nil {
+				return statement
+			}
+
+			return nil
+		}
+
+		return p.parseExpressionStatement()
+
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -145,7 +181,9 @@ func (p *Parser) ParseProgram() *ast.Program {
 	for !p.currentTokenTypeIs(token.EOFILE) {
 		statement := p.parseStatement()
 
-		program.Statements = append(program.Statements, statement)
+		if statement != nil {
+			program.Statements = append(program.Statements, statement)
+		}
 
 		p.nextToken()
 	}
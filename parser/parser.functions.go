@@ -1,23 +1,40 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/Mostafa-DE/delang/ast"
 	"github.com/Mostafa-DE/delang/token"
+	"github.com/shopspring/decimal"
 )
 
 func (p *Parser) parseVariableStatement(statementType string) *ast.VariableStatement {
 	statement := &ast.VariableStatement{Token: p.currentToken, Type: statementType}
 
-	if !p.expectPeekType(token.IDENT) {
-		p.errors = append(p.errors, fmt.Sprintf("Expected identifier after '%s'", statementType))
+	if p.peekTokenTypeIs(token.LEFTSQPRAC) || p.peekTokenTypeIs(token.LEFTBRAC) {
+		p.nextToken()
+
+		statement.Pattern = p.parsePattern()
+
+		if statement.Pattern == nil {
+			return &ast.VariableStatement{}
+		}
+
+		if !p.peekTokenTypeIs(token.ASSIGN) {
+			p.addError(p.peekToken, fmt.Sprintf("Expected '=' after the pattern of '%s', a pattern needs a value to destructure", statementType))
+			return &ast.VariableStatement{}
+		}
+	} else if !p.expectPeekType(token.IDENT) {
+		p.addError(p.peekToken, fmt.Sprintf("Expected identifier after '%s'", statementType))
 		return &ast.VariableStatement{}
+	} else {
+		statement.Name = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
 	}
 
-	statement.Name = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
-
 	if !p.peekTokenTypeIs(token.ASSIGN) {
 		if p.peekTokenTypeIs(token.SEMICOLON) {
 			p.nextToken()
@@ -43,6 +60,7 @@ func (p *Parser) parseLetStatement() *ast.LetStatement {
 	letStatement := p.parseVariableStatement("let")
 
 	statement.Name = letStatement.Name
+	statement.Pattern = letStatement.Pattern
 	statement.Value = letStatement.Value
 
 	return statement
@@ -54,6 +72,7 @@ func (p *Parser) parseConstStatement() *ast.ConstStatement {
 	constStatement := p.parseVariableStatement("const")
 
 	statement.Name = constStatement.Name
+	statement.Pattern = constStatement.Pattern
 	statement.Value = constStatement.Value
 
 	return statement
@@ -92,9 +111,16 @@ func (p *Parser) parsePrefixExpression() ast.Expression {
 		Operator: p.currentToken.Literal,
 	}
 
+	precedence := PREFIX
+
+	// `not a == b` is `not (a == b)` and `not a and b` is `(not a) and b`
+	if expression.Token.Type == token.NOT {
+		precedence = AND_OR
+	}
+
 	p.nextToken() // Move to the next token
 
-	expression.Right = p.parseExpression(PREFIX)
+	expression.Right = p.parseExpression(precedence)
 
 	return expression
 }
@@ -111,18 +137,15 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	p.nextToken()
 	expression.Right = p.parseExpression(precedence)
 
-	return expression
-}
-
-func (p *Parser) parseIdentifier() ast.Expression {
-	if p.peekTokenTypeIs(token.ASSIGN) {
-		return p.parseAssignExpression(p.parseIdentifierExpression())
+	// The right operand already reported why it failed
+	if expression.Right == nil {
+		return nil
 	}
 
-	return p.parseIdentifierExpression()
+	return expression
 }
 
-func (p *Parser) parseIdentifierExpression() ast.Expression {
+func (p *Parser) parseIdentifier() ast.Expression {
 	return &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
 }
 
@@ -130,10 +153,19 @@ func (p *Parser) parseInteger() ast.Expression {
 	// defer untrace(trace("parseInteger"))
 	literal := &ast.Integer{Token: p.currentToken}
 
-	value, err := strconv.ParseInt(p.currentToken.Literal, 0, 64)
+	text := numberText(p.currentToken.Literal)
+
+	value, err := strconv.ParseInt(text, 0, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		if bigValue, ok := new(big.Int).SetString(text, 0); ok {
+			literal.Big = bigValue
+			return literal
+		}
+	}
+
 	if err != nil {
 		msg := fmt.Sprintf("Could not parse %q as integer", p.currentToken.Literal)
-		p.errors = append(p.errors, msg)
+		p.addError(p.currentToken, msg)
 		return &ast.Integer{}
 	}
 
@@ -144,10 +176,10 @@ func (p *Parser) parseInteger() ast.Expression {
 func (p *Parser) parseFloat() ast.Expression {
 	literal := &ast.Float{Token: p.currentToken}
 
-	value, err := strconv.ParseFloat(p.currentToken.Literal, 64)
+	value, err := strconv.ParseFloat(numberText(p.currentToken.Literal), 64)
 
 	if err != nil {
-		p.errors = append(p.errors, err.Error())
+		p.addError(p.currentToken, fmt.Sprintf("The float %s is out of range", p.currentToken.Literal))
 		return &ast.Float{}
 	}
 
@@ -156,6 +188,35 @@ func (p *Parser) parseFloat() ast.Expression {
 	return literal
 }
 
+func (p *Parser) parseDecimal() ast.Expression {
+	literal := &ast.Decimal{Token: p.currentToken}
+
+	value, err := decimal.NewFromString(numberText(strings.TrimSuffix(p.currentToken.Literal, "d")))
+
+	if err != nil {
+		p.addError(p.currentToken, fmt.Sprintf("Could not parse %q as decimal", p.currentToken.Literal))
+		return &ast.Decimal{}
+	}
+
+	literal.Value = value
+
+	return literal
+}
+
+// parseIllegal reports an ILLEGAL token with the reason the lexer gave for it, e.g a malformed number
+func (p *Parser) parseIllegal() ast.Expression {
+	for _, err := range p.lexerInstance.Errors() {
+		if err.Line == p.currentToken.Line && err.Column == p.currentToken.Column {
+			p.addError(p.currentToken, err.Msg)
+			return nil
+		}
+	}
+
+	p.noPrefixParseFnError(p.currentToken.Type)
+
+	return nil
+}
+
 func (p *Parser) parseBoolean() ast.Expression {
 	// defer untrace(trace("parseBoolean"))
 	return &ast.Boolean{Token: p.currentToken, Value: p.currentTokenTypeIs(token.TRUE)}
@@ -168,7 +229,7 @@ func (p *Parser) parseGroupedExpression() ast.Expression {
 	expression := p.parseExpression(LOWEST)
 
 	if !p.expectPeekType(token.RIGHTPAR) {
-		p.errors = append(p.errors, "Grouped expression is not closed with ')'")
+		p.addError(p.peekToken, "Grouped expression is not closed with ')'")
 		return nil
 	}
 
@@ -183,12 +244,12 @@ func (p *Parser) parseIfExpression() ast.Expression {
 	expression.Condition = p.parseExpression(LOWEST)
 
 	if !p.expectPeekType(token.COLON) {
-		p.errors = append(p.errors, "Expected ':' after if condition")
+		p.addError(p.peekToken, "Expected ':' after if condition")
 		return &ast.IfExpression{}
 	}
 
 	if !p.expectPeekType(token.LEFTBRAC) {
-		p.errors = append(p.errors, "Expected '{' after if condition")
+		p.addError(p.peekToken, "Expected '{' after if condition")
 		return &ast.IfExpression{}
 	}
 
@@ -198,7 +259,7 @@ func (p *Parser) parseIfExpression() ast.Expression {
 		p.nextToken()
 
 		if !p.expectPeekType(token.LEFTBRAC) {
-			p.errors = append(p.errors, "Expected '{' after else")
+			p.addError(p.peekToken, "Expected '{' after else")
 			return &ast.IfExpression{}
 		}
 
@@ -217,16 +278,23 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 
 	for !p.currentTokenTypeIs(token.RIGHTBRAC) && !p.currentTokenTypeIs(token.EOFILE) {
 		if p.currentTokenTypeIs(token.ELSE) {
-			p.errors = append(p.errors, "Unexpected 'else' statement, if block is not closed with '}'")
+			p.addError(p.currentToken, "Unexpected 'else' statement, if block is not closed with '}'")
 			return block
 		}
 
 		statement := p.parseStatement()
-		block.Statements = append(block.Statements, statement)
+
+		if statement != nil {
+			block.Statements = append(block.Statements, statement)
+		}
 
 		p.nextToken()
 	}
 
+	if p.currentTokenTypeIs(token.RIGHTBRAC) {
+		block.End = p.currentToken
+	}
+
 	return block
 }
 
@@ -235,62 +303,126 @@ func (p *Parser) parseFunction() ast.Expression {
 	function := &ast.Function{Token: p.currentToken}
 
 	if !p.expectPeekType(token.LEFTPAR) {
-		p.errors = append(p.errors, "Function is not started with '('")
-		return &ast.Function{}
+		p.addError(p.peekToken, "Function is not started with '('")
+		return nil
 	}
 
 	function.Parameters = p.parseFunctionParameters()
 
 	if !p.currentTokenTypeIs(token.RIGHTPAR) {
-		p.errors = append(p.errors, "Function is not closed with ')'")
-		return &ast.Function{}
+		p.addError(p.currentToken, "Function is not closed with ')'")
+		return nil
 	}
 
 	if !p.expectPeekType(token.LEFTBRAC) {
-		p.errors = append(p.errors, "Function is not started with '{'")
-		return &ast.Function{}
+		p.addError(p.peekToken, "Function is not started with '{'")
+		return nil
 	}
 
 	function.Body = p.parseBlockStatement()
 
 	if !p.currentTokenTypeIs(token.RIGHTBRAC) {
-		p.errors = append(p.errors, "Function is not closed with '}'")
-		return &ast.Function{}
+		p.addError(p.currentToken, "Function is not closed with '}'")
+		return nil
 	}
 
 	return function
 }
 
-func (p *Parser) parseFunctionParameters() []*ast.Identifier {
+func (p *Parser) parseFunctionStatement() *ast.FunctionStatement {
+	// defer untrace(trace("parseFunctionStatement"))
+	statement := &ast.FunctionStatement{Token: p.currentToken}
+
+	p.nextToken()
+	name := &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	function, ok := p.parseFunction().(*ast.Function)
+
+	if !ok || function.Body == nil {
+		return nil
+	}
+
+	function.Token = statement.Token
+	function.Name = name
+	statement.Function = function
+
+	if p.peekTokenTypeIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return statement
+}
+
+func (p *Parser) parseFunctionParameters() []*ast.Parameter {
 	// defer untrace(trace("parseFunctionParameters"))
-	identifiers := []*ast.Identifier{}
+	parameters := []*ast.Parameter{}
 
 	if p.peekTokenTypeIs(token.RIGHTPAR) {
 		// No parameters
 		p.nextToken()
-		return identifiers
+		return parameters
 	}
 
 	p.nextToken()
 
-	identifier := &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
-	identifiers = append(identifiers, identifier)
+	parameter := p.parseFunctionParameter()
+
+	if parameter == nil {
+		return nil
+	}
+
+	parameters = append(parameters, parameter)
 
 	for p.peekTokenTypeIs(token.COMMA) {
+		if parameter.Rest {
+			p.addError(p.peekToken, fmt.Sprintf("The rest parameter '%s' must be the last parameter", parameter.Target))
+			return nil
+		}
+
 		// Skip the comma
 		p.nextToken()
 		p.nextToken()
 
-		// Parse the identifier
-		identifier := &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
-		identifiers = append(identifiers, identifier)
+		parameter = p.parseFunctionParameter()
+
+		if parameter == nil {
+			return nil
+		}
+
+		parameters = append(parameters, parameter)
 	}
 
 	if !p.expectPeekType(token.RIGHTPAR) {
 		return nil
 	}
 
-	return identifiers
+	return parameters
+}
+
+/*
+parseFunctionParameter parses one parameter at the current token:
+
+- A name or a pattern that destructures the argument, e.g `x` or `[x, y]`.
+- Either of them followed by a default value, e.g `y = 10`.
+- A rest parameter, e.g `...others`.
+*/
+func (p *Parser) parseFunctionParameter() *ast.Parameter {
+	if p.currentTokenTypeIs(token.ELLIPSIS) {
+		if !p.expectPeekType(token.IDENT) {
+			p.addError(p.peekToken, "Expected a name after '...'")
+			return nil
+		}
+
+		return &ast.Parameter{Target: &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}, Rest: true}
+	}
+
+	element := p.parsePatternElement()
+
+	if element == nil {
+		return nil
+	}
+
+	return &ast.Parameter{Target: element.Target, Default: element.Default}
 }
 
 func (p *Parser) parseCallFunction(function ast.Expression) ast.Expression {
@@ -312,7 +444,7 @@ func (p *Parser) parseCallArguments() []ast.Expression {
 	}
 
 	p.nextToken()
-	arguments = append(arguments, p.parseExpression(LOWEST))
+	arguments = append(arguments, p.parseCallArgument())
 
 	for p.peekTokenTypeIs(token.COMMA) {
 		// Skip the comma
@@ -320,17 +452,49 @@ func (p *Parser) parseCallArguments() []ast.Expression {
 		p.nextToken()
 
 		// Parse the argument
-		arguments = append(arguments, p.parseExpression(LOWEST))
+		argument := p.parseCallArgument()
+
+		if _, ok := argument.(*ast.KeywordArgument); !ok && isKeywordArgument(arguments[len(arguments)-1]) {
+			p.addError(p.currentToken, "Positional arguments cannot follow keyword arguments")
+			return nil
+		}
+
+		arguments = append(arguments, argument)
 	}
 
 	if !p.expectPeekType(token.RIGHTPAR) {
-		p.errors = append(p.errors, "Function call is not closed with ')'")
+		p.addError(p.peekToken, "Function call is not closed with ')'")
 		return nil
 	}
 
 	return arguments
 }
 
+// parseCallArgument parses one argument at the current token, a value, a spread `...arr` or a keyword argument `name: value`
+func (p *Parser) parseCallArgument() ast.Expression {
+	if p.currentTokenTypeIs(token.ELLIPSIS) {
+		spread := &ast.SpreadArgument{Token: p.currentToken}
+
+		p.nextToken()
+		spread.Value = p.parseExpression(LOWEST)
+
+		return spread
+	}
+
+	if p.currentTokenTypeIs(token.IDENT) && p.peekTokenTypeIs(token.COLON) {
+		keyword := &ast.KeywordArgument{Token: p.currentToken}
+		keyword.Name = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+		p.nextToken() // Skip the ':'
+		p.nextToken()
+		keyword.Value = p.parseExpression(LOWEST)
+
+		return keyword
+	}
+
+	return p.parseExpression(LOWEST)
+}
+
 func (p *Parser) parseStringLiteral() ast.Expression {
 	// defer untrace(trace("parseStringLiteral"))
 	return &ast.StringLiteral{Token: p.currentToken, Value: p.currentToken.Literal}
@@ -369,7 +533,7 @@ func (p *Parser) parseArrayElements() []ast.Expression {
 	}
 
 	if !p.expectPeekType(token.RIGHTSQPRAC) {
-		p.errors = append(p.errors, "Array is not closed with ']'")
+		p.addError(p.peekToken, "Array is not closed with ']'")
 		return nil
 	}
 
@@ -378,7 +542,7 @@ func (p *Parser) parseArrayElements() []ast.Expression {
 
 func (p *Parser) parseIndexExpression(Ident ast.Expression) ast.Expression {
 	// defer untrace(trace("parseIndexExpression"))
-	expression := &ast.IndexExpression{Token: p.currentToken, Ident: Ident}
+	expression := &ast.IndexExpression{Token: p.currentToken, Ident: Ident, Optional: p.currentTokenTypeIs(token.OPTIONAL_INDEX)}
 
 	p.nextToken()
 
@@ -389,16 +553,42 @@ func (p *Parser) parseIndexExpression(Ident ast.Expression) ast.Expression {
 	}
 
 	if !p.expectPeekType(token.RIGHTSQPRAC) {
-		p.errors = append(p.errors, "Index expression is not closed with ']'")
+		p.addError(p.peekToken, "Index expression is not closed with ']'")
 		return nil
 	}
 
-	if p.peekTokenTypeIs(token.ASSIGN) {
-		p.nextToken() // Skip the '='
-		p.nextToken()
-		expression.Value = p.parseExpression(LOWEST)
+	return expression
+}
+
+func (p *Parser) parseMemberExpression(object ast.Expression) ast.Expression {
+	expression := &ast.MemberExpression{Token: p.currentToken, Object: object, Optional: p.currentTokenTypeIs(token.OPTIONAL_DOT)}
+
+	if !p.expectPeekType(token.IDENT) {
+		p.addError(p.peekToken, fmt.Sprintf("Expected a member name after '%s'", expression.Token.Literal))
+		return nil
 	}
 
+	expression.Member = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	return expression
+}
+
+func (p *Parser) parseConditionalExpression(condition ast.Expression) ast.Expression {
+	expression := &ast.ConditionalExpression{Token: p.currentToken, Condition: condition}
+
+	p.nextToken()
+	expression.Consequence = p.parseExpression(LOWEST)
+
+	if !p.expectPeekType(token.COLON) {
+		p.addError(p.peekToken, "Expected ':' after the consequence of '?'")
+		return nil
+	}
+
+	p.nextToken()
+
+	// CONDITIONAL - 1 so that `a ? b : c ? d : e` groups from right to left
+	expression.Alternative = p.parseExpression(CONDITIONAL - 1)
+
 	return expression
 }
 
@@ -412,7 +602,7 @@ func (p *Parser) parseHash() ast.Expression {
 		key := p.parseExpression(LOWEST)
 
 		if !p.expectPeekType(token.COLON) {
-			p.errors = append(p.errors, "Hash key is not followed by ':'")
+			p.addError(p.peekToken, "Hash key is not followed by ':'")
 			return &ast.Hash{}
 		}
 
@@ -422,35 +612,48 @@ func (p *Parser) parseHash() ast.Expression {
 		hash.Pairs[key] = value
 
 		if !p.peekTokenTypeIs(token.RIGHTBRAC) && !p.expectPeekType(token.COMMA) {
-			p.errors = append(p.errors, "Hash is not closed with '}'")
+			p.addError(p.peekToken, "Hash is not closed with '}'")
 			return &ast.Hash{}
 		}
 	}
 
 	if !p.expectPeekType(token.RIGHTBRAC) {
-		p.errors = append(p.errors, "Hash is not closed with '}'")
+		p.addError(p.peekToken, "Hash is not closed with '}'")
 		return &ast.Hash{}
 	}
 
 	return hash
 }
 
-func (p *Parser) parseAssignExpression(ident ast.Expression) ast.Expression {
+func (p *Parser) parseAssignExpression(target ast.Expression) ast.Expression {
 	// defer untrace(trace("parseAssignExpression"))
+	expression := &ast.AssignExpression{Token: p.currentToken, Target: target, Operator: p.currentToken.Literal}
 
-	ident, _ = ident.(*ast.Identifier)
-
-	p.nextToken()
+	if target == nil || !ast.IsAssignable(target) {
+		p.addError(p.currentToken, fmt.Sprintf("Cannot assign to '%s', expected a variable, an index or a member", targetText(target)))
+		return nil
+	}
 
-	expression := &ast.AssignExpression{Token: p.currentToken, Ident: ident.(*ast.Identifier)}
+	if p.currentTokenTypeIs(token.INCREMENT) || p.currentTokenTypeIs(token.DECREMENT) {
+		return expression
+	}
 
 	p.nextToken()
 
+	// LOWEST and not ASSIGN so that `a = b = 1` assigns from right to left
 	expression.Value = p.parseExpression(LOWEST)
 
 	return expression
 }
 
+func targetText(target ast.Expression) string {
+	if target == nil {
+		return ""
+	}
+
+	return target.String()
+}
+
 func (p *Parser) parseDuringExpression() ast.Expression {
 	// defer untrace(trace("parseDuringExpression"))
 	expression := &ast.DuringExpression{Token: p.currentToken}
@@ -459,12 +662,12 @@ func (p *Parser) parseDuringExpression() ast.Expression {
 	expression.Condition = p.parseExpression(LOWEST)
 
 	if !p.expectPeekType(token.COLON) {
-		p.errors = append(p.errors, "Expected ':' after (during) condition")
+		p.addError(p.peekToken, "Expected ':' after (during) condition")
 		return &ast.DuringExpression{}
 	}
 
 	if !p.expectPeekType(token.LEFTBRAC) {
-		p.errors = append(p.errors, "Expected '{' after (during) condition")
+		p.addError(p.peekToken, "Expected '{' after (during) condition")
 		return &ast.DuringExpression{}
 	}
 
@@ -560,16 +763,26 @@ func (p *Parser) parseForStatement() *ast.ForStatement {
 
 	*/
 	p.nextToken()
-	if p.currentTokenTypeIs(token.IDENT) && p.currentToken.Literal != "_" {
+	if p.currentTokenTypeIs(token.LEFTSQPRAC) || p.currentTokenTypeIs(token.LEFTBRAC) {
+		fs.IdxIdent = nil
+
+		if !p.parseLoopVariable(fs) {
+			return nil
+		}
+
+	} else if p.currentTokenTypeIs(token.IDENT) && p.currentToken.Literal != "_" {
 		if p.peekTokenTypeIs(token.COMMA) {
 			fs.IdxIdent = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
 			p.nextToken()
 			p.nextToken()
 			if p.currentTokenTypeIs(token.IDENT) && p.currentToken.Literal == "_" {
-				p.errors = append(p.errors, "Cannot use underscore as a variable identifier in for statement")
+				p.addError(p.currentToken, "Cannot use underscore as a variable identifier in for statement")
+				return nil
+			}
+
+			if !p.parseLoopVariable(fs) {
 				return nil
 			}
-			fs.VarIdent = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
 		} else {
 			fs.IdxIdent = nil
 			fs.VarIdent = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
@@ -577,7 +790,7 @@ func (p *Parser) parseForStatement() *ast.ForStatement {
 
 	} else if p.currentTokenTypeIs(token.IDENT) && p.currentToken.Literal == "_" {
 		if !p.peekTokenTypeIs(token.COMMA) {
-			p.errors = append(p.errors, "Expected a comma after underscore")
+			p.addError(p.currentToken, "Expected a comma after underscore")
 			return nil
 		}
 
@@ -585,25 +798,28 @@ func (p *Parser) parseForStatement() *ast.ForStatement {
 		p.nextToken()
 
 		if p.currentTokenTypeIs(token.IDENT) && p.currentToken.Literal == "_" {
-			p.errors = append(p.errors, "Cannot use two underscores in for statement")
+			p.addError(p.currentToken, "Cannot use two underscores in for statement")
 			return nil
 		}
 
 		if p.currentTokenTypeIs(token.IN) {
-			p.errors = append(p.errors, "Expected an identifier after underscore")
+			p.addError(p.currentToken, "Expected an identifier after underscore")
 			return nil
 		}
 
 		fs.IdxIdent = nil
-		fs.VarIdent = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+		if !p.parseLoopVariable(fs) {
+			return nil
+		}
 
 	} else {
-		p.errors = append(p.errors, "Expected an identifier or underscore after for statement")
+		p.addError(p.currentToken, "Expected an identifier or underscore after for statement")
 		return nil
 	}
 
 	if !p.expectPeekType(token.IN) {
-		p.errors = append(p.errors, "Expected an in keyword after variable identifier")
+		p.addError(p.peekToken, "Expected an in keyword after variable identifier")
 		return nil
 	}
 
@@ -612,12 +828,12 @@ func (p *Parser) parseForStatement() *ast.ForStatement {
 	fs.Expression = p.parseExpression(LOWEST)
 
 	if !p.expectPeekType(token.COLON) {
-		p.errors = append(p.errors, "Expected a colon after array")
+		p.addError(p.peekToken, "Expected a colon after array")
 		return nil
 	}
 
 	if !p.expectPeekType(token.LEFTBRAC) {
-		p.errors = append(p.errors, "Expected a block statement after colon")
+		p.addError(p.peekToken, "Expected a block statement after colon")
 		return nil
 	}
 
@@ -629,3 +845,356 @@ func (p *Parser) parseForStatement() *ast.ForStatement {
 
 	return fs
 }
+
+// parseLoopVariable reads the variable of a for statement at the current token, a name or a destructuring pattern
+func (p *Parser) parseLoopVariable(fs *ast.ForStatement) bool {
+	if p.currentTokenTypeIs(token.LEFTSQPRAC) || p.currentTokenTypeIs(token.LEFTBRAC) {
+		fs.VarPattern = p.parsePattern()
+
+		return fs.VarPattern != nil
+	}
+
+	fs.VarIdent = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	return true
+}
+
+// parsePattern parses the target of a binding at the current token, a name, an array pattern or a hash pattern
+func (p *Parser) parsePattern() ast.Expression {
+	// defer untrace(trace("parsePattern"))
+	switch p.currentToken.Type {
+	case token.IDENT:
+		return &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	case token.LEFTSQPRAC:
+		return p.parseArrayPattern()
+
+	case token.LEFTBRAC:
+		return p.parseHashPattern()
+	}
+
+	p.addError(p.currentToken, fmt.Sprintf("Expected a name or a pattern, got '%s'", p.currentToken.Literal))
+
+	return nil
+}
+
+// parsePatternElement parses a pattern followed by an optional default value, e.g `x = 0`
+func (p *Parser) parsePatternElement() *ast.PatternElement {
+	target := p.parsePattern()
+
+	if target == nil {
+		return nil
+	}
+
+	element := &ast.PatternElement{Target: target}
+
+	if p.peekTokenTypeIs(token.ASSIGN) {
+		p.nextToken()
+		p.nextToken()
+
+		element.Default = p.parseExpression(LOWEST)
+	}
+
+	return element
+}
+
+func (p *Parser) parseArrayPattern() ast.Expression {
+	// defer untrace(trace("parseArrayPattern"))
+	pattern := &ast.ArrayPattern{Token: p.currentToken}
+
+	for !p.peekTokenTypeIs(token.RIGHTSQPRAC) {
+		p.nextToken()
+
+		if p.currentTokenTypeIs(token.ELLIPSIS) {
+			if !p.expectPeekType(token.IDENT) {
+				p.addError(p.peekToken, "Expected a name after '...'")
+				return nil
+			}
+
+			pattern.Rest = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+			if !p.peekTokenTypeIs(token.RIGHTSQPRAC) {
+				p.addError(p.peekToken, "The rest element must be the last element of an array pattern")
+				return nil
+			}
+
+			break
+		}
+
+		element := p.parsePatternElement()
+
+		if element == nil {
+			return nil
+		}
+
+		pattern.Elements = append(pattern.Elements, element)
+
+		if !p.peekTokenTypeIs(token.RIGHTSQPRAC) && !p.expectPeekType(token.COMMA) {
+			p.addError(p.peekToken, "Array pattern is not closed with ']'")
+			return nil
+		}
+	}
+
+	if !p.expectPeekType(token.RIGHTSQPRAC) {
+		p.addError(p.peekToken, "Array pattern is not closed with ']'")
+		return nil
+	}
+
+	return pattern
+}
+
+func (p *Parser) parseHashPattern() ast.Expression {
+	// defer untrace(trace("parseHashPattern"))
+	pattern := &ast.HashPattern{Token: p.currentToken}
+
+	for !p.peekTokenTypeIs(token.RIGHTBRAC) {
+		p.nextToken()
+
+		if !p.currentTokenTypeIs(token.IDENT) && !p.currentTokenTypeIs(token.STRING) {
+			p.addError(p.currentToken, fmt.Sprintf("Expected a key in hash pattern, got '%s'", p.currentToken.Literal))
+			return nil
+		}
+
+		key := &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+		element := &ast.PatternElement{Target: key}
+
+		if p.peekTokenTypeIs(token.COLON) {
+			p.nextToken()
+			p.nextToken()
+
+			element = p.parsePatternElement()
+
+			if element == nil {
+				return nil
+			}
+		} else if p.currentTokenTypeIs(token.STRING) {
+			// A string key is not a valid name, so it has to be renamed
+			p.addError(p.peekToken, fmt.Sprintf("Expected ':' after the key \"%s\" in hash pattern", key.Value))
+			return nil
+		} else if p.peekTokenTypeIs(token.ASSIGN) {
+			p.nextToken()
+			p.nextToken()
+
+			element.Default = p.parseExpression(LOWEST)
+		}
+
+		pattern.Entries = append(pattern.Entries, &ast.HashPatternEntry{Key: key, Element: element})
+
+		if !p.peekTokenTypeIs(token.RIGHTBRAC) && !p.expectPeekType(token.COMMA) {
+			p.addError(p.peekToken, "Hash pattern is not closed with '}'")
+			return nil
+		}
+	}
+
+	if !p.expectPeekType(token.RIGHTBRAC) {
+		p.addError(p.peekToken, "Hash pattern is not closed with '}'")
+		return nil
+	}
+
+	return pattern
+}
+
+func (p *Parser) parseMatchExpression() ast.Expression {
+	// defer untrace(trace("parseMatchExpression"))
+	expression := &ast.MatchExpression{Token: p.currentToken}
+
+	p.nextToken()
+	expression.Value = p.parseExpression(LOWEST)
+
+	if !p.expectPeekType(token.LEFTBRAC) {
+		p.addError(p.peekToken, "Expected '{' after the value of match")
+		return nil
+	}
+
+	for !p.peekTokenTypeIs(token.RIGHTBRAC) {
+		if p.peekTokenTypeIs(token.EOFILE) {
+			p.addError(p.peekToken, "Match is not closed with '}'")
+			return nil
+		}
+
+		p.nextToken()
+
+		arm := p.parseMatchArm()
+
+		if arm == nil {
+			return nil
+		}
+
+		expression.Arms = append(expression.Arms, arm)
+
+		// Arms are separated by a comma or a semicolon, the one after the last arm is optional
+		if p.peekTokenTypeIs(token.COMMA) || p.peekTokenTypeIs(token.SEMICOLON) {
+			p.nextToken()
+		}
+	}
+
+	p.nextToken()
+
+	return expression
+}
+
+// parseMatchArm parses `patterns [if guard] => body` starting at the current token, the body is a block or an expression
+func (p *Parser) parseMatchArm() *ast.MatchArm {
+	arm := &ast.MatchArm{}
+
+	for {
+		pattern := p.parseMatchPattern()
+
+		if pattern == nil {
+			return nil
+		}
+
+		arm.Patterns = append(arm.Patterns, pattern)
+
+		if !p.peekTokenTypeIs(token.COMMA) {
+			break
+		}
+
+		p.nextToken()
+		p.nextToken()
+	}
+
+	if p.peekTokenTypeIs(token.IF) {
+		p.nextToken()
+		p.nextToken()
+
+		arm.Guard = p.parseExpression(LOWEST)
+	}
+
+	if !p.expectPeekType(token.ARROW) {
+		p.addError(p.peekToken, "Expected '=>' after the pattern of a match arm")
+		return nil
+	}
+
+	p.nextToken()
+
+	if p.currentTokenTypeIs(token.LEFTBRAC) {
+		arm.Body = p.parseBlockStatement()
+
+		return arm
+	}
+
+	// A single expression is the body of the arm, it is wrapped in a block so arms are evaluated the same way
+	statement := &ast.ExpressionStatement{Token: p.currentToken, Expression: p.parseExpression(LOWEST)}
+	arm.Body = &ast.BlockStatement{Token: statement.Token, End: p.peekToken, Statements: []ast.Statement{statement}}
+
+	return arm
+}
+
+// parseMatchPattern parses one pattern of a match arm at the current token, see ast.MatchExpression for the forms
+func (p *Parser) parseMatchPattern() ast.Expression {
+	// defer untrace(trace("parseMatchPattern"))
+	switch p.currentToken.Type {
+	case token.IDENT:
+		if _, ok := ast.TypePatterns[p.currentToken.Literal]; ok {
+			return &ast.TypePattern{Token: p.currentToken, Name: p.currentToken.Literal}
+		}
+
+		return &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+	case token.INT, token.FLOAT, token.DECIMAL, token.STRING, token.TRUE, token.FALSE:
+		return p.prefixParseFuns[p.currentToken.Type]()
+
+	case token.MINUS:
+		if !p.peekTokenTypeIs(token.INT) && !p.peekTokenTypeIs(token.FLOAT) && !p.peekTokenTypeIs(token.DECIMAL) {
+			p.addError(p.peekToken, fmt.Sprintf("Expected a number after '-' in match pattern, got '%s'", p.peekToken.Literal))
+			return nil
+		}
+
+		return p.parsePrefixExpression()
+
+	case token.LEFTSQPRAC:
+		return p.parseArrayMatchPattern()
+
+	case token.LEFTBRAC:
+		return p.parseHashMatchPattern()
+	}
+
+	p.addError(p.currentToken, fmt.Sprintf("Expected a pattern in match arm, got '%s'", p.currentToken.Literal))
+
+	return nil
+}
+
+func (p *Parser) parseArrayMatchPattern() ast.Expression {
+	pattern := &ast.ArrayPattern{Token: p.currentToken}
+
+	for !p.peekTokenTypeIs(token.RIGHTSQPRAC) {
+		p.nextToken()
+
+		if p.currentTokenTypeIs(token.ELLIPSIS) {
+			if !p.expectPeekType(token.IDENT) {
+				p.addError(p.peekToken, "Expected a name after '...'")
+				return nil
+			}
+
+			pattern.Rest = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+
+			if !p.peekTokenTypeIs(token.RIGHTSQPRAC) {
+				p.addError(p.peekToken, "The rest element must be the last element of an array pattern")
+				return nil
+			}
+
+			break
+		}
+
+		target := p.parseMatchPattern()
+
+		if target == nil {
+			return nil
+		}
+
+		pattern.Elements = append(pattern.Elements, &ast.PatternElement{Target: target})
+
+		if !p.peekTokenTypeIs(token.RIGHTSQPRAC) && !p.expectPeekType(token.COMMA) {
+			p.addError(p.peekToken, "Array pattern is not closed with ']'")
+			return nil
+		}
+	}
+
+	p.nextToken()
+
+	return pattern
+}
+
+func (p *Parser) parseHashMatchPattern() ast.Expression {
+	pattern := &ast.HashPattern{Token: p.currentToken}
+
+	for !p.peekTokenTypeIs(token.RIGHTBRAC) {
+		p.nextToken()
+
+		if !p.currentTokenTypeIs(token.IDENT) && !p.currentTokenTypeIs(token.STRING) {
+			p.addError(p.currentToken, fmt.Sprintf("Expected a key in hash pattern, got '%s'", p.currentToken.Literal))
+			return nil
+		}
+
+		key := &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Literal}
+		element := &ast.PatternElement{Target: key}
+
+		if p.peekTokenTypeIs(token.COLON) {
+			p.nextToken()
+			p.nextToken()
+
+			target := p.parseMatchPattern()
+
+			if target == nil {
+				return nil
+			}
+
+			element.Target = target
+		} else if p.currentTokenTypeIs(token.STRING) {
+			p.addError(p.peekToken, fmt.Sprintf("Expected ':' after the key \"%s\" in hash pattern", key.Value))
+			return nil
+		}
+
+		pattern.Entries = append(pattern.Entries, &ast.HashPatternEntry{Key: key, Element: element})
+
+		if !p.peekTokenTypeIs(token.RIGHTBRAC) && !p.expectPeekType(token.COMMA) {
+			p.addError(p.peekToken, "Hash pattern is not closed with '}'")
+			return nil
+		}
+	}
+
+	p.nextToken()
+
+	return pattern
+}
@@ -369,7 +369,7 @@ func TestParseArrayWithFunction(t *testing.T) {
 			}
 
 			for jdx, param := range function.Parameters {
-				testIdentifier(t, param, params[jdx])
+				testIdentifier(t, param.Target, params[jdx])
 			}
 
 			for _, stmt := range function.Body.Statements {
@@ -1,9 +1,12 @@
 package tests
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/parser"
 )
 
 func TestAssignExpression(t *testing.T) {
@@ -32,7 +35,7 @@ func TestAssignExpression(t *testing.T) {
 			t.Fatalf("exp not *ast.AssignExpression. got=%T", exp.Expression)
 		}
 
-		ident := assignExp.Ident.Value
+		ident := assignExp.Target.(*ast.Identifier).Value
 
 		if ident != val.expectedIdent {
 			t.Fatalf("ident.String() is not %q, got=%q", val.expectedIdent, ident)
@@ -70,10 +73,16 @@ func TestAssignDictKeyExpression(t *testing.T) {
 			t.Fatalf("program.Statements[0] is not ast.ExpressionStatement. got=%T", program.Statements[0])
 		}
 
-		indExp, ok := exp.Expression.(*ast.IndexExpression)
+		assignExp, ok := exp.Expression.(*ast.AssignExpression)
 
 		if !ok {
-			t.Fatalf("exp not *ast.IndexExpression. got=%T", exp.Expression)
+			t.Fatalf("exp not *ast.AssignExpression. got=%T", exp.Expression)
+		}
+
+		indExp, ok := assignExp.Target.(*ast.IndexExpression)
+
+		if !ok {
+			t.Fatalf("assignExp.Target not *ast.IndexExpression. got=%T", assignExp.Target)
 		}
 
 		if indExp.Ident.String() != val.expectedIdent {
@@ -84,8 +93,8 @@ func TestAssignDictKeyExpression(t *testing.T) {
 			t.Fatalf("indExp.Index.String() is not '%q', got=%q", val.expectedLookup, indExp.Index.String())
 		}
 
-		if indExp.Value.String() != val.expectedValue {
-			t.Fatalf("indExp.Value.String() is not '%s', got=%q", val.expectedValue, indExp.Value.String())
+		if assignExp.Value.String() != val.expectedValue {
+			t.Fatalf("assignExp.Value.String() is not '%s', got=%q", val.expectedValue, assignExp.Value.String())
 		}
 	}
 
@@ -123,10 +132,16 @@ func TestAssignArrayIndexExpression(t *testing.T) {
 			t.Fatalf("program.Statements[0] is not ast.ExpressionStatement. got=%T", program.Statements[0])
 		}
 
-		indExp, ok := exp.Expression.(*ast.IndexExpression)
+		assignExp, ok := exp.Expression.(*ast.AssignExpression)
 
 		if !ok {
-			t.Fatalf("exp not *ast.IndexExpression. got=%T", exp.Expression)
+			t.Fatalf("exp not *ast.AssignExpression. got=%T", exp.Expression)
+		}
+
+		indExp, ok := assignExp.Target.(*ast.IndexExpression)
+
+		if !ok {
+			t.Fatalf("assignExp.Target not *ast.IndexExpression. got=%T", assignExp.Target)
 		}
 
 		if indExp.Ident.String() != val.expectedIdent {
@@ -137,8 +152,69 @@ func TestAssignArrayIndexExpression(t *testing.T) {
 			t.Fatalf("indExp.Index.String() is not '%q', got=%q", val.expectedLookup, indExp.Index.String())
 		}
 
-		if indExp.Value.String() != val.expectedValue {
-			t.Fatalf("indExp.Value.String() is not '%s', got=%q", val.expectedValue, indExp.Value.String())
+		if assignExp.Value.String() != val.expectedValue {
+			t.Fatalf("assignExp.Value.String() is not '%s', got=%q", val.expectedValue, assignExp.Value.String())
+		}
+	}
+}
+
+func TestAssignTargets(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"grid[i][j] = 0", "((grid[i])[j]) = 0;"},
+		{`h["a"]["b"] = 1`, "((h[a])[b]) = 1;"},
+		{"person.address.city = 'Amman'", "((person.address).city) = Amman;"},
+		{"x += 1", "x += 1;"},
+		{"x -= y * 2", "x -= (y * 2);"},
+		{"arr[0] *= 3", "(arr[0]) *= 3;"},
+		{"x /= 2", "x /= 2;"},
+		{"x %= 2", "x %= 2;"},
+		{"counter.count++", "(counter.count)++"},
+		{"arr[i]--", "(arr[i])--"},
+		{"x = y + 1", "x = (y + 1);"},
+	}
+
+	for _, tt := range tests {
+		program := parseProgram(t, tt.input)
+
+		if actual := program.String(); actual != tt.expected {
+			t.Errorf("%s: expected=%q, got=%q", tt.input, tt.expected, actual)
+		}
+	}
+}
+
+func TestAssignRightAssociative(t *testing.T) {
+	program := parseProgram(t, "a = b = 1")
+
+	outer, ok := testExpressionStatement(t, program.Statements[0]).Expression.(*ast.AssignExpression)
+
+	if !ok {
+		t.Fatalf("expression is not *ast.AssignExpression. got=%T", program.Statements[0])
+	}
+
+	inner, ok := outer.Value.(*ast.AssignExpression)
+
+	if !ok || outer.Target.String() != "a" || inner.Target.String() != "b" || inner.Value.String() != "1" {
+		t.Errorf("expected a = (b = 1), got %s", outer.String())
+	}
+}
+
+func TestAssignInvalidTargets(t *testing.T) {
+	tests := []string{
+		"1 = 2",
+		"a + b = 3",
+		"f() = 1",
+		"5++",
+	}
+
+	for _, input := range tests {
+		p := parser.New(lexer.New(input))
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 || !strings.HasPrefix(p.Errors()[0], "Cannot assign to") {
+			t.Errorf("%s: expected an invalid target error, got %v", input, p.Errors())
 		}
 	}
 }
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/parser"
+)
+
+func TestDestructuringPatterns(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let [a, b] = arr;", "let [a, b] = arr;"},
+		{"let [a, b, ...rest] = arr;", "let [a, b, ...rest] = arr;"},
+		{"let [x = 0, y = x + 1] = arr;", "let [x = 0, y = (x + 1)] = arr;"},
+		{"let {name, age: years} = person;", "let {name, age: years} = person;"},
+		{"const {name = 'anon', tags: [first, ...others]} = person;", "const {name = anon, tags: [first, ...others]} = person;"},
+		{"let [[a, b], {c}] = pairs;", "let [[a, b], {c}] = pairs;"},
+		{"let [] = arr;", "let [] = arr;"},
+		{"for [k, v] in pairs: { k; }", "for _, [k, v] in pairs: {k;}"},
+		{"for i, {name} in people: { name; }", "for i, {name} in people: {name;}"},
+		{"for _, [a, ...rest] in rows: { a; }", "for _, [a, ...rest] in rows: {a;}"},
+	}
+
+	for _, tt := range tests {
+		program := parseProgram(t, tt.input)
+
+		if program.String() != tt.expected {
+			t.Errorf("%s: expected=%q, got=%q", tt.input, tt.expected, program.String())
+		}
+	}
+}
+
+func TestDestructuringLetStatement(t *testing.T) {
+	program := parseProgram(t, "let {name, age: years = 1} = person;")
+
+	statement, ok := program.Statements[0].(*ast.LetStatement)
+
+	if !ok {
+		t.Fatalf("statement is not *ast.LetStatement. got=%T", program.Statements[0])
+	}
+
+	if statement.Name != nil {
+		t.Errorf("statement.Name should be nil for a pattern. got=%q", statement.Name.Value)
+	}
+
+	pattern, ok := statement.Pattern.(*ast.HashPattern)
+
+	if !ok {
+		t.Fatalf("statement.Pattern is not *ast.HashPattern. got=%T", statement.Pattern)
+	}
+
+	if len(pattern.Entries) != 2 {
+		t.Fatalf("pattern should have 2 entries. got=%d", len(pattern.Entries))
+	}
+
+	entry := pattern.Entries[1]
+
+	if entry.Key.Value != "age" {
+		t.Errorf("entry.Key is not 'age'. got=%q", entry.Key.Value)
+	}
+
+	testIdentifier(t, entry.Element.Target, "years")
+	testInteger(t, entry.Element.Default, 1)
+
+	names := []string{}
+
+	for _, ident := range ast.PatternNames(statement.Pattern) {
+		names = append(names, ident.Value)
+	}
+
+	if len(names) != 2 || names[0] != "name" || names[1] != "years" {
+		t.Errorf("PatternNames wrong. expected=[name years], got=%v", names)
+	}
+}
+
+func TestDestructuringParameters(t *testing.T) {
+	program := parseProgram(t, "fun(a, [b, c], {d}) { a; };")
+
+	function := testExpressionStatement(t, program.Statements[0]).Expression.(*ast.Function)
+
+	if len(function.Parameters) != 3 {
+		t.Fatalf("function should have 3 parameters. got=%d", len(function.Parameters))
+	}
+
+	if function.Parameters[0].Name() == nil || function.Parameters[0].Name().Value != "a" {
+		t.Errorf("first parameter should be named 'a'. got=%s", function.Parameters[0])
+	}
+
+	if _, ok := function.Parameters[1].Target.(*ast.ArrayPattern); !ok {
+		t.Errorf("second parameter is not *ast.ArrayPattern. got=%T", function.Parameters[1].Target)
+	}
+
+	if _, ok := function.Parameters[2].Target.(*ast.HashPattern); !ok {
+		t.Errorf("third parameter is not *ast.HashPattern. got=%T", function.Parameters[2].Target)
+	}
+
+	if function.Parameters[2].Name() != nil {
+		t.Errorf("a pattern parameter should have no name")
+	}
+}
+
+func TestDestructuringErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let [a, b];", "Expected '=' after the pattern of 'let', a pattern needs a value to destructure"},
+		{"let [...rest, a] = arr;", "The rest element must be the last element of an array pattern"},
+		{"let [...] = arr;", "Expected next token to be 'IDENT', got ']' instead"},
+		{"let [1] = arr;", "Expected a name or a pattern, got '1'"},
+		{"let {1: a} = h;", "Expected a key in hash pattern, got '1'"},
+		{"let {'first name'} = h;", "Expected ':' after the key \"first name\" in hash pattern"},
+		{"let [a b] = arr;", "Expected next token to be ',', got 'IDENT' instead"},
+	}
+
+	for _, tt := range tests {
+		p := parser.New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0] != tt.expected {
+			t.Errorf("%s: expected error %q, got %v", tt.input, tt.expected, p.Errors())
+		}
+	}
+}
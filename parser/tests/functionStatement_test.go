@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/parser"
+)
+
+func TestFunctionDeclaration(t *testing.T) {
+	program := parseProgram(t, "fun add(x, y = 1) { x + y; } add(1);")
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("program should have 2 statements. got=%d", len(program.Statements))
+	}
+
+	statement, ok := program.Statements[0].(*ast.FunctionStatement)
+
+	if !ok {
+		t.Fatalf("statement is not *ast.FunctionStatement. got=%T", program.Statements[0])
+	}
+
+	if statement.Function.Name == nil || statement.Function.Name.Value != "add" {
+		t.Fatalf("function name is not 'add'. got=%v", statement.Function.Name)
+	}
+
+	if statement.Function.Token.Literal != "fun" {
+		t.Errorf("function token is not 'fun'. got=%q", statement.Function.Token.Literal)
+	}
+
+	if len(statement.Function.Parameters) != 2 {
+		t.Errorf("function should have 2 parameters. got=%d", len(statement.Function.Parameters))
+	}
+
+	if statement.String() != "fun add(x, y = 1) {(x + y);}" {
+		t.Errorf("statement.String() wrong. got=%q", statement.String())
+	}
+
+	// Without a name `fun` still starts a function expression
+	program = parseProgram(t, "fun(x) { x; }(1);")
+
+	if _, ok := program.Statements[0].(*ast.ExpressionStatement); !ok {
+		t.Errorf("statement is not *ast.ExpressionStatement. got=%T", program.Statements[0])
+	}
+}
+
+func TestFunctionDeclarationErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"fun add { }", "Expected next token to be '(', got '{' instead"},
+		{"fun add(x) x", "Expected next token to be '{', got 'IDENT' instead"},
+		{"let f = fun g() {};", "Expected next token to be '(', got 'IDENT' instead"},
+	}
+
+	for _, tt := range tests {
+		p := parser.New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0] != tt.expected {
+			t.Errorf("%s: expected error %q, got %v", tt.input, tt.expected, p.Errors())
+		}
+	}
+}
+
+func TestFunctionDeclarationErrorsLeaveNoNilStatements(t *testing.T) {
+	inputs := []string{
+		"fun add { } let x = 1;",
+		"let x = 1; fun add(x) x",
+		"if true: { fun broken( { } }",
+	}
+
+	for _, input := range inputs {
+		p := parser.New(lexer.New(input))
+		program := p.ParseProgram()
+
+		if len(p.Errors()) == 0 {
+			t.Errorf("%s: expected parser errors", input)
+		}
+
+		ast.Inspect(program, func(node ast.Node) bool {
+			if block, ok := node.(*ast.BlockStatement); ok {
+				checkNoNilStatements(t, input, block.Statements)
+			}
+
+			return true
+		})
+
+		checkNoNilStatements(t, input, program.Statements)
+	}
+}
+
+func checkNoNilStatements(t *testing.T, input string, statements []ast.Statement) {
+	t.Helper()
+
+	for idx, statement := range statements {
+		if declaration, ok := statement.(*ast.FunctionStatement); statement == nil || ok && declaration == nil {
+			t.Errorf("%s: statement %d is nil", input, idx)
+		}
+	}
+}
@@ -34,8 +34,8 @@ func TestFunctionStatement(t *testing.T) {
 		t.Fatalf("function literal parameters wrong. want 2, got=%d\n", len(function.Parameters))
 	}
 
-	testLiteralExpression(t, function.Parameters[0], "x")
-	testLiteralExpression(t, function.Parameters[1], "y")
+	testLiteralExpression(t, function.Parameters[0].Target, "x")
+	testLiteralExpression(t, function.Parameters[1].Target, "y")
 
 	if function.Body == nil {
 		t.Fatalf("function.Body is nil\n")
@@ -80,7 +80,7 @@ func TestFunctionParameters(t *testing.T) {
 		}
 
 		for idx, ident := range val.expectedParams {
-			testLiteralExpression(t, function.Parameters[idx], ident)
+			testLiteralExpression(t, function.Parameters[idx].Target, ident)
 		}
 	}
 }
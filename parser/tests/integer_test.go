@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/parser"
 )
 
 func TestIntegerExpression(t *testing.T) {
@@ -22,3 +24,103 @@ func TestIntegerExpression(t *testing.T) {
 
 	testInteger(t, statement.Expression, 5)
 }
+
+func TestNumberLiterals(t *testing.T) {
+	integers := []struct {
+		input    string
+		expected int64
+	}{
+		{"1_000_000", 1000000},
+		{"0xFF", 255},
+		{"0b1010", 10},
+		{"0o755", 493},
+		{"0755", 755},
+		{"0x7FFF_FFFF_FFFF_FFFF", 9223372036854775807},
+	}
+
+	for _, tt := range integers {
+		program := parseProgram(t, tt.input)
+		integer, ok := testExpressionStatement(t, program.Statements[0]).Expression.(*ast.Integer)
+
+		if !ok || integer.Value != tt.expected {
+			t.Errorf("%s: expected the integer %d. got=%s", tt.input, tt.expected, program.Statements[0])
+		}
+	}
+
+	bigIntegers := []struct {
+		input    string
+		expected string
+	}{
+		{"9223372036854775808", "9223372036854775808"},
+		{"0x1_0000_0000_0000_0000", "18446744073709551616"},
+		{"123456789012345678901234567890", "123456789012345678901234567890"},
+	}
+
+	for _, tt := range bigIntegers {
+		program := parseProgram(t, tt.input)
+		integer, ok := testExpressionStatement(t, program.Statements[0]).Expression.(*ast.Integer)
+
+		if !ok || integer.Big == nil || integer.Big.String() != tt.expected {
+			t.Errorf("%s: expected the big integer %s. got=%s", tt.input, tt.expected, program.Statements[0])
+		}
+	}
+
+	floats := []struct {
+		input    string
+		expected float64
+	}{
+		{"6.02e23", 6.02e23},
+		{"1e-9", 1e-9},
+		{"1_000.5", 1000.5},
+	}
+
+	for _, tt := range floats {
+		program := parseProgram(t, tt.input)
+		float, ok := testExpressionStatement(t, program.Statements[0]).Expression.(*ast.Float)
+
+		if !ok || float.Value != tt.expected {
+			t.Errorf("%s: expected the float %g. got=%s", tt.input, tt.expected, program.Statements[0])
+		}
+	}
+
+	decimals := []struct {
+		input    string
+		expected string
+	}{
+		{"19.99d", "19.99"},
+		{"5d", "5"},
+		{"1_000.25d", "1000.25"},
+		{"1.5e3d", "1500"},
+	}
+
+	for _, tt := range decimals {
+		program := parseProgram(t, tt.input)
+		literal, ok := testExpressionStatement(t, program.Statements[0]).Expression.(*ast.Decimal)
+
+		if !ok || literal.Value.String() != tt.expected {
+			t.Errorf("%s: expected the decimal %s. got=%s", tt.input, tt.expected, program.Statements[0])
+		}
+	}
+}
+
+func TestNumberLiteralErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let x = 1.2.3;", "Invalid number '1.2.3': a number has at most one decimal point"},
+		{"1 + 0b12", "Invalid number '0b12': '2' is not a digit of binary numbers"},
+		{"f(1e)", "Invalid number '1e': expected digits after the exponent"},
+		{"1e999", "The float 1e999 is out of range"},
+		{"let x = 5 @", "No prefix parse function for ILLEGAL found"},
+	}
+
+	for _, tt := range tests {
+		p := parser.New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0] != tt.expected {
+			t.Errorf("%s: expected error %q, got %v", tt.input, tt.expected, p.Errors())
+		}
+	}
+}
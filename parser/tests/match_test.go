@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/parser"
+)
+
+func TestMatchExpression(t *testing.T) {
+	input := `match value {
+		1, 2 => "small",
+		[x, y] => x + y,
+		{kind: "a", name} => { name; },
+		n if n > 10 => n;
+		int => -1,
+		_ => null
+	}`
+
+	program := parseProgram(t, input)
+	match, ok := testExpressionStatement(t, program.Statements[0]).Expression.(*ast.MatchExpression)
+
+	if !ok {
+		t.Fatalf("expression is not *ast.MatchExpression. got=%T", testExpressionStatement(t, program.Statements[0]).Expression)
+	}
+
+	testIdentifier(t, match.Value, "value")
+
+	if len(match.Arms) != 6 {
+		t.Fatalf("match should have 6 arms. got=%d", len(match.Arms))
+	}
+
+	first := match.Arms[0]
+
+	if len(first.Patterns) != 2 {
+		t.Fatalf("first arm should have 2 patterns. got=%d", len(first.Patterns))
+	}
+
+	testInteger(t, first.Patterns[0], 1)
+	testInteger(t, first.Patterns[1], 2)
+
+	if _, ok := match.Arms[1].Patterns[0].(*ast.ArrayPattern); !ok {
+		t.Errorf("second arm pattern is not *ast.ArrayPattern. got=%T", match.Arms[1].Patterns[0])
+	}
+
+	hash, ok := match.Arms[2].Patterns[0].(*ast.HashPattern)
+
+	if !ok {
+		t.Fatalf("third arm pattern is not *ast.HashPattern. got=%T", match.Arms[2].Patterns[0])
+	}
+
+	testString(t, hash.Entries[0].Element.Target, "a")
+	testIdentifier(t, hash.Entries[1].Element.Target, "name")
+
+	guarded := match.Arms[3]
+	testIdentifier(t, guarded.Patterns[0], "n")
+	testInfixExpression(t, guarded.Guard, "n", ">", 10)
+
+	if typePattern, ok := match.Arms[4].Patterns[0].(*ast.TypePattern); !ok || typePattern.Name != "int" {
+		t.Errorf("fifth arm pattern is not the type pattern int. got=%s", match.Arms[4].Patterns[0])
+	}
+
+	testIdentifier(t, match.Arms[5].Patterns[0], "_")
+}
+
+func TestMatchString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"match x { 1 => 2 }", "match x {1 => {2}}"},
+		{"match x { 1, -2 => a, _ => b }", "match x {1, (-2) => {a}, _ => {b}}"},
+		{"match x { [a, ...rest] if a > 0 => rest }", "match x {[a, ...rest] if (a > 0) => {rest}}"},
+		{"let y = match x { str => 1 };", "let y = match x {str => {1}};"},
+		{"match x { }", "match x {}"},
+	}
+
+	for _, tt := range tests {
+		program := parseProgram(t, tt.input)
+
+		if program.String() != tt.expected {
+			t.Errorf("%s: expected=%q, got=%q", tt.input, tt.expected, program.String())
+		}
+	}
+}
+
+func TestMatchErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"match x 1 => 2", "Expected next token to be '{', got 'INT' instead"},
+		{"match x { 1 2 }", "Expected next token to be '=>', got 'INT' instead"},
+		{"match x { 1 => 2", "Match is not closed with '}'"},
+		{"match x { x + 1 => 2 }", "Expected next token to be '=>', got '+' instead"},
+		{"match x { -a => 2 }", "Expected a number after '-' in match pattern, got 'a'"},
+		{"match x { (1) => 2 }", "Expected a pattern in match arm, got '('"},
+		{"match x { [...rest, a] => 2 }", "The rest element must be the last element of an array pattern"},
+	}
+
+	for _, tt := range tests {
+		p := parser.New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0] != tt.expected {
+			t.Errorf("%s: expected error %q, got %v", tt.input, tt.expected, p.Errors())
+		}
+	}
+}
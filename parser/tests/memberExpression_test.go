@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Mostafa-DE/delang/ast"
+)
+
+func TestMemberExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"person.name", "(person.name)"},
+		{"a.b.c", "((a.b).c)"},
+		{`"abc".upper()`, "(abc.upper)()"},
+		{"arr.map(f).len()", "((arr.map)(f).len)()"},
+		{"-a.b", "(-(a.b))"},
+		{"a.b * c.d", "((a.b) * (c.d))"},
+		{"a[0].b", "((a[0]).b)"},
+		{"a.b[0]", "((a.b)[0])"},
+	}
+
+	for _, tt := range tests {
+		program := parseProgram(t, tt.input)
+
+		if actual := program.String(); actual != tt.expected {
+			t.Errorf("%s: expected=%q, got=%q", tt.input, tt.expected, actual)
+		}
+	}
+}
+
+func TestMemberAssignment(t *testing.T) {
+	program := parseProgram(t, "person.address.city = 'Amman' + '!'")
+
+	stmt := testExpressionStatement(t, program.Statements[0])
+	assign, ok := stmt.Expression.(*ast.AssignExpression)
+
+	if !ok {
+		t.Fatalf("expression is not *ast.AssignExpression. got=%T", stmt.Expression)
+	}
+
+	member, ok := assign.Target.(*ast.MemberExpression)
+
+	if !ok {
+		t.Fatalf("target is not *ast.MemberExpression. got=%T", assign.Target)
+	}
+
+	if member.Member.Value != "city" || member.Object.String() != "(person.address)" {
+		t.Errorf("unexpected member expression %s", member.String())
+	}
+
+	if assign.Value == nil || assign.Value.String() != "(Amman + !)" {
+		t.Errorf("unexpected assigned value %v", assign.Value)
+	}
+}
@@ -99,6 +99,22 @@ func TestOperatorPrecedence(t *testing.T) {
 			"1 and 2 or 3",
 			"((1 and 2) or 3)",
 		},
+		{
+			"not a == b",
+			"(not (a == b))",
+		},
+		{
+			"not a and b",
+			"((not a) and b)",
+		},
+		{
+			"a or not b + 1 < c",
+			"(a or (not ((b + 1) < c)))",
+		},
+		{
+			"not !a",
+			"(not (!a))",
+		},
 	}
 
 	for _, val := range tests {
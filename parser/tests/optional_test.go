@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/parser"
+)
+
+func TestConditionalAndNullishPrecedence(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"a ? b : c", "(a ? b : c)"},
+		{"a > 1 ? b + 1 : c * 2", "((a > 1) ? (b + 1) : (c * 2))"},
+		{"a ? b : c ? d : e", "(a ? b : (c ? d : e))"},
+		{"a ? b ? c : d : e", "(a ? (b ? c : d) : e)"},
+		{"a and b ? c : d", "((a and b) ? c : d)"},
+		{"x = a ? b : c", "x = (a ? b : c);"},
+		{"a ?? b", "(a ?? b)"},
+		{"a ?? b ?? c", "((a ?? b) ?? c)"},
+		{"a ?? b + 1", "(a ?? (b + 1))"},
+		{"a ?? b or c", "(a ?? (b or c))"},
+		{"a ?? b ? c : d", "((a ?? b) ? c : d)"},
+		{"a ? b ?? c : d ?? e", "(a ? (b ?? c) : (d ?? e))"},
+		{"a?.b", "(a?.b)"},
+		{"a?[0]", "(a?[0])"},
+		{"a?.b.c?[d](e)", "(((a?.b).c)?[d])(e)"},
+		{"a?.b ?? c", "((a?.b) ?? c)"},
+		{"a ? [1] : [2]", "(a ? [1] : [2])"},
+		{"{'k': a ? 1 : 2}", "{k: (a ? 1 : 2)}"},
+	}
+
+	for _, tt := range tests {
+		program := parseProgram(t, tt.input)
+
+		if program.String() != tt.expected {
+			t.Errorf("%s: expected=%q, got=%q", tt.input, tt.expected, program.String())
+		}
+	}
+}
+
+func TestOptionalAccess(t *testing.T) {
+	program := parseProgram(t, "user?.name; user?[0]; user.name;")
+
+	member, ok := testExpressionStatement(t, program.Statements[0]).Expression.(*ast.MemberExpression)
+
+	if !ok || !member.Optional {
+		t.Errorf("first statement should be an optional *ast.MemberExpression. got=%s", program.Statements[0])
+	}
+
+	index, ok := testExpressionStatement(t, program.Statements[1]).Expression.(*ast.IndexExpression)
+
+	if !ok || !index.Optional {
+		t.Errorf("second statement should be an optional *ast.IndexExpression. got=%s", program.Statements[1])
+	}
+
+	if member := testExpressionStatement(t, program.Statements[2]).Expression.(*ast.MemberExpression); member.Optional {
+		t.Errorf("user.name should not be optional")
+	}
+}
+
+func TestConditionalErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"a ? b", "Expected next token to be ':', got 'EOFILE' instead"},
+		{"a ? b c", "Expected next token to be ':', got 'IDENT' instead"},
+		{"a?.1", "Expected next token to be 'IDENT', got 'INT' instead"},
+		{"a?.b = 1", "Cannot assign to '(a?.b)', expected a variable, an index or a member"},
+		{"a?[0] += 1", "Cannot assign to '(a?[0])', expected a variable, an index or a member"},
+	}
+
+	for _, tt := range tests {
+		p := parser.New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0] != tt.expected {
+			t.Errorf("%s: expected error %q, got %v", tt.input, tt.expected, p.Errors())
+		}
+	}
+}
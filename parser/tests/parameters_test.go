@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/parser"
+)
+
+func TestParameterForms(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"fun(x, y = 10) { x; }", "fun(x, y = 10) {x;}"},
+		{"fun(first, ...others) { first; }", "fun(first, ...others) {first;}"},
+		{"fun(x = 1 + 2, [a, b] = [1, 2], ...rest) { x; }", "fun(x = (1 + 2), [a, b] = [1, 2], ...rest) {x;}"},
+		{"fun(...all) { all; }", "fun(...all) {all;}"},
+	}
+
+	for _, tt := range tests {
+		program := parseProgram(t, tt.input)
+
+		if program.String() != tt.expected {
+			t.Errorf("%s: expected=%q, got=%q", tt.input, tt.expected, program.String())
+		}
+	}
+
+	function := testExpressionStatement(t, parseProgram(t, "fun(x, y = 10, ...others) {}").Statements[0]).Expression.(*ast.Function)
+
+	if function.Parameters[0].Default != nil || function.Parameters[0].Rest {
+		t.Errorf("x should be a plain parameter. got=%s", function.Parameters[0])
+	}
+
+	testInteger(t, function.Parameters[1].Default, 10)
+
+	if !function.Parameters[2].Rest || function.Parameters[2].Name().Value != "others" {
+		t.Errorf("others should be a rest parameter. got=%s", function.Parameters[2])
+	}
+}
+
+func TestCallArgumentForms(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"f(...arr)", "f(...arr)"},
+		{"f(1, ...rest, 2)", "f(1, ...rest, 2)"},
+		{"f(y: 2)", "f(y: 2)"},
+		{"f(1, y: 2 * 3, z: g(x: 1))", "f(1, y: (2 * 3), z: g(x: 1))"},
+		{"f(...[1, 2], y: 3)", "f(...[1, 2], y: 3)"},
+	}
+
+	for _, tt := range tests {
+		program := parseProgram(t, tt.input)
+
+		if program.String() != tt.expected {
+			t.Errorf("%s: expected=%q, got=%q", tt.input, tt.expected, program.String())
+		}
+	}
+
+	call := testExpressionStatement(t, parseProgram(t, "f(...arr, y: 2)").Statements[0]).Expression.(*ast.CallFunction)
+
+	spread, ok := call.Arguments[0].(*ast.SpreadArgument)
+
+	if !ok {
+		t.Fatalf("first argument is not *ast.SpreadArgument. got=%T", call.Arguments[0])
+	}
+
+	testIdentifier(t, spread.Value, "arr")
+
+	keyword, ok := call.Arguments[1].(*ast.KeywordArgument)
+
+	if !ok {
+		t.Fatalf("second argument is not *ast.KeywordArgument. got=%T", call.Arguments[1])
+	}
+
+	if keyword.Name.Value != "y" {
+		t.Errorf("keyword.Name is not 'y'. got=%q", keyword.Name.Value)
+	}
+
+	testInteger(t, keyword.Value, 2)
+}
+
+func TestParameterErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"fun(...others, x) {}", "The rest parameter 'others' must be the last parameter"},
+		{"fun(...) {}", "Expected next token to be 'IDENT', got ')' instead"},
+		{"fun(1) {}", "Expected a name or a pattern, got '1'"},
+		{"f(x: 1, 2)", "Positional arguments cannot follow keyword arguments"},
+		{"f(x: 1, ...arr)", "Positional arguments cannot follow keyword arguments"},
+	}
+
+	for _, tt := range tests {
+		p := parser.New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0] != tt.expected {
+			t.Errorf("%s: expected error %q, got %v", tt.input, tt.expected, p.Errors())
+		}
+	}
+}
@@ -10,6 +10,9 @@ import (
 const (
 	_            int = iota
 	LOWEST           // Lowest precedence
+	ASSIGN           // = += -= *= /= %=
+	CONDITIONAL      // cond ? a : b
+	NULLISH          // a ?? b
 	AND_OR           // and or or
 	EQUAL            // ==
 	LESS_GREATER     // > or <
@@ -17,25 +20,38 @@ const (
 	MUL_DIV_MOD      // * or / or %
 	PREFIX           // -X or !X
 	CALL             // myFunction(X)
-	INDEX            // array[index]
+	INDEX            // array[index], object.member, x++ or x--
 )
 
 var precedences = map[token.TokenType]int{
-	token.EQUAL:         EQUAL,
-	token.NOTEQUAL:      EQUAL,
-	token.LESSTHAN:      LESS_GREATER,
-	token.GREATERTHAN:   LESS_GREATER,
-	token.LESSTHANEQ:    LESS_GREATER,
-	token.GREATERTHANEQ: LESS_GREATER,
-	token.AND:           AND_OR,
-	token.OR:            AND_OR,
-	token.PLUS:          SUM_SUB,
-	token.MINUS:         SUM_SUB,
-	token.SLASH:         MUL_DIV_MOD,
-	token.ASTERISK:      MUL_DIV_MOD,
-	token.MOD:           MUL_DIV_MOD,
-	token.LEFTPAR:       CALL,
-	token.LEFTSQPRAC:    INDEX, // array indexing has the highest precedence
+	token.EQUAL:           EQUAL,
+	token.NOTEQUAL:        EQUAL,
+	token.LESSTHAN:        LESS_GREATER,
+	token.GREATERTHAN:     LESS_GREATER,
+	token.LESSTHANEQ:      LESS_GREATER,
+	token.GREATERTHANEQ:   LESS_GREATER,
+	token.AND:             AND_OR,
+	token.OR:              AND_OR,
+	token.PLUS:            SUM_SUB,
+	token.MINUS:           SUM_SUB,
+	token.SLASH:           MUL_DIV_MOD,
+	token.ASTERISK:        MUL_DIV_MOD,
+	token.MOD:             MUL_DIV_MOD,
+	token.LEFTPAR:         CALL,
+	token.LEFTSQPRAC:      INDEX, // array indexing has the highest precedence
+	token.DOT:             INDEX,
+	token.OPTIONAL_DOT:    INDEX,
+	token.OPTIONAL_INDEX:  INDEX,
+	token.QUESTION:        CONDITIONAL,
+	token.NULLISH:         NULLISH,
+	token.ASSIGN:          ASSIGN,
+	token.PLUS_ASSIGN:     ASSIGN,
+	token.MINUS_ASSIGN:    ASSIGN,
+	token.ASTERISK_ASSIGN: ASSIGN,
+	token.SLASH_ASSIGN:    ASSIGN,
+	token.MOD_ASSIGN:      ASSIGN,
+	token.INCREMENT:       INDEX,
+	token.DECREMENT:       INDEX,
 }
 
 type (
@@ -43,11 +59,18 @@ type (
 	infixParseFunc  func(ast.Expression) ast.Expression
 )
 
+type ParseError struct {
+	Msg    string
+	Line   int
+	Column int
+}
+
 type Parser struct {
 	lexerInstance   *lexer.Lexer
 	currentToken    token.Token
 	peekToken       token.Token
 	errors          []string
+	detailedErrors  []ParseError
 	prefixParseFuns map[token.TokenType]prefixParseFunc
 	infixParseFuns  map[token.TokenType]infixParseFunc
 }
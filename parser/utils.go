@@ -2,7 +2,9 @@ package parser
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/Mostafa-DE/delang/ast"
 	"github.com/Mostafa-DE/delang/token"
 )
 
@@ -10,6 +12,16 @@ func (p *Parser) Errors() []string {
 	return p.errors
 }
 
+// DetailedErrors returns the same errors as Errors() along with the position of the token that caused them
+func (p *Parser) DetailedErrors() []ParseError {
+	return p.detailedErrors
+}
+
+func (p *Parser) addError(tok token.Token, msg string) {
+	p.errors = append(p.errors, msg)
+	p.detailedErrors = append(p.detailedErrors, ParseError{Msg: msg, Line: tok.Line, Column: tok.Column})
+}
+
 func (p *Parser) currentTokenTypeIs(t token.TokenType) bool {
 	return p.currentToken.Type == t
 }
@@ -31,7 +43,7 @@ func (p *Parser) expectPeekType(t token.TokenType) bool {
 func (p *Parser) peekError(tokType token.TokenType) {
 	msg := fmt.Sprintf("Expected next token to be '%s', got '%s' instead", tokType, p.peekToken.Type)
 
-	p.errors = append(p.errors, msg)
+	p.addError(p.peekToken, msg)
 }
 
 func (p *Parser) peekPrecedence() int {
@@ -52,5 +64,23 @@ func (p *Parser) currentPrecedence() int {
 
 func (p *Parser) noPrefixParseFnError(t token.TokenType) {
 	msg := fmt.Sprintf("No prefix parse function for %s found", t)
-	p.errors = append(p.errors, msg)
+	p.addError(p.currentToken, msg)
+}
+
+func isKeywordArgument(argument ast.Expression) bool {
+	_, ok := argument.(*ast.KeywordArgument)
+
+	return ok
+}
+
+// numberText is the literal of a number without its `_` separators, a plain integer keeps base 10 even with leading zeros
+func numberText(literal string) string {
+	literal = strings.ReplaceAll(literal, "_", "")
+
+	// strconv reads a leading 0 as octal, DE writes octal as 0o755
+	if trimmed := strings.TrimLeft(literal, "0"); trimmed != literal && trimmed != "" && trimmed[0] >= '0' && trimmed[0] <= '9' {
+		return trimmed
+	}
+
+	return literal
 }
@@ -0,0 +1,145 @@
+package repl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Mostafa-DE/delang/evaluator"
+	"github.com/Mostafa-DE/delang/object"
+	"github.com/Mostafa-DE/delang/token"
+)
+
+// The commands the REPL understands besides DE code, they all start with a dot
+var commands = []string{
+	".ast", ".clear", ".clearHistory", ".doc", ".env", ".exit", ".help", ".load", ".reset", ".save", ".time", ".tokens", ".type",
+}
+
+/*
+Complete returns the candidates for the word that ends at the cursor along with the byte offset where that word starts.
+A word at the start of the line that begins with a dot is completed from the REPL commands,
+any other word from the names of the environment chain, the builtin functions and the keywords.
+*/
+func Complete(input string, cursor int, env *object.Environment) (int, []string) {
+	start := cursor
+
+	for start > 0 && isIdentChar(input[start-1]) {
+		start--
+	}
+
+	prefix := input[start:cursor]
+
+	if start > 0 && input[start-1] == '.' && strings.TrimSpace(input[:start-1]) == "" {
+		return start - 1, matching("."+prefix, commands)
+	}
+
+	if prefix == "" {
+		return start, nil
+	}
+
+	names := append(evaluator.BuiltinNames(), token.Keywords()...)
+
+	for current := env; current != nil; current = current.GetOuterEnv() {
+		names = append(names, current.Names()...)
+	}
+
+	return start, matching(prefix, names)
+}
+
+// matching returns the names that start with prefix, sorted and without duplicates
+func matching(prefix string, names []string) []string {
+	seen := make(map[string]bool)
+	result := []string{}
+
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) && !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+func isIdentChar(char byte) bool {
+	return 'a' <= char && char <= 'z' || 'A' <= char && char <= 'Z' || '0' <= char && char <= '9' || char == '_'
+}
+
+// commonPrefix returns the longest prefix shared by all the candidates
+func commonPrefix(candidates []string) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	prefix := candidates[0]
+
+	for _, candidate := range candidates[1:] {
+		for !strings.HasPrefix(candidate, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+
+	return prefix
+}
+
+/*
+handleTab completes the word before the cursor as far as all the candidates agree,
+when that doesn't add anything the candidates are listed below the prompt.
+*/
+func handleTab(editor *Editor, env *object.Environment) {
+	input, cursor := editor.String(), editor.ByteCursor()
+	start, candidates := Complete(input, cursor, env)
+
+	if len(candidates) == 0 {
+		return
+	}
+
+	completion := commonPrefix(candidates)
+
+	if len(candidates) == 1 {
+		completion += " "
+
+		// Functions are most likely called right away
+		if _, ok := evaluator.GetBuiltin(candidates[0]); ok {
+			completion = candidates[0] + "("
+		}
+	}
+
+	word := input[start:cursor]
+
+	if len(completion) > len(word) {
+		editor.Replace(input[:start]+completion+input[cursor:], start+len(completion))
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(strings.Join(candidates, "  "))
+	editor.ForgetRows()
+}
+
+// Doc returns the documentation of a builtin function or the signature of a user function
+func Doc(name string, env *object.Environment) (string, bool) {
+	if builtin, ok := evaluator.GetBuiltin(name); ok {
+		return fmt.Sprintf("%s(...): builtin function\n%s", builtin.Name, builtin.Desc), true
+	}
+
+	value, ok := env.Get(name)
+
+	if !ok {
+		return "", false
+	}
+
+	if function, ok := value.(*object.Function); ok {
+		params := []string{}
+
+		for _, param := range function.Parameters {
+			params = append(params, param.String())
+		}
+
+		return fmt.Sprintf("%s = fun(%s)", name, strings.Join(params, ", ")), true
+	}
+
+	return fmt.Sprintf("%s: %s", name, value.Type()), true
+}
@@ -0,0 +1,217 @@
+package repl
+
+import (
+	"fmt"
+	"io"
+	"unicode"
+)
+
+const DEFAULT_WIDTH = 80 // Used when the width of the terminal is unknown
+
+/*
+Editor is the line being typed at the prompt.
+The line is kept as runes so that the cursor never lands inside a multi-byte character,
+and it is drawn by the width of its runes so that wide characters and lines longer than the terminal wrap correctly.
+*/
+type Editor struct {
+	line      []rune
+	cursor    int // Index of the rune under the cursor
+	cursorRow int // Row of the cursor counted from the row of the prompt, long lines wrap on several rows
+
+	// Highlight returns the line with colors added, it must not change the visible text
+	Highlight func(line string) string
+}
+
+func NewEditor() *Editor {
+	return &Editor{}
+}
+
+func (e *Editor) String() string {
+	return string(e.line)
+}
+
+// Cursor returns the position of the cursor in runes
+func (e *Editor) Cursor() int {
+	return e.cursor
+}
+
+// ByteCursor returns the position of the cursor in bytes of String()
+func (e *Editor) ByteCursor() int {
+	return len(string(e.line[:e.cursor]))
+}
+
+// Set replaces the line and moves the cursor to its end
+func (e *Editor) Set(text string) {
+	e.line = []rune(text)
+	e.cursor = len(e.line)
+}
+
+// Replace replaces the line and moves the cursor to the given byte offset
+func (e *Editor) Replace(text string, byteCursor int) {
+	e.line = []rune(text)
+	e.cursor = len([]rune(text[:byteCursor]))
+}
+
+// Accept returns the line and clears the editor for the next prompt
+func (e *Editor) Accept() string {
+	text := e.String()
+
+	e.line = nil
+	e.cursor = 0
+	e.cursorRow = 0
+
+	return text
+}
+
+func (e *Editor) Insert(text string) {
+	runes := []rune(text)
+
+	line := make([]rune, 0, len(e.line)+len(runes))
+	line = append(line, e.line[:e.cursor]...)
+	line = append(line, runes...)
+	line = append(line, e.line[e.cursor:]...)
+
+	e.line = line
+	e.cursor += len(runes)
+}
+
+// Backspace deletes the rune before the cursor
+func (e *Editor) Backspace() {
+	if e.cursor > 0 {
+		e.deleteRange(e.cursor-1, e.cursor)
+	}
+}
+
+// Delete deletes the rune under the cursor
+func (e *Editor) Delete() {
+	if e.cursor < len(e.line) {
+		e.deleteRange(e.cursor, e.cursor+1)
+	}
+}
+
+func (e *Editor) Left() {
+	if e.cursor > 0 {
+		e.cursor--
+	}
+}
+
+func (e *Editor) Right() {
+	if e.cursor < len(e.line) {
+		e.cursor++
+	}
+}
+
+func (e *Editor) Home() {
+	e.cursor = 0
+}
+
+func (e *Editor) End() {
+	e.cursor = len(e.line)
+}
+
+// WordLeft moves the cursor to the start of the word before it
+func (e *Editor) WordLeft() {
+	e.cursor = e.wordStart()
+}
+
+// WordRight moves the cursor past the end of the word after it
+func (e *Editor) WordRight() {
+	for e.cursor < len(e.line) && !isWordRune(e.line[e.cursor]) {
+		e.cursor++
+	}
+
+	for e.cursor < len(e.line) && isWordRune(e.line[e.cursor]) {
+		e.cursor++
+	}
+}
+
+// DeleteWordLeft deletes the word before the cursor, like Ctrl + w in a shell
+func (e *Editor) DeleteWordLeft() {
+	e.deleteRange(e.wordStart(), e.cursor)
+}
+
+// DeleteToStart deletes everything before the cursor
+func (e *Editor) DeleteToStart() {
+	e.deleteRange(0, e.cursor)
+}
+
+// DeleteToEnd deletes everything from the cursor to the end of the line
+func (e *Editor) DeleteToEnd() {
+	e.deleteRange(e.cursor, len(e.line))
+}
+
+func (e *Editor) wordStart() int {
+	start := e.cursor
+
+	for start > 0 && !isWordRune(e.line[start-1]) {
+		start--
+	}
+
+	for start > 0 && isWordRune(e.line[start-1]) {
+		start--
+	}
+
+	return start
+}
+
+func (e *Editor) deleteRange(from int, to int) {
+	e.line = append(e.line[:from:from], e.line[to:]...)
+	e.cursor = from
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// ForgetRows tells the editor that the cursor is at the start of a fresh row, e.g after the screen is cleared
+func (e *Editor) ForgetRows() {
+	e.cursorRow = 0
+}
+
+/*
+Render redraws the prompt and the line in place:
+
+- The cursor goes back to the first row of the prompt and everything below it is cleared.
+- The prompt and the line are written, the terminal wraps them on as many rows as they need.
+- The cursor is moved to its row and column, both are computed from the width of the runes before it.
+*/
+func (e *Editor) Render(out io.Writer, prompt string, columns int) {
+	if columns <= 0 {
+		columns = DEFAULT_WIDTH
+	}
+
+	if e.cursorRow > 0 {
+		fmt.Fprintf(out, "\033[%dA", e.cursorRow)
+	}
+
+	display := e.String()
+
+	if e.Highlight != nil {
+		display = e.Highlight(display)
+	}
+
+	fmt.Fprint(out, "\r\033[J", prompt, display)
+
+	promptWidth := StringWidth(prompt)
+	end := promptWidth + StringWidth(e.String())
+
+	// Terminals only wrap once the next character is written, wrap now so that the cursor is where we count it
+	if end > 0 && end%columns == 0 {
+		fmt.Fprint(out, "\r\n")
+	}
+
+	position := promptWidth + StringWidth(string(e.line[:e.cursor]))
+	row, column := position/columns, position%columns
+
+	if endRow := end / columns; endRow > row {
+		fmt.Fprintf(out, "\033[%dA", endRow-row)
+	}
+
+	fmt.Fprint(out, "\r")
+
+	if column > 0 {
+		fmt.Fprintf(out, "\033[%dC", column)
+	}
+
+	e.cursorRow = row
+}
@@ -0,0 +1,167 @@
+package repl
+
+import (
+	"os"
+	"strings"
+
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/token"
+)
+
+// What the highlighter colors
+const (
+	KEYWORD_COLOR = "keyword"
+	STRING_COLOR  = "string"
+	NUMBER_COLOR  = "number"
+	COMMENT_COLOR = "comment"
+	ERROR_COLOR   = "error" // Closing brackets that don't match an opening one
+)
+
+// Theme maps what is highlighted to the SGR parameters of its color, e.g "1;35" for bold magenta
+type Theme map[string]string
+
+var DefaultTheme = Theme{
+	KEYWORD_COLOR: "35",
+	STRING_COLOR:  "32",
+	NUMBER_COLOR:  "33",
+	COMMENT_COLOR: "90",
+	ERROR_COLOR:   "31",
+}
+
+/*
+LoadTheme returns the colors of the REPL, or nil when colors are off.
+They are off when NO_COLOR is set or when the output is not a terminal.
+DE_COLORS changes the default colors, e.g DE_COLORS="keyword=1;34:comment=37", an empty color turns one off.
+*/
+func LoadTheme() Theme {
+	if os.Getenv("NO_COLOR") != "" || !isTerminal(os.Stdout) {
+		return nil
+	}
+
+	return ParseTheme(os.Getenv("DE_COLORS"))
+}
+
+// ParseTheme returns the default theme with the changes of a DE_COLORS value, unknown names are ignored
+func ParseTheme(spec string) Theme {
+	theme := Theme{}
+
+	for name, color := range DefaultTheme {
+		theme[name] = color
+	}
+
+	for _, entry := range strings.Split(spec, ":") {
+		name, color, ok := strings.Cut(entry, "=")
+
+		if _, known := DefaultTheme[name]; ok && known {
+			theme[name] = color
+		}
+	}
+
+	return theme
+}
+
+type span struct {
+	start, end int // Byte offsets in the line
+	color      string
+}
+
+/*
+Highlight colors the last line of the input, previous are the lines before it when a statement spans several lines.
+They are lexed along with the line so that strings and brackets opened on an earlier line are known.
+Comments are not tokens so they are found in the gaps between the tokens.
+*/
+func Highlight(previous []string, line string, theme Theme) string {
+	if theme == nil {
+		return line
+	}
+
+	lastLine := len(previous) + 1
+	source := strings.Join(append(append([]string{}, previous...), line), "\n")
+
+	spans := []span{}
+	brackets := []token.TokenType{}
+	end := 0 // Where the previous token of the line ends
+
+	addGap := func(from int, to int) {
+		if idx := strings.Index(line[from:to], "//"); idx != -1 {
+			spans = append(spans, span{from + idx, len(line), theme[COMMENT_COLOR]})
+		}
+	}
+
+	l := lexer.New(source)
+
+	for tok := l.NextToken(); tok.Type != token.EOFILE; tok = l.NextToken() {
+		color := ""
+
+		switch tok.Type {
+		case token.LEFTPAR, token.LEFTBRAC, token.LEFTSQPRAC:
+			brackets = append(brackets, tok.Type)
+
+		case token.RIGHTPAR, token.RIGHTBRAC, token.RIGHTSQPRAC:
+			if len(brackets) == 0 || closing[brackets[len(brackets)-1]] != tok.Type {
+				color = theme[ERROR_COLOR]
+			} else {
+				brackets = brackets[:len(brackets)-1]
+			}
+
+		case token.STRING:
+			color = theme[STRING_COLOR]
+
+		case token.INT, token.FLOAT, token.DECIMAL:
+			color = theme[NUMBER_COLOR]
+
+		default:
+			if token.LookupIdent(tok.Literal) != token.IDENT {
+				color = theme[KEYWORD_COLOR]
+			}
+		}
+
+		if tok.Line != lastLine {
+			continue
+		}
+
+		start := tok.Column - 1
+		tokEnd := min(start+max(len(tok.Literal), 1), len(line))
+
+		if tok.Type == token.STRING {
+			// The quotes are not part of the literal and the closing one is missing while the string is typed
+			tokEnd = min(start+1+len(tok.Literal), len(line))
+
+			if tokEnd < len(line) && (line[tokEnd] == '"' || line[tokEnd] == '\'') {
+				tokEnd++
+			}
+		}
+
+		addGap(end, start)
+		end = tokEnd
+
+		if color != "" {
+			spans = append(spans, span{start, tokEnd, color})
+		}
+	}
+
+	addGap(end, len(line))
+
+	var out strings.Builder
+	position := 0
+
+	for _, s := range spans {
+		if s.color == "" {
+			continue
+		}
+
+		out.WriteString(line[position:s.start])
+		out.WriteString("\033[" + s.color + "m" + line[s.start:s.end] + "\033[0m")
+		position = s.end
+	}
+
+	out.WriteString(line[position:])
+
+	return out.String()
+}
+
+var closing = map[token.TokenType]token.TokenType{
+	token.LEFTPAR:    token.RIGHTPAR,
+	token.LEFTBRAC:   token.RIGHTBRAC,
+	token.LEFTSQPRAC: token.RIGHTSQPRAC,
+}
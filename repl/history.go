@@ -0,0 +1,93 @@
+package repl
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+)
+
+const HISTORY_SIZE = 1000 // The number of entries kept in the history file
+
+/*
+HistoryPath returns where the history is kept, following the XDG base directory spec:
+$XDG_STATE_HOME/de/history, or ~/.local/state/de/history when XDG_STATE_HOME is not set.
+*/
+func HistoryPath() string {
+	dir := os.Getenv("XDG_STATE_HOME")
+
+	if dir == "" {
+		home, err := os.UserHomeDir()
+
+		if err != nil {
+			return ""
+		}
+
+		dir = filepath.Join(home, ".local", "state")
+	}
+
+	return filepath.Join(dir, "de", "history")
+}
+
+// AddToHistory appends an entry, drops its older copies and keeps the newest HISTORY_SIZE entries
+func AddToHistory(history []string, entry string) []string {
+	result := make([]string, 0, len(history)+1)
+
+	for _, old := range history {
+		if old != entry {
+			result = append(result, old)
+		}
+	}
+
+	result = append(result, entry)
+
+	if len(result) > HISTORY_SIZE {
+		result = result[len(result)-HISTORY_SIZE:]
+	}
+
+	return result
+}
+
+func saveHistoryToFile(history []string, filename string) error {
+	if filename == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0700); err != nil {
+		return err
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if len(history) > HISTORY_SIZE {
+		history = history[len(history)-HISTORY_SIZE:]
+	}
+
+	for _, cmd := range history {
+		if _, err := file.WriteString(cmd + "\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func loadHistoryFromFile(filename string) []string {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil
+	}
+
+	defer file.Close()
+
+	var history []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		history = AddToHistory(history, scanner.Text())
+	}
+
+	return history
+}
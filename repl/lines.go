@@ -0,0 +1,71 @@
+package repl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+)
+
+type Options struct {
+	Quiet bool // Don't print the banner, nor the prompts when the input is not a terminal
+}
+
+// isTerminal reports whether the file is a terminal and not a pipe or a regular file
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func printBanner(out io.Writer) {
+	fmt.Fprintf(out, "Hi! Welcome to DE\n")
+	fmt.Fprintf(out, "Type '.help' to see a list of commands.\n")
+}
+
+/*
+RunLines is the REPL for input that doesn't come from a terminal, e.g `echo '1 + 2' | de`.
+It reads whole lines instead of keys but otherwise behaves like the interactive session:
+every line is evaluated in the same environment, statements can span several lines and the commands work.
+*/
+func RunLines(in io.Reader, out io.Writer, options Options) error {
+	s := newSession(out, []string{})
+	pendingLines := []string{}
+
+	showPrompt := func() {
+		if !options.Quiet {
+			fmt.Fprint(out, currentPrompt(pendingLines, nil))
+		}
+	}
+
+	if !options.Quiet {
+		printBanner(out)
+		fmt.Fprintln(out)
+	}
+
+	showPrompt()
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if input, complete := collectLine(&pendingLines, line); complete {
+			if !s.exec(input) {
+				return nil
+			}
+		}
+
+		showPrompt()
+	}
+
+	// Evaluate what is left so that incomplete input at the end is reported
+	if len(pendingLines) != 0 {
+		if input, complete := collectLine(&pendingLines, ""); complete {
+			s.exec(input)
+		}
+	}
+
+	return scanner.Err()
+}
@@ -4,36 +4,57 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
-	"github.com/Mostafa-DE/delang/evaluator"
-	"github.com/Mostafa-DE/delang/lexer"
-	"github.com/Mostafa-DE/delang/object"
-	"github.com/Mostafa-DE/delang/parser"
 	"github.com/eiannone/keyboard"
 )
 
-func StartSession() {
-	PROMPT := ">>> "
+const (
+	PROMPT              = ">>> "
+	CONTINUATION_PROMPT = "... " // Shown while a statement spans several lines
+)
+
+// StartSession runs the REPL, it reads keys from the terminal or falls back to lines when stdin is not one
+func StartSession(options Options) {
+	if !isTerminal(os.Stdin) {
+		if err := RunLines(os.Stdin, os.Stdout, options); err != nil {
+			log.Fatal(err)
+		}
 
-	env := object.NewEnvironment()
+		return
+	}
 
 	if err := keyboard.Open(); err != nil {
 		log.Fatal(err)
 	}
 	defer keyboard.Close()
 
-	fmt.Printf("Hi! Welcome to DE\n")
-	fmt.Printf("Type '.help' to see a list of commands.\n")
+	if !options.Quiet {
+		printBanner(os.Stdout)
+	}
+
+	historyFile := HistoryPath()
+	s := newSession(os.Stdout, loadHistoryFromFile(historyFile))
 
-	history := []string{}
+	// The history is saved when leaving, whichever way that happens
+	defer func() {
+		if err := saveHistoryToFile(s.history, historyFile); err != nil {
+			fmt.Printf("Failed to save history: %v\n", err)
+		}
+	}()
 
-	history = loadHistoryFromFile("history.txt")
+	pendingLines := []string{} // The lines of a statement that is not complete yet
+	editor := NewEditor()
 
-	historyIndex := 0
-	currentInput := ""
-	cursorPosition := 0
+	if theme := LoadTheme(); theme != nil {
+		editor.Highlight = func(line string) string {
+			return Highlight(pendingLines, line, theme)
+		}
+	}
 	quitCount := 0
 
+	var search *reverseSearch // Not nil while Ctrl + r is active
+
 	fmt.Print("\n")
 	fmt.Print(PROMPT)
 
@@ -43,182 +64,183 @@ func StartSession() {
 			log.Fatal(err)
 		}
 
+		if search != nil {
+			active, consumed := search.handleKey(char, key, editor, s.history)
+
+			if !active {
+				search = nil
+			}
+
+			if consumed {
+				refreshLine(editor, currentPrompt(pendingLines, search))
+				continue
+			}
+		}
+
 		switch key {
 		case keyboard.KeyCtrlC:
+			// Ctrl + c drops a statement that spans several lines instead of exiting
+			if len(pendingLines) != 0 {
+				pendingLines = []string{}
+				editor.Accept()
+				fmt.Println()
+				break
+			}
+
 			quitCount++
 			if quitCount == 2 {
 				fmt.Println("\nBye!")
 				return
 			} else {
-				if err := saveHistoryToFile(history, "history.txt"); err != nil {
+				if err := saveHistoryToFile(s.history, historyFile); err != nil {
 					log.Fatalf("Failed to save history: %v", err)
 				}
 
 				fmt.Println()
 				fmt.Printf("\033[33m%s\033[0m\n", "Press ctrl + c again to exit.")
+				editor.ForgetRows()
 			}
 
 		case keyboard.KeyEnter:
-			handleEnterKey(&history, &historyIndex, &currentInput, &cursorPosition, env)
+			// Move to the last row of a wrapped line so the output starts below it
+			editor.End()
+			refreshLine(editor, currentPrompt(pendingLines, nil))
+			if !handleEnterKey(s, &pendingLines, editor.Accept()) {
+				return
+			}
 
 		case keyboard.KeyArrowUp, keyboard.KeyArrowDown:
-			handleArrowUpDown(key, &history, &historyIndex, &currentInput, &cursorPosition)
+			handleArrowUpDown(key, s, editor)
+
+		case keyboard.KeyArrowLeft:
+			editor.Left()
 
-		case keyboard.KeyArrowLeft, keyboard.KeyArrowRight:
-			handleArrowLeftRight(key, &currentInput, &cursorPosition)
+		case keyboard.KeyArrowRight:
+			editor.Right()
+
+		case keyboard.KeyHome, keyboard.KeyCtrlA:
+			editor.Home()
+
+		case keyboard.KeyEnd, keyboard.KeyCtrlE:
+			editor.End()
 
 		case keyboard.KeyBackspace, keyboard.KeyBackspace2:
-			handleBackspace(&currentInput, &cursorPosition)
+			editor.Backspace()
+
+		case keyboard.KeyDelete:
+			editor.Delete()
+
+		case keyboard.KeyCtrlW:
+			editor.DeleteWordLeft()
+
+		case keyboard.KeyCtrlU:
+			editor.DeleteToStart()
+
+		case keyboard.KeyCtrlK:
+			editor.DeleteToEnd()
+
+		case keyboard.KeyEsc:
+			// Alt + b and Alt + f arrive as Esc followed by the letter
+			switch char {
+			case 'b':
+				editor.WordLeft()
+			case 'f':
+				editor.WordRight()
+			}
+
+		case keyboard.KeyCtrlR:
+			search = newReverseSearch(editor, s.history)
+
+		case keyboard.KeyTab:
+			handleTab(editor, s.env)
 
 		case keyboard.KeySpace:
-			insertCharacterAtCursor(&currentInput, &cursorPosition, " ")
+			editor.Insert(" ")
 
 		case keyboard.KeyCtrlL:
 			clearScreen()
+			editor.ForgetRows()
 
 		default:
 			if char != 0 {
-				insertCharacterAtCursor(&currentInput, &cursorPosition, string(char))
+				editor.Insert(string(char))
 			}
 		}
 
-		refreshLine(PROMPT, currentInput, cursorPosition)
+		refreshLine(editor, currentPrompt(pendingLines, search))
 	}
 }
 
-func startExec(command string, env *object.Environment, history *[]string, historyIndex *int) {
-	l := lexer.New(command)
-	p := parser.New(l)
-
-	if command == ".clear" {
-		fmt.Print("\033[H\033[2J")
-		return
+func currentPrompt(pendingLines []string, search *reverseSearch) string {
+	if search != nil {
+		return search.prompt()
 	}
 
-	if command == ".exit" {
-		fmt.Println("Bye!")
-		os.Exit(0)
+	if len(pendingLines) != 0 {
+		return CONTINUATION_PROMPT
 	}
 
-	if command == ".clearHistory" {
-		*history = []string{}
-		*historyIndex = 0
-		fmt.Println("History cleared.")
-		return
-	}
+	return PROMPT
+}
 
-	if command == ".help" {
-		// This should be a separate function in the future
-		fmt.Println("Commands:")
-		fmt.Println(" ctrl + c or .exit: Exit the REPL")
-		fmt.Println(" .clear: Clear the terminal screen")
-		fmt.Println(" .clearHistory: Clear the command history")
-		fmt.Println(" .help: Show this help message")
-		fmt.Println()
+/*
+handleEnterKey evaluates the input once it is complete, until then the lines are kept in pendingLines.
+Pasted code arrives one line at a time as well so it is evaluated statement by statement.
+An empty line evaluates whatever is pending, which lets the parser report input that can never be completed.
+*/
+func handleEnterKey(s *session, pendingLines *[]string, line string) bool {
+	fmt.Println()
 
-		return
+	if line != "" {
+		s.addHistory(line)
 	}
 
-	program := p.ParseProgram()
+	input, complete := collectLine(pendingLines, line)
 
-	if parserErrors(p) {
-		return
+	if !complete {
+		return true
 	}
 
-	eval := evaluator.Eval(program, env)
-
-	if eval != nil {
-		if eval.Type() == object.STRING_OBJ {
-			fmt.Printf("'%s'\n", eval.Inspect())
-		} else {
-			fmt.Println(eval.Inspect())
-		}
-	} else {
-		fmt.Println("null")
-	}
+	return s.exec(input)
 }
 
-func handleEnterKey(history *[]string, historyIndex *int, currentInput *string, cursorPosition *int, env *object.Environment) {
-	fmt.Println()
-	if *currentInput != "" {
-		*history = append(*history, *currentInput)
-		*historyIndex = len(*history)
-
-		startExec(*currentInput, env, history, historyIndex)
-
-		*currentInput = ""
-		*cursorPosition = 0
+// collectLine adds a line to the pending ones and returns the whole input once it is complete
+func collectLine(pendingLines *[]string, line string) (string, bool) {
+	if line == "" && len(*pendingLines) == 0 {
+		return "", false
 	}
-}
 
-func handleArrowUpDown(key keyboard.Key, history *[]string, historyIndex *int, currentInput *string, cursorPosition *int) {
-	if key == keyboard.KeyArrowUp && *historyIndex > 0 { // we check if historyIndex > 0 to prevent index out of range error
-		*historyIndex--
-	} else if key == keyboard.KeyArrowDown && *historyIndex < len(*history)-1 {
-		*historyIndex++
-	}
+	*pendingLines = append(*pendingLines, line)
+	input := strings.Join(*pendingLines, "\n")
 
-	if *historyIndex == len(*history) {
-		*currentInput = ""
-		*cursorPosition = 0
+	if line != "" && IsIncomplete(input) {
+		return "", false
+	}
 
-	} else if key == keyboard.KeyArrowUp || (key == keyboard.KeyArrowDown && *historyIndex < len(*history)) {
-		*currentInput = (*history)[*historyIndex]
-		*cursorPosition = len(*currentInput)
+	*pendingLines = []string{}
 
-	} else if key == keyboard.KeyArrowDown && *historyIndex == len(*history) {
-		// This is to handle the case when the user presses the down arrow key after reaching the end of the history
-		*currentInput = ""
-		*cursorPosition = 0
-	}
+	return input, true
 }
 
-func handleArrowLeftRight(key keyboard.Key, currentInput *string, cursorPosition *int) {
-	if key == keyboard.KeyArrowLeft && *cursorPosition > 0 {
-		*cursorPosition--
-	} else if key == keyboard.KeyArrowRight && *cursorPosition < len(*currentInput) {
-		*cursorPosition++
+func handleArrowUpDown(key keyboard.Key, s *session, editor *Editor) {
+	if key == keyboard.KeyArrowUp && s.historyIndex > 0 { // we check if historyIndex > 0 to prevent index out of range error
+		s.historyIndex--
+	} else if key == keyboard.KeyArrowDown && s.historyIndex < len(s.history) {
+		s.historyIndex++
 	}
-}
-
-func handleBackspace(currentInput *string, cursorPosition *int) {
-	if *cursorPosition > 0 {
-		/*
-			** We use `cursorPosition-1` because we want to delete the character before the cursor
-			and then move the cursor back one position.
-
-			==> e.g. if the cursor is at position 5, we want to delete the character at position 4
-				and then move the cursor back to position 4.
 
-			** Not only this but also we want to keep anything after the cursor position.
-
-			==> e.g. if the currentInput is "hello world" and the cursor is at position 5,
-				we want to delete the character at position 4 and then move the cursor back to position 4
-				and keep the rest of the string after the cursor position. so the result will be "hell world".
-		*/
-
-		*currentInput = (*currentInput)[:*cursorPosition-1] + (*currentInput)[*cursorPosition:]
-		*cursorPosition--
+	if s.historyIndex == len(s.history) {
+		// Going down past the newest entry gives an empty line
+		editor.Set("")
+	} else {
+		editor.Set(s.history[s.historyIndex])
 	}
 }
 
-func insertCharacterAtCursor(currentInput *string, cursorPosition *int, char string) {
-	// This function inserts a character at the cursor position and then moves the cursor forward one position.
-	*currentInput = (*currentInput)[:*cursorPosition] + char + (*currentInput)[*cursorPosition:]
-	*cursorPosition++
-}
-
 func clearScreen() {
 	fmt.Print("\033[H\033[2J")
 }
 
-func refreshLine(prompt string, currentInput string, cursorPosition int) {
-	clearCurrentLine()
-	fmt.Print(prompt)
-	fmt.Print(currentInput)
-
-	// We only need to move the cursor if the cursor is not at the end of the line
-	if len(currentInput) > cursorPosition {
-		moveCursorLeft(len(currentInput) - cursorPosition)
-	}
+func refreshLine(editor *Editor, prompt string) {
+	editor.Render(os.Stdout, prompt, terminalWidth())
 }
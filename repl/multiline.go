@@ -0,0 +1,52 @@
+package repl
+
+import (
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/parser"
+	"github.com/Mostafa-DE/delang/token"
+)
+
+/*
+IsIncomplete reports whether the input needs more lines before it can be evaluated, that is when:
+
+- A bracket `{ ( [` is still open.
+- A string is not closed.
+- The parser ran into the end of the input, e.g `let x =` or `if x > 1:`.
+
+Input with more closing brackets than opening ones is complete so that the parser can report the error.
+*/
+func IsIncomplete(input string) bool {
+	l := lexer.New(input)
+	depth := 0
+
+	var tok token.Token
+
+	for tok = l.NextToken(); tok.Type != token.EOFILE; tok = l.NextToken() {
+		switch tok.Type {
+		case token.LEFTPAR, token.LEFTBRAC, token.LEFTSQPRAC:
+			depth++
+
+		case token.RIGHTPAR, token.RIGHTBRAC, token.RIGHTSQPRAC:
+			depth--
+		}
+	}
+
+	if l.EndsInString() || depth > 0 {
+		return true
+	}
+
+	if depth < 0 {
+		return false
+	}
+
+	p := parser.New(lexer.New(input))
+	p.ParseProgram()
+
+	for _, err := range p.DetailedErrors() {
+		if err.Line == tok.Line && err.Column == tok.Column {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,81 @@
+package repl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eiannone/keyboard"
+)
+
+// SearchHistory returns the index of the newest entry at or before from that contains query, or -1
+func SearchHistory(history []string, query string, from int) int {
+	if from >= len(history) {
+		from = len(history) - 1
+	}
+
+	for idx := from; idx >= 0; idx-- {
+		if strings.Contains(history[idx], query) {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// reverseSearch is the state of Ctrl + r, the match is shown in the editor while the query is typed
+type reverseSearch struct {
+	query    []rune
+	match    int
+	original string // The line before the search started, restored when the search is cancelled
+}
+
+func newReverseSearch(editor *Editor, history []string) *reverseSearch {
+	return &reverseSearch{match: len(history), original: editor.String()}
+}
+
+func (s *reverseSearch) prompt() string {
+	return fmt.Sprintf("(reverse-i-search)`%s': ", string(s.query))
+}
+
+// update searches the query from the given entry backwards and shows the match, if any
+func (s *reverseSearch) update(editor *Editor, history []string, from int) {
+	if idx := SearchHistory(history, string(s.query), from); idx != -1 {
+		s.match = idx
+		editor.Set(history[idx])
+	}
+}
+
+/*
+handleKey handles a key while Ctrl + r is active and reports whether the search is still active.
+Typing refines the query, Ctrl + r again looks for an older match and Ctrl + g restores the line.
+Any other key keeps the match in the editor and is then handled as usual.
+*/
+func (s *reverseSearch) handleKey(char rune, key keyboard.Key, editor *Editor, history []string) (active bool, consumed bool) {
+	switch {
+	case key == keyboard.KeyCtrlR:
+		s.update(editor, history, s.match-1)
+
+	case key == keyboard.KeyCtrlG:
+		editor.Set(s.original)
+		return false, true
+
+	case key == keyboard.KeyBackspace || key == keyboard.KeyBackspace2:
+		if len(s.query) > 0 {
+			s.query = s.query[:len(s.query)-1]
+			s.update(editor, history, len(history)-1)
+		}
+
+	case char != 0 || key == keyboard.KeySpace:
+		if char == 0 {
+			char = ' '
+		}
+
+		s.query = append(s.query, char)
+		s.update(editor, history, s.match)
+
+	default:
+		return false, false
+	}
+
+	return true, true
+}
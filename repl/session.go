@@ -0,0 +1,258 @@
+package repl
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/evaluator"
+	"github.com/Mostafa-DE/delang/introspect"
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/object"
+	"github.com/Mostafa-DE/delang/parser"
+)
+
+// session is the state of the REPL, the interactive and the line based REPL both drive one
+type session struct {
+	env          *object.Environment
+	out          io.Writer
+	history      []string
+	historyIndex int
+	inputs       []string // The inputs that were evaluated without errors, `.save` writes them
+}
+
+func newSession(out io.Writer, history []string) *session {
+	s := &session{out: out, history: history, historyIndex: len(history)}
+	s.reset()
+
+	return s
+}
+
+func (s *session) reset() {
+	s.env = object.NewEnvironment()
+	s.env.Runtime().Output = s.out
+	s.inputs = []string{}
+}
+
+func (s *session) addHistory(line string) {
+	s.history = AddToHistory(s.history, line)
+	s.historyIndex = len(s.history)
+}
+
+// exec runs a command or evaluates DE code, it returns false when the user asked to exit
+func (s *session) exec(command string) bool {
+	name, arg, _ := strings.Cut(strings.TrimSpace(command), " ")
+	arg = strings.TrimSpace(arg)
+
+	switch name {
+	case ".clear":
+		fmt.Fprint(s.out, "\033[H\033[2J")
+
+	case ".exit":
+		fmt.Fprintln(s.out, "Bye!")
+		return false
+
+	case ".clearHistory":
+		s.history = []string{}
+		s.historyIndex = 0
+		fmt.Fprintln(s.out, "History cleared.")
+
+	case ".doc":
+		if doc, ok := Doc(arg, s.env); ok {
+			fmt.Fprintln(s.out, doc)
+		} else {
+			fmt.Fprintf(s.out, "No documentation for '%s'\n", arg)
+		}
+
+	case ".env":
+		s.printEnv()
+
+	case ".save":
+		s.save(arg)
+
+	case ".load":
+		s.load(arg)
+
+	case ".tokens":
+		fmt.Fprint(s.out, introspect.FormatTokens(introspect.Tokens(arg)))
+
+	case ".ast":
+		if program, ok := s.parse(arg); ok {
+			fmt.Fprint(s.out, introspect.Tree(program))
+		}
+
+	case ".type":
+		if result, ok := s.eval(arg); ok {
+			s.inputs = append(s.inputs, arg)
+			fmt.Fprintln(s.out, typeOf(result))
+		}
+
+	case ".time":
+		var timing introspect.Timing
+
+		result, ok := s.evalWith(arg, func(program *ast.Program) object.Object {
+			result, t := introspect.Time(program, s.env)
+			timing = t
+
+			return result
+		})
+
+		if ok {
+			s.inputs = append(s.inputs, arg)
+			s.print(result)
+		}
+
+		if timing.Duration != 0 {
+			fmt.Fprintln(s.out, timing)
+		}
+
+	case ".reset":
+		s.reset()
+		fmt.Fprintln(s.out, "Environment reset.")
+
+	case ".help":
+		// This should be a separate function in the future
+		fmt.Fprintln(s.out, "Commands:")
+		fmt.Fprintln(s.out, " ctrl + c or .exit: Exit the REPL")
+		fmt.Fprintln(s.out, " .clear: Clear the terminal screen")
+		fmt.Fprintln(s.out, " .clearHistory: Clear the command history")
+		fmt.Fprintln(s.out, " .doc name: Show the documentation of a builtin or the signature of a function")
+		fmt.Fprintln(s.out, " .env: List the variables of the session with their types")
+		fmt.Fprintln(s.out, " .save file.de: Save the inputs of the session that ran without errors")
+		fmt.Fprintln(s.out, " .load file.de: Run a file in the session")
+		fmt.Fprintln(s.out, " .reset: Forget every variable of the session")
+		fmt.Fprintln(s.out, " .tokens code: Show the tokens of the code")
+		fmt.Fprintln(s.out, " .ast code: Show the syntax tree of the code")
+		fmt.Fprintln(s.out, " .type code: Show the type of the result of the code")
+		fmt.Fprintln(s.out, " .time code: Show how long the code takes to run and how much it allocates")
+		fmt.Fprintln(s.out, " .help: Show this help message")
+		fmt.Fprintln(s.out, " tab: Complete names, keywords and commands")
+		fmt.Fprintln(s.out, " ctrl + r: Search the history")
+		fmt.Fprintln(s.out)
+
+	default:
+		if result, ok := s.eval(command); ok {
+			s.inputs = append(s.inputs, command)
+			s.print(result)
+		}
+	}
+
+	return true
+}
+
+func (s *session) parse(source string) (*ast.Program, bool) {
+	p := parser.New(lexer.New(source))
+	program := p.ParseProgram()
+
+	return program, !parserErrors(s.out, p)
+}
+
+// eval parses and evaluates source in the environment of the session, errors are printed
+func (s *session) eval(source string) (object.Object, bool) {
+	return s.evalWith(source, func(program *ast.Program) object.Object {
+		return evaluator.Eval(program, s.env)
+	})
+}
+
+func (s *session) evalWith(source string, evaluate func(program *ast.Program) object.Object) (object.Object, bool) {
+	program, ok := s.parse(source)
+
+	if !ok {
+		return nil, false
+	}
+
+	result := evaluate(program)
+
+	if err, ok := result.(*object.Error); ok {
+		fmt.Fprintln(s.out, err.Inspect())
+		return nil, false
+	}
+
+	return result, true
+}
+
+func (s *session) print(result object.Object) {
+	if result == nil {
+		fmt.Fprintln(s.out, "null")
+	} else if result.Type() == object.STRING_OBJ {
+		fmt.Fprintf(s.out, "'%s'\n", result.Inspect())
+	} else {
+		fmt.Fprintln(s.out, result.Inspect())
+	}
+}
+
+func typeOf(result object.Object) string {
+	if result == nil {
+		return object.NULL_OBJ
+	}
+
+	if function, ok := result.(*object.Function); ok && function.Name != "" {
+		return fmt.Sprintf("%s (%s)", function.Type(), function.Name)
+	}
+
+	return result.Type()
+}
+
+func (s *session) printEnv() {
+	names := s.env.Names()
+
+	if len(names) == 0 {
+		fmt.Fprintln(s.out, "No variables.")
+		return
+	}
+
+	for _, name := range names {
+		value, _ := s.env.Get(name)
+		kind := "let"
+
+		if s.env.IsConst(name) {
+			kind = "const"
+		}
+
+		fmt.Fprintf(s.out, "%s %s: %s\n", kind, name, value.Type())
+	}
+}
+
+func (s *session) save(filename string) {
+	if filename == "" {
+		fmt.Fprintln(s.out, "Usage: .save file.de")
+		return
+	}
+
+	content := ""
+
+	for _, input := range s.inputs {
+		content += input + "\n"
+	}
+
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		fmt.Fprintf(s.out, "Failed to save the session: %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(s.out, "Saved %d inputs to %s\n", len(s.inputs), filename)
+}
+
+// load runs a file in the environment of the session, the file counts as one input for `.save`
+func (s *session) load(filename string) {
+	if filename == "" {
+		fmt.Fprintln(s.out, "Usage: .load file.de")
+		return
+	}
+
+	content, err := os.ReadFile(filename)
+
+	if err != nil {
+		fmt.Fprintf(s.out, "Failed to load the file: %v\n", err)
+		return
+	}
+
+	source := strings.TrimRight(string(content), "\n")
+
+	if result, ok := s.eval(source); ok {
+		s.inputs = append(s.inputs, source)
+		s.print(result)
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !unix
+
+package repl
+
+func terminalWidth() int {
+	return DEFAULT_WIDTH
+}
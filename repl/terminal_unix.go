@@ -0,0 +1,20 @@
+//go:build unix
+
+package repl
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// terminalWidth returns the number of columns of the terminal, it is read on every redraw to follow resizes
+func terminalWidth() int {
+	size, err := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
+
+	if err != nil || size.Col == 0 {
+		return DEFAULT_WIDTH
+	}
+
+	return int(size.Col)
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Mostafa-DE/delang/evaluator"
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/object"
+	"github.com/Mostafa-DE/delang/parser"
+	"github.com/Mostafa-DE/delang/repl"
+)
+
+func newEnv(input string) *object.Environment {
+	env := object.NewEnvironment()
+	evaluator.Eval(parser.New(lexer.New(input)).ParseProgram(), env)
+
+	return env
+}
+
+func TestComplete(t *testing.T) {
+	env := newEnv("let total = 1; let totalPrice = 2; let add = fun(a, b) { a + b };")
+	local := object.NewLocalEnvironment(env)
+	local.Set("lenient", &object.Integer{Value: 1}, false)
+
+	tests := []struct {
+		input      string
+		cursor     int
+		env        *object.Environment
+		start      int
+		candidates []string
+	}{
+		{"tot", 3, env, 0, []string{"total", "totalPrice"}},
+		{"logs(tot", 8, env, 5, []string{"total", "totalPrice"}},
+		{"tot + 1", 3, env, 0, []string{"total", "totalPrice"}},
+		{"le", 2, local, 0, []string{"len", "lenient", "let"}},
+		{"le", 2, env, 0, []string{"len", "let"}},
+		{"ret", 3, env, 0, []string{"return"}},
+		{".he", 3, env, 0, []string{".help"}},
+		{"  .cl", 5, env, 2, []string{".clear", ".clearHistory"}},
+		{"x.cl", 4, env, 2, []string{}},
+		{"zzz", 3, env, 0, []string{}},
+		{"", 0, env, 0, nil},
+	}
+
+	for _, tt := range tests {
+		start, candidates := repl.Complete(tt.input, tt.cursor, tt.env)
+
+		if start != tt.start {
+			t.Errorf("Complete(%q) start wrong. expected=%d, got=%d", tt.input, tt.start, start)
+		}
+
+		if !reflect.DeepEqual(candidates, tt.candidates) {
+			t.Errorf("Complete(%q) candidates wrong. expected=%v, got=%v", tt.input, tt.candidates, candidates)
+		}
+	}
+}
+
+func TestDoc(t *testing.T) {
+	env := newEnv("let add = fun(a, b) { a + b }; let x = 1;")
+
+	tests := []struct {
+		name     string
+		expected string
+		found    bool
+	}{
+		{"len", "len(...): builtin function\nReturns the length of a string or an array", true},
+		{"add", "add = fun(a, b)", true},
+		{"x", "x: INTEGER", true},
+		{"missing", "", false},
+	}
+
+	for _, tt := range tests {
+		doc, found := repl.Doc(tt.name, env)
+
+		if found != tt.found || doc != tt.expected {
+			t.Errorf("Doc(%q) wrong. expected=(%q, %t), got=(%q, %t)", tt.name, tt.expected, tt.found, doc, found)
+		}
+	}
+}
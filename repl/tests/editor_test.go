@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Mostafa-DE/delang/repl"
+)
+
+func TestEditorUnicode(t *testing.T) {
+	editor := repl.NewEditor()
+
+	editor.Insert("مرحبا")
+	editor.Insert(" 👋")
+	editor.Left()
+	editor.Left()
+	editor.Backspace()
+	editor.Insert("!")
+
+	if editor.String() != "مرحب! 👋" {
+		t.Fatalf("Line wrong. got=%q", editor.String())
+	}
+
+	if editor.Cursor() != 5 || editor.ByteCursor() != len("مرحب!") {
+		t.Errorf("Cursor wrong. got=%d runes, %d bytes", editor.Cursor(), editor.ByteCursor())
+	}
+
+	editor.End()
+	editor.Backspace()
+	editor.Home()
+	editor.Delete()
+
+	if editor.String() != "رحب! " {
+		t.Errorf("Line wrong after deleting. got=%q", editor.String())
+	}
+}
+
+func TestEditorWords(t *testing.T) {
+	tests := []struct {
+		desc     string
+		edit     func(e *repl.Editor)
+		expected string
+		cursor   int
+	}{
+		{"Ctrl + w deletes the word before the cursor", (*repl.Editor).DeleteWordLeft, "let total = add(first, ", 23},
+		{"Ctrl + u deletes before the cursor", (*repl.Editor).DeleteToStart, "", 0},
+		{"Ctrl + k deletes after the cursor", func(e *repl.Editor) { e.Home(); e.WordRight(); e.DeleteToEnd() }, "let", 3},
+		{"Alt + b moves to the start of the word", func(e *repl.Editor) { e.WordLeft(); e.WordLeft() }, "let total = add(first, second", 16},
+		{"Alt + f moves past the end of the word", func(e *repl.Editor) { e.Home(); e.WordRight(); e.WordRight() }, "let total = add(first, second", 9},
+	}
+
+	for _, tt := range tests {
+		editor := repl.NewEditor()
+		editor.Set("let total = add(first, second")
+
+		tt.edit(editor)
+
+		if editor.String() != tt.expected || editor.Cursor() != tt.cursor {
+			t.Errorf("%s. expected=(%q, %d), got=(%q, %d)", tt.desc, tt.expected, tt.cursor, editor.String(), editor.Cursor())
+		}
+	}
+}
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"let x", 5},
+		{"مرحبا", 5},
+		{"日本", 4},
+		{"👋", 2},
+		{"é", 1},
+	}
+
+	for _, tt := range tests {
+		if got := repl.StringWidth(tt.input); got != tt.expected {
+			t.Errorf("StringWidth(%q) wrong. expected=%d, got=%d", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestEditorRender(t *testing.T) {
+	editor := repl.NewEditor()
+	editor.Set("abcdefgh")
+
+	var out bytes.Buffer
+
+	// The prompt and the line take 12 columns so they wrap on 2 rows of a 10 columns terminal
+	editor.Render(&out, ">>> ", 10)
+
+	if out.String() != "\r\033[J>>> abcdefgh\r\033[2C" {
+		t.Errorf("Render wrong. got=%q", out.String())
+	}
+
+	out.Reset()
+	editor.Home()
+	editor.Render(&out, ">>> ", 10)
+
+	// Back to the first row to redraw, then from the end of the line up to the cursor
+	if out.String() != "\033[1A\r\033[J>>> abcdefgh\033[1A\r\033[4C" {
+		t.Errorf("Render wrong. got=%q", out.String())
+	}
+
+	out.Reset()
+	editor.Set("abcdef")
+	editor.Render(&out, ">>> ", 10)
+
+	// The line ends on the last column so the wrap is forced
+	if out.String() != "\r\033[J>>> abcdef\r\n\r" {
+		t.Errorf("Render wrong. got=%q", out.String())
+	}
+}
+
+func TestSearchHistory(t *testing.T) {
+	history := []string{"let x = 1;", "logs(x);", "let y = x + 1;", "logs(y);"}
+
+	tests := []struct {
+		query    string
+		from     int
+		expected int
+	}{
+		{"logs", 10, 3},
+		{"logs", 2, 1},
+		{"let", 3, 2},
+		{"x = 1", 3, 0},
+		{"missing", 3, -1},
+		{"let", -1, -1},
+	}
+
+	for _, tt := range tests {
+		if got := repl.SearchHistory(history, tt.query, tt.from); got != tt.expected {
+			t.Errorf("SearchHistory(%q, %d) wrong. expected=%d, got=%d", tt.query, tt.from, tt.expected, got)
+		}
+	}
+}
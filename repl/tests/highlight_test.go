@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Mostafa-DE/delang/repl"
+)
+
+// Short colors keep the expectations readable
+var theme = repl.Theme{
+	repl.KEYWORD_COLOR: "K",
+	repl.STRING_COLOR:  "S",
+	repl.NUMBER_COLOR:  "N",
+	repl.COMMENT_COLOR: "C",
+	repl.ERROR_COLOR:   "E",
+}
+
+func color(code string, text string) string {
+	return "\033[" + code + "m" + text + "\033[0m"
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		desc     string
+		previous []string
+		line     string
+		expected string
+	}{
+		{
+			"It should color keywords, numbers and strings",
+			nil,
+			"let x = 1.5 + 2; logs('hi');",
+			color("K", "let") + " x = " + color("N", "1.5") + " + " + color("N", "2") + "; logs(" + color("S", "'hi'") + ");",
+		},
+		{
+			"It should color comments",
+			nil,
+			"x = 1; // set x",
+			"x = " + color("N", "1") + "; " + color("C", "// set x"),
+		},
+		{
+			"It should color a string that is not closed yet",
+			nil,
+			"logs(\"abc",
+			"logs(" + color("S", "\"abc"),
+		},
+		{
+			"It should color mismatched brackets",
+			nil,
+			"foo(1]) }",
+			"foo(" + color("N", "1") + color("E", "]") + ") " + color("E", "}"),
+		},
+		{
+			"It should match brackets opened on earlier lines",
+			[]string{"let f = fun() {", "    if x: {"},
+			"    } };",
+			"    } };",
+		},
+		{
+			"It should keep multi-byte characters",
+			nil,
+			"logs('مرحبا') // 👋",
+			"logs(" + color("S", "'مرحبا'") + ") " + color("C", "// 👋"),
+		},
+		{
+			"It should color true, false and the logical operators as keywords",
+			nil,
+			"true and false",
+			color("K", "true") + " " + color("K", "and") + " " + color("K", "false"),
+		},
+	}
+
+	for _, tt := range tests {
+		if got := repl.Highlight(tt.previous, tt.line, theme); got != tt.expected {
+			t.Errorf("%s. expected=%q, got=%q", tt.desc, tt.expected, got)
+		}
+	}
+
+	if got := repl.Highlight(nil, "let x = 1;", nil); got != "let x = 1;" {
+		t.Errorf("Without a theme the line should be left as it is. got=%q", got)
+	}
+}
+
+func TestParseTheme(t *testing.T) {
+	theme := repl.ParseTheme("keyword=1;34:comment=:unknown=1")
+
+	if theme[repl.KEYWORD_COLOR] != "1;34" || theme[repl.COMMENT_COLOR] != "" || theme[repl.STRING_COLOR] != repl.DefaultTheme[repl.STRING_COLOR] {
+		t.Errorf("Theme wrong. got=%v", theme)
+	}
+
+	if _, ok := theme["unknown"]; ok {
+		t.Errorf("Unknown names should be ignored")
+	}
+}
+
+func TestLoadThemeNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	if theme := repl.LoadTheme(); theme != nil {
+		t.Errorf("NO_COLOR should turn colors off. got=%v", theme)
+	}
+}
+
+func TestRenderHighlighted(t *testing.T) {
+	editor := repl.NewEditor()
+	editor.Highlight = func(line string) string { return repl.Highlight(nil, line, theme) }
+	editor.Set("let x")
+	editor.Home()
+
+	var out bytes.Buffer
+	editor.Render(&out, ">>> ", 80)
+
+	// The colors are written but the cursor is placed by the visible text only
+	expected := "\r\033[J>>> " + color("K", "let") + " x\r\033[4C"
+
+	if out.String() != expected {
+		t.Errorf("Render wrong. expected=%q, got=%q", expected, out.String())
+	}
+}
@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Mostafa-DE/delang/repl"
+)
+
+func TestRunLines(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected string
+	}{
+		{
+			"It should keep the environment across lines",
+			"let x = 1 + 2;\nx * 2\n",
+			"null\n6\n",
+		},
+		{
+			"It should evaluate statements that span several lines",
+			"let add = fun(a, b) {\n    return a + b;\n};\nlogs(add(1, 2));\n",
+			"null\n3\nnull\n",
+		},
+		{
+			"It should print strings with quotes",
+			"'hello'\n",
+			"'hello'\n",
+		},
+		{
+			"It should run commands",
+			"let add = fun(a, b) { a + b };\n.doc add\n",
+			"null\nadd = fun(a, b)\n",
+		},
+		{
+			"It should stop at .exit",
+			"1\n.exit\n2\n",
+			"1\nBye!\n",
+		},
+		{
+			"It should report incomplete input at the end",
+			"let x = [1, 2\n",
+			"Error parsing program:\n",
+		},
+		{
+			"It should report errors and carry on",
+			"1 + true\n2\n",
+			"ERROR: type mismatch: INTEGER + BOOLEAN\n2\n",
+		},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+
+		if err := repl.RunLines(strings.NewReader(tt.input), &out, repl.Options{Quiet: true}); err != nil {
+			t.Fatalf("%s: RunLines returned an error: %v", tt.desc, err)
+		}
+
+		if !strings.HasPrefix(out.String(), tt.expected) {
+			t.Errorf("%s. expected=%q, got=%q", tt.desc, tt.expected, out.String())
+		}
+	}
+}
+
+func TestRunLinesPrompts(t *testing.T) {
+	var out bytes.Buffer
+
+	repl.RunLines(strings.NewReader("let f = fun() {\n1\n};\n"), &out, repl.Options{})
+
+	expected := "Hi! Welcome to DE\nType '.help' to see a list of commands.\n\n>>> ... ... null\n>>> "
+
+	if out.String() != expected {
+		t.Errorf("Output wrong. expected=%q, got=%q", expected, out.String())
+	}
+}
@@ -0,0 +1,39 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Mostafa-DE/delang/repl"
+)
+
+func TestIsIncomplete(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"let x = 5;", false},
+		{"logs('hello');", false},
+		{"let add = fun(a, b) {", true},
+		{"let add = fun(a, b) {\n    return a + b;", true},
+		{"let add = fun(a, b) {\n    return a + b;\n};", false},
+		{"let arr = [1, 2,", true},
+		{"logs(1,", true},
+		{"let x = {'a': 1", true},
+		{"during x < 3: {\n    x = x + 1;\n}", false},
+		{"let s = 'hello", true},
+		{"let s = '{';", false},
+		{"// {", false},
+		{"let x =", true},
+		{"if x > 1:", true},
+		{"let x = 1 +", true},
+		{"}", false},
+		{"let = 5;", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := repl.IsIncomplete(tt.input); got != tt.expected {
+			t.Errorf("IsIncomplete(%q) wrong. expected=%t, got=%t", tt.input, tt.expected, got)
+		}
+	}
+}
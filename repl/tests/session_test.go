@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Mostafa-DE/delang/repl"
+)
+
+func runLines(t *testing.T, input string) string {
+	var out bytes.Buffer
+
+	if err := repl.RunLines(strings.NewReader(input), &out, repl.Options{Quiet: true}); err != nil {
+		t.Fatalf("RunLines returned an error: %v", err)
+	}
+
+	return out.String()
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "session.de")
+
+	output := runLines(t, fmt.Sprintf("let x = 2;\nlet y = x + true;\nconst double = fun(n) {\n    n * 2\n};\n.save %s\n", file))
+
+	if !strings.HasSuffix(output, "Saved 2 inputs to "+file+"\n") {
+		t.Errorf("Save output wrong. got=%q", output)
+	}
+
+	content, err := os.ReadFile(file)
+
+	if err != nil {
+		t.Fatalf("Failed to read the saved session: %v", err)
+	}
+
+	expected := "let x = 2;\nconst double = fun(n) {\n    n * 2\n};\n"
+
+	if string(content) != expected {
+		t.Errorf("Saved session wrong. expected=%q, got=%q", expected, string(content))
+	}
+
+	output = runLines(t, fmt.Sprintf(".load %s\ndouble(x)\n", file))
+
+	if output != "null\n4\n" {
+		t.Errorf("Load output wrong. got=%q", output)
+	}
+
+	output = runLines(t, ".load missing.de\n.save\n")
+
+	if !strings.HasPrefix(output, "Failed to load the file: ") || !strings.HasSuffix(output, "Usage: .save file.de\n") {
+		t.Errorf("Errors wrong. got=%q", output)
+	}
+}
+
+func TestEnvAndReset(t *testing.T) {
+	output := runLines(t, "let name = 'de';\nconst pi = 3.14;\nlet items = [1];\n.env\n.reset\n.env\nname\n")
+
+	expected := "null\nnull\nnull\n" +
+		"let items: ARRAY\nlet name: STRING\nconst pi: FLOAT\n" +
+		"Environment reset.\nNo variables.\n" +
+		"ERROR: identifier not found: name\n"
+
+	if output != expected {
+		t.Errorf("Output wrong. expected=%q, got=%q", expected, output)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	history := []string{}
+
+	for _, entry := range []string{"a", "b", "a", "c", "b"} {
+		history = repl.AddToHistory(history, entry)
+	}
+
+	if !reflect.DeepEqual(history, []string{"a", "c", "b"}) {
+		t.Errorf("Duplicates should keep the newest copy. got=%v", history)
+	}
+
+	for idx := 0; idx < repl.HISTORY_SIZE+10; idx++ {
+		history = repl.AddToHistory(history, fmt.Sprint(idx))
+	}
+
+	if len(history) != repl.HISTORY_SIZE || history[len(history)-1] != fmt.Sprint(repl.HISTORY_SIZE+9) {
+		t.Errorf("History should keep the newest %d entries. got=%d entries", repl.HISTORY_SIZE, len(history))
+	}
+
+	t.Setenv("XDG_STATE_HOME", "/tmp/state")
+
+	if path := repl.HistoryPath(); path != "/tmp/state/de/history" {
+		t.Errorf("HistoryPath wrong. got=%q", path)
+	}
+}
+
+func TestIntrospectionCommands(t *testing.T) {
+	output := runLines(t, "let x = 'de';\n.type x\n.tokens x + 1\n.ast -x\n")
+
+	expected := "null\nSTRING\n" +
+		"1:1\tIDENT\t\"x\"\n1:3\t+\t\"+\"\n1:5\tINT\t\"1\"\n" +
+		"Program\n└── ExpressionStatement\n    └── PrefixExpression -\n        └── Identifier x\n"
+
+	if output != expected {
+		t.Errorf("Output wrong. expected=%q, got=%q", expected, output)
+	}
+
+	output = runLines(t, "fun greet() { 1 }\n.type greet\n")
+
+	if output != "null\nFUNCTION (greet)\n" {
+		t.Errorf(".type of a named function wrong. got=%q", output)
+	}
+
+	output = runLines(t, ".time 1 + 2\n")
+
+	if !strings.HasPrefix(output, "3\n") || !strings.Contains(output, "allocations") {
+		t.Errorf(".time output wrong. got=%q", output)
+	}
+}
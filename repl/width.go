@@ -0,0 +1,70 @@
+package repl
+
+import "unicode"
+
+// Ranges of runes that terminals draw two columns wide, mostly CJK and emoji
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F},
+	{0x231A, 0x231B},
+	{0x2329, 0x232A},
+	{0x23E9, 0x23EC},
+	{0x25FD, 0x25FE},
+	{0x2614, 0x2615},
+	{0x2648, 0x2653},
+	{0x26AA, 0x26AB},
+	{0x26BD, 0x26BE},
+	{0x26C4, 0x26C5},
+	{0x26F2, 0x26F5},
+	{0x2705, 0x2705},
+	{0x270A, 0x270B},
+	{0x2728, 0x2728},
+	{0x274C, 0x274C},
+	{0x2753, 0x2755},
+	{0x2795, 0x2797},
+	{0x2B1B, 0x2B1C},
+	{0x2E80, 0x303E},
+	{0x3041, 0x33FF},
+	{0x3400, 0x4DBF},
+	{0x4E00, 0x9FFF},
+	{0xA000, 0xA4CF},
+	{0xAC00, 0xD7A3},
+	{0xF900, 0xFAFF},
+	{0xFE30, 0xFE4F},
+	{0xFF00, 0xFF60},
+	{0xFFE0, 0xFFE6},
+	{0x1F300, 0x1F64F},
+	{0x1F680, 0x1F6FF},
+	{0x1F900, 0x1F9FF},
+	{0x1FA70, 0x1FAFF},
+	{0x20000, 0x3FFFD},
+}
+
+// RuneWidth returns the number of terminal columns a rune takes
+func RuneWidth(r rune) int {
+	if r == 0 || unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || unicode.Is(unicode.Cf, r) {
+		return 0 // Combining marks and joiners are drawn over the previous rune
+	}
+
+	for _, wide := range wideRanges {
+		if r < wide[0] {
+			break
+		}
+
+		if r <= wide[1] {
+			return 2
+		}
+	}
+
+	return 1
+}
+
+// StringWidth returns the number of terminal columns a string takes
+func StringWidth(s string) int {
+	width := 0
+
+	for _, r := range s {
+		width += RuneWidth(r)
+	}
+
+	return width
+}
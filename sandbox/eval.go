@@ -0,0 +1,230 @@
+/*
+Package sandbox runs untrusted DE programs with time, step, output and call depth limits.
+It is shared by `de serve` and the WebAssembly build of the playground.
+*/
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Mostafa-DE/delang/ast"
+	"github.com/Mostafa-DE/delang/evaluator"
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/object"
+	"github.com/Mostafa-DE/delang/parser"
+)
+
+// Event types
+const (
+	OUTPUT_EVENT = "output" // Something the program printed with `logs`
+	RESULT_EVENT = "result" // The value of the program, always the last event when it ran without errors
+	ERROR_EVENT  = "error"  // A syntax error, a runtime error or a limit that was hit, always the last event
+)
+
+// Request is the body of an evaluation request
+type Request struct {
+	Source string `json:"source"`
+	Input  string `json:"input,omitempty"` // What `input` reads, one answer per line
+}
+
+// Event is one message streamed back while a program runs
+type Event struct {
+	Type      string `json:"type"`
+	Text      string `json:"text,omitempty"`
+	Value     string `json:"value,omitempty"`
+	ValueType string `json:"valueType,omitempty"`
+	Message   string `json:"message,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+}
+
+// Limits bound what a single program can use, zero means no limit
+type Limits struct {
+	Timeout   time.Duration
+	MaxSteps  int // The number of AST nodes evaluated
+	MaxOutput int // The number of bytes printed
+	MaxDepth  int // The number of nested function calls, deep recursion would crash the server otherwise
+
+	// A single operation runs between two steps, these bound the values it can build so it ends quickly
+	MaxIntegerBits   int
+	MaxStringLength  int // In bytes, also bounds the text of str(), logs and join
+	MaxRangeLength   int // The number of elements of range()
+	MaxDecimalDigits int // The digits `+`, `-` and `*` write out to compute a decimal
+}
+
+var DefaultLimits = Limits{
+	Timeout:          5 * time.Second,
+	MaxSteps:         10_000_000,
+	MaxOutput:        1 << 20,
+	MaxDepth:         1000,
+	MaxIntegerBits:   1 << 16,
+	MaxStringLength:  1 << 20,
+	MaxRangeLength:   1_000_000,
+	MaxDecimalDigits: 20_000,
+}
+
+var (
+	ErrTimeout   = errors.New("time limit exceeded")
+	ErrSteps     = errors.New("step limit exceeded")
+	ErrOutput    = errors.New("output limit exceeded")
+	ErrDepth     = errors.New("maximum call depth exceeded")
+	ErrCancelled = errors.New("evaluation cancelled")
+)
+
+/*
+Eval runs the source in a fresh interpreter and calls emit for every event, the last one is the result or an error.
+The time, step, output and depth limits are checked before every node is evaluated, the size limits
+are checked by the operations themselves so `range(2000000000)`, `x = x * x` or `str(a)` of an array
+holding itself on every level cannot run for long between two nodes.
+*/
+func Eval(ctx context.Context, req Request, limits Limits, emit func(Event)) {
+	p := parser.New(lexer.New(req.Source))
+	program := p.ParseProgram()
+
+	if errs := p.DetailedErrors(); len(errs) != 0 {
+		emit(Event{Type: ERROR_EVENT, Message: errs[0].Msg, Line: errs[0].Line, Column: errs[0].Column})
+		return
+	}
+
+	if limits.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limits.Timeout)
+		defer cancel()
+	}
+
+	l := &limiter{ctx: ctx, limits: limits}
+	out := &outputWriter{limiter: l, emit: emit}
+
+	env := object.NewEnvironment()
+	runtime := env.Runtime()
+	runtime.Output = out
+	runtime.Input = strings.NewReader(req.Input)
+	runtime.Limits = object.Limits{
+		MaxIntegerBits:   limits.MaxIntegerBits,
+		MaxStringLength:  limits.MaxStringLength,
+		MaxRangeLength:   limits.MaxRangeLength,
+		MaxDecimalDigits: limits.MaxDecimalDigits,
+	}
+	runtime.Hooks = &object.Hooks{
+		BeforeNode:    l.beforeNode,
+		EnterFunction: func(*object.Function, []object.Object, *object.Environment) { l.depth++ },
+		ExitFunction:  func(*object.Function, object.Object, *object.Environment) { l.depth-- },
+	}
+
+	result := evaluator.Eval(program, env)
+
+	if l.err != nil {
+		emit(Event{Type: ERROR_EVENT, Message: l.err.Error(), Line: l.line, Column: l.column})
+		return
+	}
+
+	if err, ok := result.(*object.Error); ok {
+		emit(Event{Type: ERROR_EVENT, Message: err.Msg, Line: l.line, Column: l.column})
+		return
+	}
+
+	if result == nil {
+		result = &object.Null{}
+	}
+
+	// The value is printed like the output of the program, so it is bound by the same limit
+	value, ok := object.InspectLimit(result, limits.MaxOutput)
+
+	if !ok {
+		emit(Event{Type: ERROR_EVENT, Message: ErrOutput.Error(), Line: l.line, Column: l.column})
+		return
+	}
+
+	emit(Event{Type: RESULT_EVENT, Value: value, ValueType: result.Type()})
+}
+
+// limiter stops a program when it goes over its limits, it is only used by the goroutine that runs the program
+type limiter struct {
+	ctx    context.Context
+	limits Limits
+	steps  int
+	depth  int
+	output int
+	err    error
+	line   int // Position of the last statement, used to point at runtime errors
+	column int
+}
+
+func (l *limiter) beforeNode(node ast.Node, env *object.Environment) *object.Error {
+	if l.err != nil {
+		return &object.Error{Msg: l.err.Error()}
+	}
+
+	l.steps++
+
+	if tok, ok := ast.StatementToken(node); ok {
+		l.line, l.column = tok.Line, tok.Column
+	}
+
+	switch {
+	case l.limits.MaxSteps > 0 && l.steps > l.limits.MaxSteps:
+		l.err = ErrSteps
+
+	case l.limits.MaxDepth > 0 && l.depth > l.limits.MaxDepth:
+		l.err = ErrDepth
+
+	case l.limits.MaxOutput > 0 && l.output > l.limits.MaxOutput:
+		l.err = ErrOutput
+
+	// Checking the clock and the context is slower so it is only done every few steps
+	case l.steps%256 == 0:
+		l.err = l.checkContext()
+	}
+
+	if l.err != nil {
+		return &object.Error{Msg: l.err.Error()}
+	}
+
+	return nil
+}
+
+/*
+checkContext compares the deadline with the clock itself instead of waiting for the context timer,
+under WebAssembly the program runs on the only thread and the timer never gets a chance to fire.
+*/
+func (l *limiter) checkContext() error {
+	if deadline, ok := l.ctx.Deadline(); ok && time.Now().After(deadline) {
+		return ErrTimeout
+	}
+
+	switch err := l.ctx.Err(); {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrTimeout
+
+	case err != nil:
+		return ErrCancelled
+	}
+
+	return nil
+}
+
+// outputWriter streams what the program prints, up to the output limit
+type outputWriter struct {
+	limiter *limiter
+	emit    func(Event)
+}
+
+func (w *outputWriter) Write(p []byte) (int, error) {
+	limit := w.limiter.limits.MaxOutput
+	text := string(p)
+
+	if limit > 0 && w.limiter.output+len(text) > limit {
+		text = text[:max(limit-w.limiter.output, 0)]
+	}
+
+	w.limiter.output += len(p)
+
+	if text != "" {
+		w.emit(Event{Type: OUTPUT_EVENT, Text: text})
+	}
+
+	return len(p), nil
+}
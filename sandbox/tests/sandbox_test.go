@@ -0,0 +1,190 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mostafa-DE/delang/sandbox"
+)
+
+func evalEvents(source string, input string, limits sandbox.Limits) []sandbox.Event {
+	var events []sandbox.Event
+
+	sandbox.Eval(context.Background(), sandbox.Request{Source: source, Input: input}, limits, func(event sandbox.Event) {
+		events = append(events, event)
+	})
+
+	return events
+}
+
+func lastEvent(t *testing.T, events []sandbox.Event) sandbox.Event {
+	t.Helper()
+
+	if len(events) == 0 {
+		t.Fatalf("no events")
+	}
+
+	return events[len(events)-1]
+}
+
+func TestEvalResultAndOutput(t *testing.T) {
+	events := evalEvents(`logs("hello"); let x = 2; x * 21;`, "", sandbox.DefaultLimits)
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %+v", events)
+	}
+
+	if events[0].Type != sandbox.OUTPUT_EVENT || events[0].Text != "'hello'\n" {
+		t.Errorf("expected the output event first, got %+v", events[0])
+	}
+
+	if events[1].Type != sandbox.RESULT_EVENT || events[1].Value != "42" || events[1].ValueType != "INTEGER" {
+		t.Errorf("unexpected result %+v", events[1])
+	}
+}
+
+func TestEvalInput(t *testing.T) {
+	event := lastEvent(t, evalEvents(`let name = input(); "Hello " + name;`, "DE\n", sandbox.DefaultLimits))
+
+	if event.Type != sandbox.RESULT_EVENT || event.Value != "Hello DE" {
+		t.Errorf("unexpected result %+v", event)
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	tests := []struct {
+		source  string
+		message string
+		line    int
+	}{
+		{"let x = 1;\nlet = 2;", "Expected next token to be 'IDENT'", 2},
+		{"let x = 1;\nlet y = x + true;", "type mismatch", 2},
+		{"let x = 1;\n\nunknown;", "identifier not found", 3},
+	}
+
+	for _, tt := range tests {
+		event := lastEvent(t, evalEvents(tt.source, "", sandbox.DefaultLimits))
+
+		if event.Type != sandbox.ERROR_EVENT || !strings.Contains(event.Message, tt.message) {
+			t.Errorf("%q: expected an error containing %q, got %+v", tt.source, tt.message, event)
+		}
+
+		if event.Line != tt.line || event.Column == 0 {
+			t.Errorf("%q: expected the error on line %d with a column, got %d:%d", tt.source, tt.line, event.Line, event.Column)
+		}
+	}
+}
+
+func TestEvalLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		limits sandbox.Limits
+		err    error
+	}{
+		{"steps", "during true: { 1; }", sandbox.Limits{MaxSteps: 1000}, sandbox.ErrSteps},
+		{"timeout", "during true: { 1; }", sandbox.Limits{Timeout: 50 * time.Millisecond}, sandbox.ErrTimeout},
+		{"output", `during true: { logs("spam"); }`, sandbox.Limits{MaxOutput: 100}, sandbox.ErrOutput},
+		{"depth", "let f = fun(n) { f(n + 1) }; f(0);", sandbox.Limits{MaxDepth: 100}, sandbox.ErrDepth},
+	}
+
+	for _, tt := range tests {
+		events := evalEvents(tt.source, "", tt.limits)
+		event := lastEvent(t, events)
+
+		if event.Type != sandbox.ERROR_EVENT || event.Message != tt.err.Error() {
+			t.Errorf("%s: expected %q, got %+v", tt.name, tt.err, event)
+		}
+
+		if tt.name == "output" {
+			printed := 0
+
+			for _, event := range events {
+				printed += len(event.Text)
+			}
+
+			if printed != 100 {
+				t.Errorf("expected the output to be cut at 100 bytes, got %d", printed)
+			}
+		}
+	}
+}
+
+func TestEvalTightLoops(t *testing.T) {
+	tests := []string{
+		"during true: { }",
+		"let x = 0; during true: { x = 1; }",
+	}
+
+	for _, source := range tests {
+		done := make(chan sandbox.Event, 1)
+
+		go func() {
+			done <- lastEvent(t, evalEvents(source, "", sandbox.Limits{Timeout: 50 * time.Millisecond}))
+		}()
+
+		select {
+		case event := <-done:
+			if event.Type != sandbox.ERROR_EVENT || event.Message != sandbox.ErrTimeout.Error() {
+				t.Errorf("%q: expected %q, got %+v", source, sandbox.ErrTimeout, event)
+			}
+
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%q: the time limit did not stop the loop", source)
+		}
+	}
+}
+
+func TestEvalSizeLimits(t *testing.T) {
+	limits := sandbox.DefaultLimits
+	limits.Timeout = 500 * time.Millisecond
+
+	tests := []struct {
+		source  string
+		message string
+	}{
+		{"let x = 3; during true: { x = x * x; }", "integer too large, the limit is 65536 bits"},
+		{"range(2000000000)", "range of 2000000000 elements is too long, the limit is 1000000 elements"},
+		{"range(-2000000000, 0)", "range of 2000000000 elements is too long, the limit is 1000000 elements"},
+		{`let s = "ab"; during true: { s = s + s; }`, "string too long, the limit is 1048576 bytes"},
+		{`let s = "ab"; during true: { s = s.replace("", s); }`, "string too long, the limit is 1048576 bytes"},
+		{`let s = "ab"; during true: { s = [s, s, s].join(s); }`, "string too long, the limit is 1048576 bytes"},
+		// Every level prints the one below twice, the text is only built up to the limit
+		{"let a = [1]; for i in range(26): { a = [a, a]; } len(str(a))", "string too long, the limit is 1048576 bytes"},
+		{"let a = [1]; for i in range(26): { a = [a, a]; } logs(a)", "string too long, the limit is 1048576 bytes"},
+		{"let a = [1]; for i in range(26): { a = [a, a]; } [a].join(\",\")", "string too long, the limit is 1048576 bytes"},
+		{"let a = [1]; for i in range(26): { a = [a, a]; } a", sandbox.ErrOutput.Error()},
+		{"let d = 1.1d; for i in range(40): { d = d * d; }", "decimal too large, the limit is 20000 digits"},
+		{"let d = 1e100000000d; d + 1d", "decimal too large, the limit is 20000 digits"},
+	}
+
+	for _, tt := range tests {
+		start := time.Now()
+		event := lastEvent(t, evalEvents(tt.source, "", limits))
+
+		if event.Type != sandbox.ERROR_EVENT || event.Message != tt.message {
+			t.Errorf("%q: expected %q, got %+v", tt.source, tt.message, event)
+		}
+
+		if elapsed := time.Since(start); elapsed > 2*limits.Timeout {
+			t.Errorf("%q: expected the program to stop within the time limit, it ran for %s", tt.source, elapsed)
+		}
+	}
+}
+
+func TestEvalCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var last sandbox.Event
+
+	sandbox.Eval(ctx, sandbox.Request{Source: "during true: { 1; }"}, sandbox.Limits{}, func(event sandbox.Event) {
+		last = event
+	})
+
+	if last.Message != sandbox.ErrCancelled.Error() {
+		t.Errorf("expected the evaluation to be cancelled, got %+v", last)
+	}
+}
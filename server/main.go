@@ -0,0 +1,142 @@
+package server
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/Mostafa-DE/delang/sandbox"
+)
+
+const MAX_SOURCE_SIZE = 1 << 20 // Bytes of source code accepted in one request
+
+/*
+Server runs DE programs for the playground:
+
+- POST /eval takes a sandbox.Request as JSON and streams the events back as newline-delimited JSON.
+- GET /ws upgrades to a WebSocket, every text message is a sandbox.Request and every event is sent as a text message.
+
+Every request gets its own interpreter so requests can run concurrently.
+*/
+type Server struct {
+	limits sandbox.Limits
+	mux    *http.ServeMux
+}
+
+func New(limits sandbox.Limits) *Server {
+	s := &Server{limits: limits, mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("/eval", s.handleEval)
+	s.mux.HandleFunc("/ws", s.handleWebSocket)
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The playground is served from another origin
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleEval(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req sandbox.Request
+
+	if err := json.NewDecoder(io.LimitReader(r.Body, MAX_SOURCE_SIZE)).Decode(&req); err != nil {
+		http.Error(w, "Invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	encoder := json.NewEncoder(w)
+	flusher, _ := w.(http.Flusher)
+
+	sandbox.Eval(r.Context(), req, s.limits, func(event sandbox.Event) {
+		encoder.Encode(event)
+
+		if flusher != nil {
+			flusher.Flush()
+		}
+	})
+}
+
+func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	ws, err := upgrade(w, r)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	defer ws.Close()
+
+	for {
+		message, err := ws.ReadMessage()
+
+		if err != nil {
+			return
+		}
+
+		var req sandbox.Request
+
+		if err := json.Unmarshal(message, &req); err != nil {
+			ws.WriteJSON(sandbox.Event{Type: sandbox.ERROR_EVENT, Message: "Invalid request: " + err.Error()})
+			continue
+		}
+
+		sandbox.Eval(r.Context(), req, s.limits, func(event sandbox.Event) {
+			ws.WriteJSON(event)
+		})
+	}
+}
+
+// Run is the entry point of `de serve`
+func Run() {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+
+	addr := flags.String("addr", ":8080", "Address to listen on")
+	timeout := flags.Duration("timeout", sandbox.DefaultLimits.Timeout, "Time limit of a program")
+	maxSteps := flags.Int("max-steps", sandbox.DefaultLimits.MaxSteps, "Maximum number of evaluated nodes of a program, 0 for no limit")
+	maxOutput := flags.Int("max-output", sandbox.DefaultLimits.MaxOutput, "Maximum number of bytes a program can print, 0 for no limit")
+	maxDepth := flags.Int("max-depth", sandbox.DefaultLimits.MaxDepth, "Maximum depth of nested function calls, 0 for no limit")
+	maxIntegerBits := flags.Int("max-integer-bits", sandbox.DefaultLimits.MaxIntegerBits, "Maximum number of bits of an integer, 0 for no limit")
+	maxStringLength := flags.Int("max-string-length", sandbox.DefaultLimits.MaxStringLength, "Maximum number of bytes of a string, 0 for no limit")
+	maxRangeLength := flags.Int("max-range-length", sandbox.DefaultLimits.MaxRangeLength, "Maximum number of elements of range(), 0 for no limit")
+	maxDecimalDigits := flags.Int("max-decimal-digits", sandbox.DefaultLimits.MaxDecimalDigits, "Maximum number of digits of a decimal, 0 for no limit")
+
+	flags.Parse(os.Args[2:])
+
+	limits := sandbox.Limits{
+		Timeout:          *timeout,
+		MaxSteps:         *maxSteps,
+		MaxOutput:        *maxOutput,
+		MaxDepth:         *maxDepth,
+		MaxIntegerBits:   *maxIntegerBits,
+		MaxStringLength:  *maxStringLength,
+		MaxRangeLength:   *maxRangeLength,
+		MaxDecimalDigits: *maxDecimalDigits,
+	}
+
+	fmt.Printf("Listening on %s\n", *addr)
+
+	if err := http.ListenAndServe(*addr, New(limits)); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
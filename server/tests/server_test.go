@@ -0,0 +1,246 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mostafa-DE/delang/sandbox"
+	"github.com/Mostafa-DE/delang/server"
+)
+
+func postEval(t *testing.T, url string, source string) []sandbox.Event {
+	t.Helper()
+
+	body, _ := json.Marshal(sandbox.Request{Source: source})
+	resp, err := http.Post(url+"/eval", "application/json", bytes.NewReader(body))
+
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	if resp.Header.Get("Access-Control-Allow-Origin") != "*" {
+		t.Errorf("expected the CORS header to be set")
+	}
+
+	var events []sandbox.Event
+	decoder := json.NewDecoder(resp.Body)
+
+	for {
+		var event sandbox.Event
+
+		if err := decoder.Decode(&event); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("invalid event: %v", err)
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func TestHTTPEval(t *testing.T) {
+	ts := httptest.NewServer(server.New(sandbox.DefaultLimits))
+	defer ts.Close()
+
+	events := postEval(t, ts.URL, `logs(1); logs(2); 3;`)
+
+	if len(events) != 3 || events[0].Text != "1\n" || events[1].Text != "2\n" || events[2].Value != "3" {
+		t.Errorf("unexpected events %+v", events)
+	}
+
+	resp, err := http.Get(ts.URL + "/eval")
+
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected GET /eval to be rejected, got %d", resp.StatusCode)
+	}
+
+	resp, err = http.Post(ts.URL+"/eval", "application/json", strings.NewReader("{"))
+
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected an invalid body to be rejected, got %d", resp.StatusCode)
+	}
+}
+
+func TestHTTPConcurrentRequests(t *testing.T) {
+	ts := httptest.NewServer(server.New(sandbox.DefaultLimits))
+	defer ts.Close()
+
+	var wg sync.WaitGroup
+
+	for idx := range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			source := fmt.Sprintf("let total = 0; for i, n in range(%d): { total = total + n; }; logs(total); 10 / 4.0;", 100+idx)
+			events := postEval(t, ts.URL, source)
+			want := fmt.Sprintf("%d\n", (99+idx)*(100+idx)/2)
+
+			if len(events) != 2 || events[0].Text != want || events[1].Value != "2.5" {
+				t.Errorf("request %d: unexpected events %+v", idx, events)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// A tiny WebSocket client, enough to talk to the server
+type wsClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dialWebSocket(t *testing.T, url string) *wsClient {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(url, "http://"))
+
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	fmt.Fprintf(conn, "GET /ws HTTP/1.1\r\nHost: localhost\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Key: %s\r\nSec-WebSocket-Version: 13\r\n\r\n", key)
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+
+	if err != nil {
+		t.Fatalf("handshake failed: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("expected status 101, got %d", resp.StatusCode)
+	}
+
+	if resp.Header.Get("Sec-WebSocket-Accept") != server.AcceptKey(key) {
+		t.Fatalf("unexpected Sec-WebSocket-Accept %q", resp.Header.Get("Sec-WebSocket-Accept"))
+	}
+
+	return &wsClient{conn: conn, reader: reader}
+}
+
+func (c *wsClient) send(t *testing.T, payload []byte) {
+	t.Helper()
+
+	frame := []byte{0x80 | server.TEXT_FRAME}
+
+	if len(payload) < 126 {
+		frame = append(frame, 0x80|byte(len(payload)))
+	} else {
+		frame = append(frame, 0x80|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(len(payload)))
+	}
+
+	mask := []byte{1, 2, 3, 4}
+	frame = append(frame, mask...)
+
+	for idx, b := range payload {
+		frame = append(frame, b^mask[idx%4])
+	}
+
+	if _, err := c.conn.Write(frame); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+}
+
+func (c *wsClient) receive(t *testing.T) sandbox.Event {
+	t.Helper()
+
+	c.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	header := make([]byte, 2)
+
+	if _, err := io.ReadFull(c.reader, header); err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+
+	length := int(header[1] & 0x7F)
+
+	if length == 126 {
+		extended := make([]byte, 2)
+		io.ReadFull(c.reader, extended)
+		length = int(binary.BigEndian.Uint16(extended))
+	}
+
+	payload := make([]byte, length)
+
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+
+	var event sandbox.Event
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		t.Fatalf("invalid event %q: %v", payload, err)
+	}
+
+	return event
+}
+
+func TestWebSocket(t *testing.T) {
+	ts := httptest.NewServer(server.New(sandbox.DefaultLimits))
+	defer ts.Close()
+
+	client := dialWebSocket(t, ts.URL)
+	defer client.conn.Close()
+
+	client.send(t, []byte(`{"source": "logs(\"hi\"); 1 + 1;"}`))
+
+	if event := client.receive(t); event.Type != sandbox.OUTPUT_EVENT || event.Text != "'hi'\n" {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	if event := client.receive(t); event.Type != sandbox.RESULT_EVENT || event.Value != "2" {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	// The connection stays open for more programs, larger messages use the extended length
+	source, _ := json.Marshal(sandbox.Request{Source: strings.Repeat("1; ", 100) + "x;"})
+	client.send(t, source)
+
+	if event := client.receive(t); event.Type != sandbox.ERROR_EVENT || !strings.Contains(event.Message, "identifier not found") {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	client.send(t, []byte("not json"))
+
+	if event := client.receive(t); event.Type != sandbox.ERROR_EVENT || !strings.HasPrefix(event.Message, "Invalid request") {
+		t.Errorf("unexpected event %+v", event)
+	}
+}
@@ -0,0 +1,229 @@
+package server
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// A minimal WebSocket (RFC 6455) server side, enough for text messages
+
+const WEBSOCKET_GUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// Opcodes
+const (
+	CONTINUATION_FRAME = 0x0
+	TEXT_FRAME         = 0x1
+	BINARY_FRAME       = 0x2
+	CLOSE_FRAME        = 0x8
+	PING_FRAME         = 0x9
+	PONG_FRAME         = 0xA
+)
+
+type webSocket struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	writeMu sync.Mutex
+}
+
+// AcceptKey returns the Sec-WebSocket-Accept value for a Sec-WebSocket-Key
+func AcceptKey(key string) string {
+	hash := sha1.Sum([]byte(key + WEBSOCKET_GUID))
+
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+func headerContains(r *http.Request, name string, value string) bool {
+	for _, part := range strings.Split(r.Header.Get(name), ",") {
+		if strings.EqualFold(strings.TrimSpace(part), value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func upgrade(w http.ResponseWriter, r *http.Request) (*webSocket, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+
+	if r.Method != http.MethodGet || !headerContains(r, "Connection", "upgrade") || !headerContains(r, "Upgrade", "websocket") || key == "" {
+		return nil, errors.New("Expected a WebSocket handshake")
+	}
+
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		return nil, errors.New("Unsupported WebSocket version")
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+
+	if !ok {
+		return nil, errors.New("The connection cannot be upgraded")
+	}
+
+	conn, rw, err := hijacker.Hijack()
+
+	if err != nil {
+		return nil, err
+	}
+
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n")
+	rw.WriteString("Upgrade: websocket\r\n")
+	rw.WriteString("Connection: Upgrade\r\n")
+	rw.WriteString("Sec-WebSocket-Accept: " + AcceptKey(key) + "\r\n\r\n")
+
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &webSocket{conn: conn, reader: rw.Reader}, nil
+}
+
+// ReadMessage returns the next text or binary message, it answers pings and fails once the client closes
+func (ws *webSocket) ReadMessage() ([]byte, error) {
+	var message []byte
+
+	for {
+		fin, opcode, payload, err := ws.readFrame()
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case CLOSE_FRAME:
+			ws.writeFrame(CLOSE_FRAME, payload)
+			return nil, io.EOF
+
+		case PING_FRAME:
+			ws.writeFrame(PONG_FRAME, payload)
+			continue
+
+		case PONG_FRAME:
+			continue
+		}
+
+		message = append(message, payload...)
+
+		if len(message) > MAX_SOURCE_SIZE {
+			return nil, errors.New("message too large")
+		}
+
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+func (ws *webSocket) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	header := make([]byte, 2)
+
+	if _, err = io.ReadFull(ws.reader, header); err != nil {
+		return
+	}
+
+	fin = header[0]&0x80 != 0
+	opcode = header[0] & 0x0F
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7F)
+
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+
+		if _, err = io.ReadFull(ws.reader, extended); err != nil {
+			return
+		}
+
+		length = uint64(binary.BigEndian.Uint16(extended))
+
+	case 127:
+		extended := make([]byte, 8)
+
+		if _, err = io.ReadFull(ws.reader, extended); err != nil {
+			return
+		}
+
+		length = binary.BigEndian.Uint64(extended)
+	}
+
+	if length > MAX_SOURCE_SIZE {
+		err = errors.New("frame too large")
+		return
+	}
+
+	// Clients must mask every frame they send
+	if !masked {
+		err = errors.New("unmasked frame from the client")
+		return
+	}
+
+	mask := make([]byte, 4)
+
+	if _, err = io.ReadFull(ws.reader, mask); err != nil {
+		return
+	}
+
+	payload = make([]byte, length)
+
+	if _, err = io.ReadFull(ws.reader, payload); err != nil {
+		return
+	}
+
+	for idx := range payload {
+		payload[idx] ^= mask[idx%4]
+	}
+
+	return
+}
+
+func (ws *webSocket) writeFrame(opcode byte, payload []byte) error {
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+
+	header := []byte{0x80 | opcode}
+	length := len(payload)
+
+	switch {
+	case length < 126:
+		header = append(header, byte(length))
+
+	case length <= 0xFFFF:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(length))
+
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(length))
+	}
+
+	if _, err := ws.conn.Write(append(header, payload...)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ws *webSocket) WriteJSON(value interface{}) error {
+	data, err := json.Marshal(value)
+
+	if err != nil {
+		return err
+	}
+
+	return ws.writeFrame(TEXT_FRAME, data)
+}
+
+func (ws *webSocket) Close() error {
+	ws.writeFrame(CLOSE_FRAME, nil)
+
+	return ws.conn.Close()
+}
@@ -0,0 +1,16 @@
+// Command gen writes the keyword and operator reference of DE to TOKENS.md, it is run by `go generate ./token`
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Mostafa-DE/delang/token"
+)
+
+func main() {
+	if err := os.WriteFile("TOKENS.md", []byte(token.Reference()), 0o644); err != nil {
+		fmt.Println("Error writing TOKENS.md:", err)
+		os.Exit(1)
+	}
+}
@@ -1,10 +1,14 @@
 package token
 
+import "sort"
+
 type TokenType string
 
 type Token struct {
 	Type    TokenType
 	Literal string
+	Line    int // 1-based line of the first character of the token
+	Column  int // 1-based byte column of the first character of the token
 }
 
 const (
@@ -12,10 +16,11 @@ const (
 	EOFILE  = "EOFILE"  // Tells the parser that it should stop
 
 	// Identifiers + literals
-	IDENT  = "IDENT" // add, foobar, x, y, ...
-	INT    = "INT"   // 1343456
-	FLOAT  = "FLOAT" // 1.234
-	STRING = "STRING"
+	IDENT   = "IDENT"   // add, foobar, x, y, ...
+	INT     = "INT"     // 1343456, 1_000, 0xFF, 0b1010, 0o755
+	FLOAT   = "FLOAT"   // 1.234, 6.02e23
+	DECIMAL = "DECIMAL" // 19.99d
+	STRING  = "STRING"
 
 	// Operators
 	ASSIGN        = "="
@@ -31,10 +36,22 @@ const (
 	EQUAL         = "=="
 	NOTEQUAL      = "!="
 	MOD           = "%"
+	QUESTION      = "?"  // cond ? a : b
+	NULLISH       = "??" // a ?? b
+
+	// Assignment operators
+	PLUS_ASSIGN     = "+="
+	MINUS_ASSIGN    = "-="
+	ASTERISK_ASSIGN = "*="
+	SLASH_ASSIGN    = "/="
+	MOD_ASSIGN      = "%="
+	INCREMENT       = "++"
+	DECREMENT       = "--"
 
 	// Logical Operators
 	AND = "and"
 	OR  = "or"
+	NOT = "NOT" // the keyword form of !, it binds looser than comparisons
 
 	// Delimiters
 	COMMA       = ","
@@ -47,6 +64,13 @@ const (
 	LEFTSQPRAC  = "["
 	RIGHTSQPRAC = "]"
 	UNDERSCORE  = "_"
+	DOT         = "."
+	ARROW       = "=>"
+	ELLIPSIS    = "..."
+
+	// Optional access, `?[` must be written without a space, `c ? [1] : []` is a conditional
+	OPTIONAL_DOT   = "?."
+	OPTIONAL_INDEX = "?["
 
 	// Keywords
 	FUNCTION = "FUNCTION"
@@ -62,22 +86,20 @@ const (
 	SKIP     = "SKIP"
 	FOR      = "FOR"
 	IN       = "IN"
+	MATCH    = "MATCH"
 )
 
-var keywords = map[string]TokenType{
-	"fun":    FUNCTION,
-	"let":    LET,
-	"const":  CONST,
-	"true":   TRUE,
-	"false":  FALSE,
-	"if":     IF,
-	"else":   ELSE,
-	"return": RETURN,
-	"during": DURING,
-	"break":  BREAK,
-	"skip":   SKIP,
-	"for":    FOR,
-	"in":     IN,
+// Keywords returns the reserved words of the language sorted alphabetically
+func Keywords() []string {
+	words := make([]string, 0, len(keywords))
+
+	for word := range keywords {
+		words = append(words, word)
+	}
+
+	sort.Strings(words)
+
+	return words
 }
 
 func LookupIdent(ident string) TokenType {
@@ -0,0 +1,119 @@
+package token
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go run ./gen
+
+// Spec documents a keyword or an operator of the language, tools like editors and the REPL read it through KeywordSpecs and OperatorSpecs
+type Spec struct {
+	Literal string    // How it is written in the source
+	Type    TokenType // The type of the token the lexer gives for it
+	Doc     string
+}
+
+// keywordSpecs are the reserved words, the lexer reads a whole identifier before it looks it up here so `order` is not `or`
+var keywordSpecs = []Spec{
+	{"and", AND, "Logical and, the right side is only evaluated when the left side is truthy"},
+	{"break", BREAK, "Leaves the innermost loop"},
+	{"const", CONST, "Declares a variable that cannot be reassigned"},
+	{"during", DURING, "Loops while a condition is truthy"},
+	{"else", ELSE, "The branch of an if that runs when the condition is falsy"},
+	{"false", FALSE, "The boolean false"},
+	{"for", FOR, "Loops over the elements of an array, the characters of a string or an iterable host object"},
+	{"fun", FUNCTION, "Declares a function"},
+	{"if", IF, "Runs a block when a condition is truthy, it is also the guard of a match arm"},
+	{"in", IN, "Separates the variables of a for loop from what it loops over"},
+	{"let", LET, "Declares a variable"},
+	{"match", MATCH, "Picks the first arm whose pattern matches a value"},
+	{"not", NOT, "Logical not, it binds looser than comparisons so `not a == b` is `not (a == b)`"},
+	{"or", OR, "Logical or, the right side is only evaluated when the left side is falsy"},
+	{"return", RETURN, "Returns a value from a function"},
+	{"skip", SKIP, "Skips to the next iteration of the innermost loop"},
+	{"true", TRUE, "The boolean true"},
+}
+
+// operatorSpecs are the operators and the delimiters, in the order of the reference
+var operatorSpecs = []Spec{
+	{"+", PLUS, "Addition, also joins strings"},
+	{"-", MINUS, "Subtraction or negation"},
+	{"*", ASTERISK, "Multiplication"},
+	{"/", SLASH, "Division"},
+	{"%", MOD, "Remainder"},
+	{"==", EQUAL, "Equal"},
+	{"!=", NOTEQUAL, "Not equal"},
+	{"<", LESSTHAN, "Less than"},
+	{">", GREATERTHAN, "Greater than"},
+	{"<=", LESSTHANEQ, "Less than or equal"},
+	{">=", GREATERTHANEQ, "Greater than or equal"},
+	{"!", EXCLAMATION, "Logical not"},
+	{"?", QUESTION, "Conditional expression `cond ? a : b`"},
+	{"??", NULLISH, "Null coalescing, `a ?? b` is b only when a is null"},
+	{"?.", OPTIONAL_DOT, "Optional member access, null when the object is null"},
+	{"?[", OPTIONAL_INDEX, "Optional index, null when the indexed value is null"},
+	{"=", ASSIGN, "Assignment"},
+	{"+=", PLUS_ASSIGN, "Adds to a variable, an index or a member"},
+	{"-=", MINUS_ASSIGN, "Subtracts from a variable, an index or a member"},
+	{"*=", ASTERISK_ASSIGN, "Multiplies a variable, an index or a member"},
+	{"/=", SLASH_ASSIGN, "Divides a variable, an index or a member"},
+	{"%=", MOD_ASSIGN, "Sets a variable, an index or a member to its remainder"},
+	{"++", INCREMENT, "Adds one to a variable, an index or a member"},
+	{"--", DECREMENT, "Subtracts one from a variable, an index or a member"},
+	{"=>", ARROW, "Separates the pattern of a match arm from its body"},
+	{"...", ELLIPSIS, "Rest parameter, rest element of a pattern or spread argument"},
+	{".", DOT, "Member access"},
+	{",", COMMA, "Separates elements, arguments and parameters"},
+	{";", SEMICOLON, "Ends a statement"},
+	{":", COLON, "Starts the block of if, during and for, separates the keys and values of a hash"},
+	{"(", LEFTPAR, "Opens a group, a call or a parameter list"},
+	{")", RIGHTPAR, "Closes a group, a call or a parameter list"},
+	{"{", LEFTBRAC, "Opens a block or a hash"},
+	{"}", RIGHTBRAC, "Closes a block or a hash"},
+	{"[", LEFTSQPRAC, "Opens an array or an index"},
+	{"]", RIGHTSQPRAC, "Closes an array or an index"},
+}
+
+var keywords = func() map[string]TokenType {
+	words := make(map[string]TokenType, len(keywordSpecs))
+
+	for _, spec := range keywordSpecs {
+		words[spec.Literal] = spec.Type
+	}
+
+	return words
+}()
+
+// KeywordSpecs returns the documented keywords sorted alphabetically
+func KeywordSpecs() []Spec {
+	return append([]Spec{}, keywordSpecs...)
+}
+
+// OperatorSpecs returns the documented operators and delimiters
+func OperatorSpecs() []Spec {
+	return append([]Spec{}, operatorSpecs...)
+}
+
+// Reference renders the keywords and the operators as markdown, `go generate ./token` writes it to TOKENS.md
+func Reference() string {
+	var out strings.Builder
+
+	out.WriteString("# DE keywords and operators\n\n")
+	out.WriteString("<!-- Generated by `go generate ./token` from token/spec.go, do not edit. -->\n")
+
+	writeTable := func(title string, specs []Spec) {
+		fmt.Fprintf(&out, "\n## %s\n\n", title)
+		out.WriteString("| Token | Type | Description |\n")
+		out.WriteString("| --- | --- | --- |\n")
+
+		for _, spec := range specs {
+			fmt.Fprintf(&out, "| `%s` | %s | %s |\n", spec.Literal, spec.Type, spec.Doc)
+		}
+	}
+
+	writeTable("Keywords", keywordSpecs)
+	writeTable("Operators and delimiters", operatorSpecs)
+
+	return out.String()
+}
@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Mostafa-DE/delang/lexer"
+	"github.com/Mostafa-DE/delang/token"
+)
+
+func TestReferenceIsGenerated(t *testing.T) {
+	content, err := os.ReadFile("../TOKENS.md")
+
+	if err != nil {
+		t.Fatalf("could not read TOKENS.md: %s", err)
+	}
+
+	if string(content) != token.Reference() {
+		t.Errorf("TOKENS.md is out of date, run `go generate ./token`")
+	}
+}
+
+func TestKeywordSpecs(t *testing.T) {
+	// Written by hand so that adding, dropping or retyping a keyword has to be done on purpose
+	expected := []struct {
+		literal   string
+		tokenType token.TokenType
+	}{
+		{"and", token.AND},
+		{"break", token.BREAK},
+		{"const", token.CONST},
+		{"during", token.DURING},
+		{"else", token.ELSE},
+		{"false", token.FALSE},
+		{"for", token.FOR},
+		{"fun", token.FUNCTION},
+		{"if", token.IF},
+		{"in", token.IN},
+		{"let", token.LET},
+		{"match", token.MATCH},
+		{"not", token.NOT},
+		{"or", token.OR},
+		{"return", token.RETURN},
+		{"skip", token.SKIP},
+		{"true", token.TRUE},
+	}
+
+	specs := token.KeywordSpecs()
+
+	if len(specs) != len(expected) {
+		t.Fatalf("expected %d keyword specs, got=%d", len(expected), len(specs))
+	}
+
+	for idx, tt := range expected {
+		spec := specs[idx]
+
+		if spec.Literal != tt.literal || spec.Type != tt.tokenType {
+			t.Errorf("spec %d expected=%q %s, got=%q %s", idx, tt.literal, tt.tokenType, spec.Literal, spec.Type)
+		}
+
+		if spec.Doc == "" {
+			t.Errorf("keyword %q has no documentation", tt.literal)
+		}
+
+		// What the lexer really produces, with an identifier after it to check the keyword ends at its boundary
+		l := lexer.New(tt.literal + " " + tt.literal + "x")
+
+		if tok := l.NextToken(); tok.Type != tt.tokenType || tok.Literal != tt.literal {
+			t.Errorf("lexing %q expected=%s, got=%s %q", tt.literal, tt.tokenType, tok.Type, tok.Literal)
+		}
+
+		if tok := l.NextToken(); tok.Type != token.IDENT {
+			t.Errorf("lexing %q expected=%s, got=%s %q", tt.literal+"x", token.IDENT, tok.Type, tok.Literal)
+		}
+	}
+}
+
+func TestOperatorSpecs(t *testing.T) {
+	seen := map[token.TokenType]bool{}
+
+	for _, spec := range token.OperatorSpecs() {
+		if seen[spec.Type] {
+			t.Errorf("operator %s is documented twice", spec.Type)
+		}
+
+		seen[spec.Type] = true
+
+		if spec.Doc == "" {
+			t.Errorf("operator %q has no documentation", spec.Literal)
+		}
+	}
+}
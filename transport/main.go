@@ -0,0 +1,73 @@
+package transport
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"sync"
+)
+
+// MAX_CONTENT_LENGTH bounds the body of a message, a bogus header must not make Read allocate gigabytes
+const MAX_CONTENT_LENGTH = 64 << 20
+
+// Conn reads and writes JSON messages framed with the `Content-Length` header,
+// this is the framing used by both the Language Server Protocol and the Debug Adapter Protocol.
+type Conn struct {
+	reader *bufio.Reader
+	writer io.Writer
+	mu     sync.Mutex // Guards writer, messages can be sent from different goroutines
+}
+
+func NewConn(r io.Reader, w io.Writer) *Conn {
+	return &Conn{reader: bufio.NewReader(r), writer: w}
+}
+
+// Read returns the body of the next message
+func (c *Conn) Read() ([]byte, error) {
+	headers, err := textproto.NewReader(c.reader).ReadMIMEHeader()
+
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := strconv.Atoi(headers.Get("Content-Length"))
+
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid Content-Length header: %q", headers.Get("Content-Length"))
+	}
+
+	if length > MAX_CONTENT_LENGTH {
+		return nil, fmt.Errorf("Content-Length %d is larger than the maximum of %d bytes", length, MAX_CONTENT_LENGTH)
+	}
+
+	body := make([]byte, length)
+
+	if _, err := io.ReadFull(c.reader, body); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// Write encodes the value as JSON and writes it with its header
+func (c *Conn) Write(value interface{}) error {
+	body, err := json.Marshal(value)
+
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := fmt.Fprintf(c.writer, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+
+	_, err = c.writer.Write(body)
+
+	return err
+}
@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Mostafa-DE/delang/transport"
+)
+
+func TestReadWrite(t *testing.T) {
+	var buffer bytes.Buffer
+	conn := transport.NewConn(&buffer, &buffer)
+
+	if err := conn.Write(map[string]int{"id": 1}); err != nil {
+		t.Fatalf("Write failed: %s", err)
+	}
+
+	body, err := conn.Read()
+
+	if err != nil || string(body) != `{"id":1}` {
+		t.Errorf("expected the body written before, got %q (%v)", body, err)
+	}
+}
+
+func TestReadInvalidContentLength(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Content-Length: abc\r\n\r\n", `invalid Content-Length header: "abc"`},
+		{"Content-Length: -1\r\n\r\n", `invalid Content-Length header: "-1"`},
+		{"Content-Length: 99999999999\r\n\r\n", "Content-Length 99999999999 is larger than the maximum of 67108864 bytes"},
+	}
+
+	for _, tt := range tests {
+		_, err := transport.NewConn(strings.NewReader(tt.input), nil).Read()
+
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("%q: expected error %q, got %v", tt.input, tt.expected, err)
+		}
+	}
+}